@@ -6,10 +6,10 @@ package plsqlparser
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"unicode"
 
+	"github.com/UNO-SOFT/plsql-parser/internal/casefold"
 	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 )
@@ -20,6 +20,7 @@ import (
 //go:generate sh -c "[ -e PlSqlParser.g4 ] || wget https://github.com/antlr/grammars-v4/raw/master/sql/plsql/PlSqlParser.g4"
 //go:generate java -jar antlr-4.9.2-complete.jar -Dlanguage=Go -o plsql/ PlSqlLexer.g4 PlSqlParser.g4
 //go:generate sed -i -e "s/self\\./p./; s/PlSqlLexerBase/PlSqlBaseLexer/" plsql/plsql_lexer.go
+//go:generate sed -i -e "s/^\tl := new(PlSqlLexer)$/\tl := \\&PlSqlLexer{PlSqlBaseLexer: new(PlSqlBaseLexer)}/; /fmt\\.Printf(\"l=/d; s/^\tl\\.BaseLexer = antlr\\.NewBaseLexer(input)$/&\\n\tl.Virt = l \\/\\/ GetAllTokens calls NextToken of PlSqlBaseLexer, which the predicates depend on/" plsql/plsql_lexer.go
 //go:generate sed -i -e "s/self\\./p./; s/p\\.isVersion/p.IsVersion/; s/PlSqlParserBase/PlSqlBaseParser/" plsql/plsql_parser.go
 //go:generate sh -c "[ -e ./plsql/plsql_base_lexer.go ] || curl https://github.com/antlr/grammars-v4/raw/master/sql/plsql/Go/plsql_base_lexer.go | sed -e '/self/d; /input/ s/l\\.input/l.GetInputStream()/' >plsql/plsql_base_lexer.go"
 //go:generate sh -c "[ -e ./plsql/plsql_base_parser.go ] || (cd plsql && wget https://github.com/antlr/grammars-v4/raw/master/sql/plsql/Go/plsql_base_parser.go)"
@@ -38,17 +39,15 @@ var (
 )
 
 // NewPlSqlStringLexer returns a new *PlSqlLexer with an input stream set to the given text.
+//
+// The lexer sees the text uppercased, but the tokens keep the original text.
 func NewPlSqlStringLexer(text string) *plsql.PlSqlLexer {
-	return plsql.NewPlSqlLexer(antlr.NewInputStream(text))
+	return plsql.NewPlSqlLexer(casefold.NewStream(text))
 }
 
 // NewPlSqlLexerParser returns a new *PlSqlParser, including a PlSqlLexer with the given text.
 func NewPlSqlLexerParser(text string) *plsql.PlSqlParser {
-	input := antlr.NewInputStream(text)
-	if input == nil {
-		panic("input is nil")
-	}
-	lexer := plsql.NewPlSqlLexer(input)
+	lexer := NewPlSqlStringLexer(text)
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 	// Create the Parser
 	parser := plsql.NewPlSqlParser(stream)
@@ -62,11 +61,12 @@ func NewPlSqlParserListener() *BaseWalkListener {
 }
 
 // ParseToConvertMap parses the text into a ConvertMap (INSERT INTO with SELECT statements only).
-func ParseToConvertMap(text string) (ConvertMap, error) {
-	// Setup the input (which this parser expects to be uppercased).
+func ParseToConvertMap(text string, opts ...Options) (ConvertMap, error) {
+	// The lexer folds the case itself, but the ConvertMap has always held the uppercased text
+	// (and the INSERT prefix is trimmed from that), so the input is still uppercased.
 	text = strings.TrimPrefix(upper(strings.TrimSpace(text)), "INSERT ")
 
-	parser := NewPlSqlLexerParser(text)
+	parser := options(opts).newParser(text)
 
 	// Finally walk the tree
 	wl := &iiWalkListener{BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}}
@@ -106,13 +106,6 @@ func (wl *BaseWalkListener) AddError(err error) {
 	}
 }
 
-//func (wl *BaseWalkListener) EnterEveryRule(ctx antlr.ParserRuleContext) {
-//fmt.Println("ENTER", ctx.GetStart())
-//}
-//func (wl *BaseWalkListener) ExitEveryRule(ctx antlr.ParserRuleContext) {
-//fmt.Println("EXIT", ctx.GetStop())
-//}
-
 type iiWalkListener struct {
 	BaseWalkListener
 	ConvertMap
@@ -121,8 +114,6 @@ type iiWalkListener struct {
 }
 
 func (wl *iiWalkListener) ReportAmbiguity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, exact bool, ambigAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
-	//log.Printf("AMBIGUITY at %d:%d", startIndex, stopIndex)
-
 	wl.Ambiguity = append(wl.Ambiguity, [2]int{startIndex, stopIndex})
 }
 
@@ -144,19 +135,39 @@ func (wl *iiWalkListener) ExitInsert_into_clause(ctx *plsql.Insert_into_clauseCo
 	if wl.Table != "" {
 		return
 	}
-	wl.Table = ctx.General_table_ref().GetText()
-	for _, col := range ctx.INTO().GetChildren() {
-		log.Printf("col: %s %#v", col, col)
-		//wl.Fields = append(wl.Fields, tokenChunk(col.GetPayload()))
+	if tbl := ctx.General_table_ref(); tbl != nil {
+		wl.Table = tbl.GetText()
+	}
+	if pcl, ok := ctx.Paren_column_list().(*plsql.Paren_column_listContext); ok && pcl != nil {
+		if cl, ok := pcl.Column_list().(*plsql.Column_listContext); ok && cl != nil {
+			for _, col := range cl.AllColumn_name() {
+				wl.Fields = append(wl.Fields, tokenChunk(col))
+			}
+		}
 	}
 }
 
+// ctxChunk returns the text of the context, empty if it has no tokens
+// (such as an empty rule, or a rule missing because of a syntax error).
 func ctxChunk(ctx interface {
 	GetStart() antlr.Token
 	GetStop() antlr.Token
 }) Chunk {
-	t := Chunk{Start: ctx.GetStart().GetStart(), Stop: ctx.GetStop().GetStop()}
-	t.Text = ctx.GetStart().GetInputStream().GetText(t.Start, t.Stop)
+	return tokensChunk(ctx.GetStart(), ctx.GetStop())
+}
+
+// tokensChunk returns the text from the start of the start token to the end of the stop token.
+func tokensChunk(start, stop antlr.Token) Chunk {
+	if start == nil {
+		return Chunk{}
+	}
+	t := Chunk{Start: start.GetStart(), Stop: start.GetStart() - 1}
+	if stop != nil && stop.GetStop() >= t.Start {
+		t.Stop = stop.GetStop()
+	}
+	if input := start.GetInputStream(); input != nil && t.Start >= 0 && t.Stop >= t.Start {
+		t.Text = input.GetText(t.Start, t.Stop)
+	}
 	return t
 }
 func tokenChunk(token interface {
@@ -164,7 +175,9 @@ func tokenChunk(token interface {
 	GetStop() antlr.Token
 	GetText() string
 }) Chunk {
-	return Chunk{Start: token.GetStart().GetStart(), Stop: token.GetStop().GetStop(), Text: token.GetText()}
+	t := ctxChunk(token)
+	t.Text = token.GetText()
+	return t
 }
 
 func (wl *iiWalkListener) ExitSelect_list_elements(ctx *plsql.Select_list_elementsContext) {
@@ -186,7 +199,14 @@ func (wl *iiWalkListener) ExitSelect_list_elements(ctx *plsql.Select_list_elemen
 	}()
 	t := ctxChunk(ctx)
 	wl.Select.Values = append(wl.Select.Values, t)
-	if strings.HasPrefix(t.Text, "CASE ") {
+	// The column alias is exited before its select list element.
+	if ca, ok := ctx.Column_alias().(*plsql.Column_aliasContext); ok && ca != nil {
+		if id := ca.Identifier(); id != nil {
+			t = ctxChunk(id)
+		} else if qs := ca.Quoted_string(); qs != nil {
+			t = ctxChunk(qs)
+		}
+	} else if strings.HasPrefix(t.Text, "CASE ") {
 		if i := strings.LastIndexByte(t.Text, ' '); i >= 0 && strings.HasSuffix(t.Text[:i], "END") {
 			t.Text = t.Text[i+1:]
 		}
@@ -202,11 +222,7 @@ func (wl *iiWalkListener) ExitSelect_statement(ctx *plsql.Select_statementContex
 	if wl.Select == nil {
 		wl.Select = &selectStmt{}
 	}
-	wl.Select.Chunk = Chunk{Start: wl.enterSelect.GetStart(), Stop: ctx.GetStop().GetStop()}
-	wl.Select.Text = ctx.GetStart().GetInputStream().GetText(wl.Select.Chunk.Start, wl.Select.Chunk.Stop)
-}
-func (wl *iiWalkListener) ExitColumn_alias(ctx *plsql.Column_aliasContext) {
-	wl.Select.Aliases[len(wl.Select.Aliases)-1] = ctxChunk(ctx)
+	wl.Select.Chunk = tokensChunk(wl.enterSelect, ctx.GetStop())
 }
 
 func (wl *iiWalkListener) ExitFrom_clause(ctx *plsql.From_clauseContext) {
@@ -215,32 +231,71 @@ func (wl *iiWalkListener) ExitFrom_clause(ctx *plsql.From_clauseContext) {
 	} else if wl.Select.From != nil {
 		return
 	}
-	wl.Select.From = []TableWithAlias{} // not nil
-	for _, tbl := range ctx.Table_ref_list().(*plsql.Table_ref_listContext).AllTable_ref() {
-		tbl := tbl.(*plsql.Table_refContext)
-		aux := tbl.Table_ref_aux().(*plsql.Table_ref_auxContext)
+	wl.Select.From = fromTables(ctx)
+}
+
+// fromTables returns the tables of the FROM clause (not nil).
+func fromTables(ctx *plsql.From_clauseContext) []TableWithAlias {
+	tables := []TableWithAlias{}
+	list, ok := ctx.Table_ref_list().(*plsql.Table_ref_listContext)
+	if !ok || list == nil {
+		return tables
+	}
+	for _, tbl := range list.AllTable_ref() {
+		aux, ok := tbl.(*plsql.Table_refContext).Table_ref_aux().(*plsql.Table_ref_auxContext)
+		if !ok || aux == nil || aux.Table_ref_aux_internal() == nil {
+			continue
+		}
 		name := aux.Table_ref_aux_internal().GetText()
 		var alias string
 		if a := aux.Table_alias(); a != nil {
 			alias = a.GetText()
 		}
-		wl.Select.From = append(wl.Select.From, TableWithAlias{Table: name, Alias: alias})
+		tables = append(tables, TableWithAlias{Table: name, Alias: alias})
 	}
+	return tables
 }
 
+// upper returns the text uppercased, except the strings, quoted identifiers and comments.
 func upper(text string) string {
-	var inString bool
-	return strings.Map(func(r rune) rune {
-		if r == '\'' {
-			inString = !inString
-			return r
+	rs := []rune(text)
+	// skip returns the position after the end, searched from i.
+	skip := func(i int, end ...rune) int {
+		for ; i+len(end) <= len(rs); i++ {
+			if string(rs[i:i+len(end)]) == string(end) {
+				return i + len(end)
+			}
 		}
-		if inString {
-			return r
+		return len(rs)
+	}
+	// identRune reports whether the rune at i is part of an identifier.
+	identRune := func(i int) bool {
+		return i >= 0 && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || strings.ContainsRune("_$#", rs[i]))
+	}
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case r == '-' && i+1 < len(rs) && rs[i+1] == '-':
+			i = skip(i+2, '\n')
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			i = skip(i+2, '*', '/')
+		case r == '"' || r == '\'':
+			i = skip(i+1, r)
+		case (r == 'q' || r == 'Q') && i+2 < len(rs) && rs[i+1] == '\'' &&
+			(!identRune(i-1) || (rs[i-1] == 'N' || rs[i-1] == 'n') && !identRune(i-2)):
+			// q'[...]'
+			rs[i] = 'Q'
+			closing := rs[i+2]
+			if k := strings.IndexRune("[({<", closing); k >= 0 {
+				closing = []rune("])}>")[k]
+			}
+			i = skip(i+3, closing, '\'')
+		default:
+			rs[i] = unicode.ToUpper(r)
+			i++
 		}
-		return unicode.ToUpper(r)
-	},
-		text)
+	}
+	return string(rs)
 }
 
 var _ = error((*Errors)(nil))
@@ -0,0 +1,227 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package catalog holds an offline snapshot of the Oracle data dictionary,
+// which can be saved as JSON and used without a database connection.
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// Catalog is the offline snapshot of the data dictionary.
+//
+// The Constraints, Indexes, Sequences and Views are not read by LoadFromDB,
+// only from DDL (see plsqlparser.ParseDDL), to be compared by Compare.
+type Catalog struct {
+	Tables       []Table      `json:",omitempty"`
+	Objects      []Object     `json:",omitempty"`
+	Arguments    []Argument   `json:",omitempty"`
+	Synonyms     []Synonym    `json:",omitempty"`
+	Dependencies []Dependency `json:",omitempty"`
+	Constraints  []Constraint `json:",omitempty"`
+	Indexes      []Index      `json:",omitempty"`
+	Sequences    []Sequence   `json:",omitempty"`
+	Views        []View       `json:",omitempty"`
+}
+
+// Table is a table or view with its columns (ALL_TAB_COLUMNS).
+type Table struct {
+	Owner, Name string
+	Columns     []Column
+}
+
+// Column of a table (ALL_TAB_COLUMNS).
+type Column struct {
+	Name      string
+	DataType  string
+	Length    int `json:",omitempty"`
+	Precision int `json:",omitempty"`
+	Scale     int `json:",omitempty"`
+	Nullable  bool
+	Default   string `json:",omitempty"`
+}
+
+// Object is a row of ALL_OBJECTS.
+type Object struct {
+	Owner, Name, Type string
+	Status            string `json:",omitempty"`
+}
+
+// Argument is a row of ALL_ARGUMENTS.
+type Argument struct {
+	Owner, Package, Object string
+	Overload               string `json:",omitempty"`
+	Name                   string `json:",omitempty"`
+	Position, Sequence     int
+	Level                  int `json:",omitempty"`
+	DataType, InOut        string
+	TypeOwner              string `json:",omitempty"`
+	TypeName               string `json:",omitempty"`
+	TypeSubname            string `json:",omitempty"`
+	Length                 int    `json:",omitempty"`
+	Precision              int    `json:",omitempty"`
+	Scale                  int    `json:",omitempty"`
+	Defaulted              bool   `json:",omitempty"`
+}
+
+// Synonym is a row of ALL_SYNONYMS.
+type Synonym struct {
+	Owner, Name           string
+	TableOwner, TableName string
+	DBLink                string `json:",omitempty"`
+}
+
+// Dependency is a row of ALL_DEPENDENCIES.
+type Dependency struct {
+	Owner, Name, Type                               string
+	ReferencedOwner, ReferencedName, ReferencedType string
+	ReferencedLink                                  string `json:",omitempty"`
+}
+
+// Constraint is a primary key, unique, foreign key or check constraint of a table (ALL_CONSTRAINTS),
+// with the columns of the keys (ALL_CONS_COLUMNS).
+//
+// The NOT NULL constraints are Column.Nullable.
+type Constraint struct {
+	Owner, Table string
+	// Name is empty for the unnamed (system named) constraints of DDL.
+	Name string `json:",omitempty"`
+	// Type is P (primary key), U (unique), R (foreign key) or C (check).
+	Type    string
+	Columns []string `json:",omitempty"`
+	// RefOwner, RefTable and RefColumns are the key referenced by a foreign key.
+	RefOwner   string   `json:",omitempty"`
+	RefTable   string   `json:",omitempty"`
+	RefColumns []string `json:",omitempty"`
+	// DeleteRule of a foreign key: CASCADE or SET NULL.
+	DeleteRule string `json:",omitempty"`
+	// Condition of a check constraint.
+	Condition string `json:",omitempty"`
+}
+
+// Index is an index of a table (ALL_INDEXES, ALL_IND_COLUMNS and ALL_IND_EXPRESSIONS).
+type Index struct {
+	Owner, Name       string
+	TableOwner, Table string
+	Unique            bool `json:",omitempty"`
+	// Columns are the indexed columns or expressions, followed by DESC if descending.
+	Columns []string
+}
+
+// Sequence is a row of ALL_SEQUENCES, and the START WITH of its DDL.
+//
+// The values are kept as text, as they may not fit into an int64.
+type Sequence struct {
+	Owner, Name                     string
+	MinValue, MaxValue, IncrementBy string
+	StartWith                       string `json:",omitempty"`
+	CacheSize                       int
+	Cycle, Order                    bool `json:",omitempty"`
+}
+
+// View is a row of ALL_VIEWS: the query of the view, with the column aliases of its DDL.
+type View struct {
+	Owner, Name string
+	Columns     []string `json:",omitempty"`
+	Text        string
+}
+
+// ReadJSON reads the catalog from its JSON form.
+func ReadJSON(r io.Reader) (*Catalog, error) {
+	var c Catalog
+	if err := json.UnmarshalRead(r, &c); err != nil {
+		return nil, fmt.Errorf("read catalog: %w", err)
+	}
+	return &c, nil
+}
+
+// WriteJSON writes the catalog as indented JSON.
+func (c *Catalog) WriteJSON(w io.Writer) error {
+	return json.MarshalWrite(w, c, json.Deterministic(true), jsontext.WithIndent("  "))
+}
+
+// Table returns the table with the given name, which may be qualified with the owner.
+//
+// Unqualified names are looked up among the tables first, then among the synonyms.
+// Returns nil if no such table is found, or the synonyms form a loop (ORA-01775).
+func (c *Catalog) Table(name string) *Table {
+	if c == nil {
+		return nil
+	}
+	owner, name := splitName(name)
+	seen := make(map[Synonym]bool)
+	for {
+		for i, t := range c.Tables {
+			if t.Name == name && (owner == "" || t.Owner == owner) {
+				return &c.Tables[i]
+			}
+		}
+		var next *Synonym
+		for i, s := range c.Synonyms {
+			if s.Name == name && (owner == "" || s.Owner == owner || s.Owner == "PUBLIC") && s.DBLink == "" {
+				next = &c.Synonyms[i]
+				break
+			}
+		}
+		if next == nil || seen[*next] {
+			return nil
+		}
+		seen[*next] = true
+		owner, name = next.TableOwner, next.TableName
+	}
+}
+
+// Column returns the named column of the table, or nil.
+func (t *Table) Column(name string) *Column {
+	if t == nil {
+		return nil
+	}
+	name = Normalize(name)
+	for i, c := range t.Columns {
+		if c.Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// String returns the type of the column in DDL form, such as VARCHAR2(30) or NUMBER(12,2).
+func (c Column) String() string {
+	switch c.DataType {
+	case "VARCHAR2", "NVARCHAR2", "CHAR", "NCHAR", "RAW":
+		if c.Length != 0 {
+			return fmt.Sprintf("%s(%d)", c.DataType, c.Length)
+		}
+	case "NUMBER":
+		if c.Precision != 0 {
+			if c.Scale != 0 {
+				return fmt.Sprintf("%s(%d,%d)", c.DataType, c.Precision, c.Scale)
+			}
+			return fmt.Sprintf("%s(%d)", c.DataType, c.Precision)
+		}
+	}
+	return c.DataType
+}
+
+// Normalize the identifier as Oracle does: unquoted names are uppercased,
+// quoted names are kept as is, without the quotes.
+func Normalize(name string) string {
+	if len(name) > 1 && name[0] == '"' && name[len(name)-1] == '"' {
+		return name[1 : len(name)-1]
+	}
+	return strings.ToUpper(name)
+}
+
+func splitName(name string) (owner, object string) {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		return Normalize(name[:i]), Normalize(name[i+1:])
+	}
+	return "", Normalize(name)
+}
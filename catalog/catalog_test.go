@@ -0,0 +1,151 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+)
+
+func TestLoadFromDB(t *testing.T) {
+	db := sql.OpenDB(fakeConnector{
+		"ALL_TAB_COLUMNS": {
+			{"SCOTT", "EMP", "EMPNO", "NUMBER", nil, int64(4), int64(0), "N", nil},
+			{"SCOTT", "EMP", "ENAME", "VARCHAR2", int64(10), nil, nil, "Y", nil},
+			{"SCOTT", "DEPT", "DEPTNO", "NUMBER", nil, int64(2), int64(0), "N", "0 "},
+		},
+		"ALL_OBJECTS": {
+			{"SCOTT", "EMP", "TABLE", "VALID"},
+			{"SCOTT", "EMP_PKG", "PACKAGE", "VALID"},
+		},
+		"ALL_ARGUMENTS": {
+			{"SCOTT", "EMP_PKG", "HIRE", nil, "P_ENAME", int64(1), int64(1), int64(0), "VARCHAR2", "IN", nil, nil, nil, nil, nil, nil, "N"},
+		},
+		"ALL_SYNONYMS": {
+			{"PUBLIC", "STAFF", "SCOTT", "EMP", nil},
+			{"SCOTT", "EMPLOYEES", "SCOTT", "EMP", nil},
+		},
+		"ALL_DEPENDENCIES": {
+			{"SCOTT", "EMP_PKG", "PACKAGE BODY", "SCOTT", "EMP", "TABLE", nil},
+		},
+	})
+	defer db.Close()
+
+	cat, err := catalog.LoadFromDB(context.Background(), db, "scott")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &catalog.Catalog{
+		Tables: []catalog.Table{
+			{Owner: "SCOTT", Name: "EMP", Columns: []catalog.Column{
+				{Name: "EMPNO", DataType: "NUMBER", Precision: 4},
+				{Name: "ENAME", DataType: "VARCHAR2", Length: 10, Nullable: true},
+			}},
+			{Owner: "SCOTT", Name: "DEPT", Columns: []catalog.Column{
+				{Name: "DEPTNO", DataType: "NUMBER", Precision: 2, Default: "0"},
+			}},
+		},
+		Objects: []catalog.Object{
+			{Owner: "SCOTT", Name: "EMP", Type: "TABLE", Status: "VALID"},
+			{Owner: "SCOTT", Name: "EMP_PKG", Type: "PACKAGE", Status: "VALID"},
+		},
+		Arguments: []catalog.Argument{
+			{Owner: "SCOTT", Package: "EMP_PKG", Object: "HIRE", Name: "P_ENAME", Position: 1, Sequence: 1, DataType: "VARCHAR2", InOut: "IN"},
+		},
+		Synonyms: []catalog.Synonym{
+			{Owner: "PUBLIC", Name: "STAFF", TableOwner: "SCOTT", TableName: "EMP"},
+			{Owner: "SCOTT", Name: "EMPLOYEES", TableOwner: "SCOTT", TableName: "EMP"},
+		},
+		Dependencies: []catalog.Dependency{
+			{Owner: "SCOTT", Name: "EMP_PKG", Type: "PACKAGE BODY", ReferencedOwner: "SCOTT", ReferencedName: "EMP", ReferencedType: "TABLE"},
+		},
+	}
+	if !reflect.DeepEqual(cat, want) {
+		t.Errorf("got\n%+v\nwanted\n%+v", cat, want)
+	}
+
+	if tbl := cat.Table("employees"); tbl == nil || tbl.Name != "EMP" {
+		t.Errorf("synonym EMPLOYEES resolved to %+v", tbl)
+	}
+	if tbl := cat.Table("scott.staff"); tbl == nil || tbl.Name != "EMP" {
+		t.Errorf("public synonym STAFF resolved to %+v", tbl)
+	}
+	if col := cat.Table("scott.emp").Column("ename"); col == nil || col.String() != "VARCHAR2(10)" {
+		t.Errorf("ENAME: %+v", col)
+	}
+
+	var buf bytes.Buffer
+	if err := cat.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	got, err := catalog.ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON round-trip: got\n%+v\nwanted\n%+v", got, want)
+	}
+}
+
+// fakeConnector is a database/sql/driver.Connector returning canned rows,
+// keyed by the dictionary view the query reads.
+type fakeConnector map[string][][]driver.Value
+
+func (fc fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{fc}, nil }
+func (fc fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ fakeConnector }
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+func (fc fakeConn) QueryContext(ctx context.Context, qry string, args []driver.NamedValue) (driver.Rows, error) {
+	qry = strings.ToUpper(qry)
+	for k, rows := range fc.fakeConnector {
+		if strings.Contains(qry, "FROM "+k) {
+			fr := fakeRows{rows: rows}
+			if len(rows) != 0 {
+				fr.n = len(rows[0])
+			}
+			return &fr, nil
+		}
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	n    int
+}
+
+func (fr *fakeRows) Columns() []string { return make([]string, fr.n) }
+func (fr *fakeRows) Close() error      { return nil }
+func (fr *fakeRows) Next(dest []driver.Value) error {
+	if len(fr.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, fr.rows[0])
+	fr.rows = fr.rows[1:]
+	return nil
+}
+
+func TestTableSynonymLoop(t *testing.T) {
+	cat := &catalog.Catalog{Synonyms: []catalog.Synonym{
+		{Owner: "PUBLIC", Name: "A", TableOwner: "X", TableName: "B"},
+		{Owner: "PUBLIC", Name: "B", TableOwner: "X", TableName: "A"},
+	}}
+	if tbl := cat.Table("a"); tbl != nil {
+		t.Errorf("synonym loop resolved to %+v", tbl)
+	}
+}
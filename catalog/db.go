@@ -0,0 +1,174 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type querier interface {
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+}
+
+// LoadFromDB reads the catalog of the given schemas from the data dictionary
+// (ALL_TAB_COLUMNS, ALL_ARGUMENTS, ALL_OBJECTS, ALL_SYNONYMS and ALL_DEPENDENCIES).
+//
+// Without schemas, the current schema is read. The PUBLIC synonyms are read, too.
+func LoadFromDB(ctx context.Context, db querier, schemas ...string) (*Catalog, error) {
+	ownerCond := "owner = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')"
+	params := make([]interface{}, 0, len(schemas))
+	if len(schemas) != 0 {
+		var buf strings.Builder
+		buf.WriteString("owner IN (")
+		for i, s := range schemas {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(":" + strconv.Itoa(i+1))
+			params = append(params, Normalize(s))
+		}
+		buf.WriteByte(')')
+		ownerCond = buf.String()
+	}
+
+	var c Catalog
+	if err := loadTables(ctx, db, &c, ownerCond, params); err != nil {
+		return &c, err
+	}
+	if err := loadObjects(ctx, db, &c, ownerCond, params); err != nil {
+		return &c, err
+	}
+	if err := loadArguments(ctx, db, &c, ownerCond, params); err != nil {
+		return &c, err
+	}
+	if err := loadSynonyms(ctx, db, &c, ownerCond, params); err != nil {
+		return &c, err
+	}
+	if err := loadDependencies(ctx, db, &c, ownerCond, params); err != nil {
+		return &c, err
+	}
+	return &c, nil
+}
+
+func query(ctx context.Context, db querier, qry string, params []interface{}, scan func(*sql.Rows) error) error {
+	rows, err := db.QueryContext(ctx, qry, params...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return fmt.Errorf("%s: %w", qry, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return rows.Close()
+}
+
+func loadTables(ctx context.Context, db querier, c *Catalog, ownerCond string, params []interface{}) error {
+	qry := `SELECT owner, table_name, column_name, data_type, char_length, data_precision, data_scale, nullable, data_default
+  FROM all_tab_columns
+  WHERE ` + ownerCond + `
+  ORDER BY owner, table_name, column_id`
+	return query(ctx, db, qry, params, func(rows *sql.Rows) error {
+		var owner, table, nullable string
+		var col Column
+		var length, prec, scale sql.NullInt64
+		var dflt sql.NullString
+		if err := rows.Scan(&owner, &table, &col.Name, &col.DataType, &length, &prec, &scale, &nullable, &dflt); err != nil {
+			return err
+		}
+		col.Length, col.Precision, col.Scale = int(length.Int64), int(prec.Int64), int(scale.Int64)
+		col.Nullable = nullable == "Y"
+		col.Default = strings.TrimSpace(dflt.String)
+		if n := len(c.Tables); n == 0 || c.Tables[n-1].Owner != owner || c.Tables[n-1].Name != table {
+			c.Tables = append(c.Tables, Table{Owner: owner, Name: table})
+		}
+		t := &c.Tables[len(c.Tables)-1]
+		t.Columns = append(t.Columns, col)
+		return nil
+	})
+}
+
+func loadObjects(ctx context.Context, db querier, c *Catalog, ownerCond string, params []interface{}) error {
+	qry := `SELECT owner, object_name, object_type, status
+  FROM all_objects
+  WHERE ` + ownerCond + `
+  ORDER BY owner, object_name, object_type`
+	return query(ctx, db, qry, params, func(rows *sql.Rows) error {
+		var o Object
+		if err := rows.Scan(&o.Owner, &o.Name, &o.Type, &o.Status); err != nil {
+			return err
+		}
+		c.Objects = append(c.Objects, o)
+		return nil
+	})
+}
+
+func loadArguments(ctx context.Context, db querier, c *Catalog, ownerCond string, params []interface{}) error {
+	qry := `SELECT owner, package_name, object_name, overload, argument_name,
+       position, sequence, data_level, data_type, in_out,
+       type_owner, type_name, type_subname, char_length, data_precision, data_scale, defaulted
+  FROM all_arguments
+  WHERE ` + ownerCond + `
+  ORDER BY owner, package_name, object_name, overload, sequence`
+	return query(ctx, db, qry, params, func(rows *sql.Rows) error {
+		var a Argument
+		var pkg, overload, name, dataType, typeOwner, typeName, typeSubname, defaulted sql.NullString
+		var length, prec, scale sql.NullInt64
+		if err := rows.Scan(&a.Owner, &pkg, &a.Object, &overload, &name,
+			&a.Position, &a.Sequence, &a.Level, &dataType, &a.InOut,
+			&typeOwner, &typeName, &typeSubname, &length, &prec, &scale, &defaulted,
+		); err != nil {
+			return err
+		}
+		a.Package, a.Overload, a.Name, a.DataType = pkg.String, overload.String, name.String, dataType.String
+		a.TypeOwner, a.TypeName, a.TypeSubname = typeOwner.String, typeName.String, typeSubname.String
+		a.Length, a.Precision, a.Scale = int(length.Int64), int(prec.Int64), int(scale.Int64)
+		a.Defaulted = defaulted.String == "Y"
+		c.Arguments = append(c.Arguments, a)
+		return nil
+	})
+}
+
+func loadSynonyms(ctx context.Context, db querier, c *Catalog, ownerCond string, params []interface{}) error {
+	qry := `SELECT owner, synonym_name, table_owner, table_name, db_link
+  FROM all_synonyms
+  WHERE (` + ownerCond + ` OR owner = 'PUBLIC')
+  ORDER BY owner, synonym_name`
+	return query(ctx, db, qry, params, func(rows *sql.Rows) error {
+		var s Synonym
+		var tableOwner, dbLink sql.NullString
+		if err := rows.Scan(&s.Owner, &s.Name, &tableOwner, &s.TableName, &dbLink); err != nil {
+			return err
+		}
+		s.TableOwner, s.DBLink = tableOwner.String, dbLink.String
+		c.Synonyms = append(c.Synonyms, s)
+		return nil
+	})
+}
+
+func loadDependencies(ctx context.Context, db querier, c *Catalog, ownerCond string, params []interface{}) error {
+	qry := `SELECT owner, name, type, referenced_owner, referenced_name, referenced_type, referenced_link_name
+  FROM all_dependencies
+  WHERE ` + ownerCond + `
+  ORDER BY owner, name, type, referenced_owner, referenced_name, referenced_type`
+	return query(ctx, db, qry, params, func(rows *sql.Rows) error {
+		var d Dependency
+		var refOwner, refLink sql.NullString
+		if err := rows.Scan(&d.Owner, &d.Name, &d.Type, &refOwner, &d.ReferencedName, &d.ReferencedType, &refLink); err != nil {
+			return err
+		}
+		d.ReferencedOwner, d.ReferencedLink = refOwner.String, refLink.String
+		c.Dependencies = append(c.Dependencies, d)
+		return nil
+	})
+}
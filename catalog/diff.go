@@ -0,0 +1,654 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Op is the kind of a change.
+type Op uint8
+
+const (
+	Added = Op(iota + 1)
+	Dropped
+	Changed
+)
+
+func (op Op) String() string {
+	switch op {
+	case Added:
+		return "added"
+	case Dropped:
+		return "dropped"
+	case Changed:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// SchemaDiff is the difference between an old and a new catalog, see Compare.
+//
+// Each change has the Old and the New object: Old is nil for the Added ones, New for the Dropped ones.
+type SchemaDiff struct {
+	Tables      []TableDiff
+	Constraints []ConstraintDiff
+	Indexes     []IndexDiff
+	Sequences   []SequenceDiff
+	Views       []ViewDiff
+}
+
+// TableDiff is an added or dropped table, or a table with changed columns.
+type TableDiff struct {
+	Op       Op
+	Old, New *Table
+	// Columns are the changes of a Changed table.
+	Columns []ColumnDiff
+}
+
+// ColumnDiff is an added, dropped or changed (type, default or nullability) column.
+type ColumnDiff struct {
+	Op       Op
+	Old, New *Column
+}
+
+// ConstraintDiff is an added, dropped or changed constraint.
+type ConstraintDiff struct {
+	Op       Op
+	Old, New *Constraint
+}
+
+// IndexDiff is an added, dropped or changed index.
+type IndexDiff struct {
+	Op       Op
+	Old, New *Index
+}
+
+// SequenceDiff is an added, dropped or changed sequence.
+type SequenceDiff struct {
+	Op       Op
+	Old, New *Sequence
+}
+
+// ViewDiff is an added, dropped or changed view.
+type ViewDiff struct {
+	Op       Op
+	Old, New *View
+}
+
+// Compare the tables, constraints, indexes, sequences and views of the catalogs.
+//
+// The objects are matched by their (owner-qualified) names, the columns by name,
+// so a renamed object is dropped and added.
+// The unnamed constraints are matched by their table, type and definition.
+// The constraints and indexes of the dropped tables are not listed, they are dropped with their table.
+// START WITH of the sequences is only used for the new ones.
+func Compare(old, new *Catalog) SchemaDiff {
+	if old == nil {
+		old = &Catalog{}
+	}
+	if new == nil {
+		new = &Catalog{}
+	}
+	var d SchemaDiff
+	dropped := make(map[string]bool)
+
+	oldKeys, newKeys := make([]string, len(old.Tables)), make([]string, len(new.Tables))
+	for i, t := range old.Tables {
+		oldKeys[i] = qualified(t.Owner, t.Name)
+	}
+	for i, t := range new.Tables {
+		newKeys[i] = qualified(t.Owner, t.Name)
+	}
+	match(oldKeys, newKeys, func(o, n int) {
+		switch {
+		case n < 0:
+			dropped[oldKeys[o]] = true
+			d.Tables = append(d.Tables, TableDiff{Op: Dropped, Old: &old.Tables[o]})
+		case o < 0:
+			d.Tables = append(d.Tables, TableDiff{Op: Added, New: &new.Tables[n]})
+		default:
+			if cols := compareColumns(&old.Tables[o], &new.Tables[n]); len(cols) != 0 {
+				d.Tables = append(d.Tables, TableDiff{Op: Changed, Old: &old.Tables[o], New: &new.Tables[n], Columns: cols})
+			}
+		}
+	})
+
+	oldKeys, newKeys = make([]string, len(old.Constraints)), make([]string, len(new.Constraints))
+	for i, c := range old.Constraints {
+		if !dropped[qualified(c.Owner, c.Table)] {
+			oldKeys[i] = c.key()
+		}
+	}
+	for i, c := range new.Constraints {
+		newKeys[i] = c.key()
+	}
+	match(oldKeys, newKeys, func(o, n int) {
+		var c ConstraintDiff
+		if o >= 0 {
+			c.Old = &old.Constraints[o]
+		}
+		if n >= 0 {
+			c.New = &new.Constraints[n]
+		}
+		if c.Op = op(o, n); c.Op != Changed || !c.Old.equal(*c.New) {
+			d.Constraints = append(d.Constraints, c)
+		}
+	})
+
+	oldKeys, newKeys = make([]string, len(old.Indexes)), make([]string, len(new.Indexes))
+	for i, x := range old.Indexes {
+		if !dropped[qualified(x.TableOwner, x.Table)] {
+			oldKeys[i] = qualified(x.Owner, x.Name)
+		}
+	}
+	for i, x := range new.Indexes {
+		newKeys[i] = qualified(x.Owner, x.Name)
+	}
+	match(oldKeys, newKeys, func(o, n int) {
+		var x IndexDiff
+		if o >= 0 {
+			x.Old = &old.Indexes[o]
+		}
+		if n >= 0 {
+			x.New = &new.Indexes[n]
+		}
+		if x.Op = op(o, n); x.Op != Changed || !x.Old.equal(*x.New) {
+			d.Indexes = append(d.Indexes, x)
+		}
+	})
+
+	oldKeys, newKeys = make([]string, len(old.Sequences)), make([]string, len(new.Sequences))
+	for i, s := range old.Sequences {
+		oldKeys[i] = qualified(s.Owner, s.Name)
+	}
+	for i, s := range new.Sequences {
+		newKeys[i] = qualified(s.Owner, s.Name)
+	}
+	match(oldKeys, newKeys, func(o, n int) {
+		var s SequenceDiff
+		if o >= 0 {
+			s.Old = &old.Sequences[o]
+		}
+		if n >= 0 {
+			s.New = &new.Sequences[n]
+		}
+		if s.Op = op(o, n); s.Op != Changed || !s.Old.equal(*s.New) {
+			d.Sequences = append(d.Sequences, s)
+		}
+	})
+
+	oldKeys, newKeys = make([]string, len(old.Views)), make([]string, len(new.Views))
+	for i, v := range old.Views {
+		oldKeys[i] = qualified(v.Owner, v.Name)
+	}
+	for i, v := range new.Views {
+		newKeys[i] = qualified(v.Owner, v.Name)
+	}
+	match(oldKeys, newKeys, func(o, n int) {
+		var v ViewDiff
+		if o >= 0 {
+			v.Old = &old.Views[o]
+		}
+		if n >= 0 {
+			v.New = &new.Views[n]
+		}
+		if v.Op = op(o, n); v.Op != Changed || !v.Old.equal(*v.New) {
+			d.Views = append(d.Views, v)
+		}
+	})
+	return d
+}
+
+// match calls f with the indexes of the old and the new objects of the same key, in key order,
+// -1 for the missing one. Empty keys are skipped.
+func match(oldKeys, newKeys []string, f func(o, n int)) {
+	idx := make(map[string][2]int, len(oldKeys)+len(newKeys))
+	keys := make([]string, 0, len(oldKeys)+len(newKeys))
+	for i, k := range oldKeys {
+		if k != "" {
+			idx[k] = [2]int{i, -1}
+			keys = append(keys, k)
+		}
+	}
+	for i, k := range newKeys {
+		if k == "" {
+			continue
+		}
+		x, ok := idx[k]
+		if !ok {
+			x[0] = -1
+			keys = append(keys, k)
+		}
+		x[1] = i
+		idx[k] = x
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		f(idx[k][0], idx[k][1])
+	}
+}
+
+// op returns the Op of the old and new indexes given by match.
+func op(o, n int) Op {
+	switch {
+	case o < 0:
+		return Added
+	case n < 0:
+		return Dropped
+	}
+	return Changed
+}
+
+// compareColumns returns the dropped and changed columns of the table, in the old order,
+// then the added ones, in the new order.
+func compareColumns(old, new *Table) []ColumnDiff {
+	var cols []ColumnDiff
+	for i, o := range old.Columns {
+		n := new.column(o.Name)
+		switch {
+		case n == nil:
+			cols = append(cols, ColumnDiff{Op: Dropped, Old: &old.Columns[i]})
+		case !o.equal(*n):
+			cols = append(cols, ColumnDiff{Op: Changed, Old: &old.Columns[i], New: n})
+		}
+	}
+	for i, n := range new.Columns {
+		if old.column(n.Name) == nil {
+			cols = append(cols, ColumnDiff{Op: Added, New: &new.Columns[i]})
+		}
+	}
+	return cols
+}
+
+// column returns the column of the normalized name, or nil.
+func (t *Table) column(name string) *Column {
+	for i, c := range t.Columns {
+		if c.Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+func (c Column) equal(other Column) bool {
+	return c.String() == other.String() && c.Nullable == other.Nullable &&
+		collapseSpace(c.Default) == collapseSpace(other.Default)
+}
+
+// key identifies the constraint: by its name, or by its definition if it is unnamed.
+func (c Constraint) key() string {
+	if c.Name != "" {
+		return qualified(c.Owner, c.Name)
+	}
+	return qualified(c.Owner, c.Table) + " " + c.definition()
+}
+
+func (c Constraint) equal(other Constraint) bool {
+	return c.Owner == other.Owner && c.Table == other.Table && c.definition() == other.definition()
+}
+
+func (x Index) equal(other Index) bool {
+	return x.TableOwner == other.TableOwner && x.Table == other.Table && x.Unique == other.Unique &&
+		collapseSpace(strings.Join(x.Columns, ", ")) == collapseSpace(strings.Join(other.Columns, ", "))
+}
+
+func (s Sequence) equal(other Sequence) bool {
+	return s.MinValue == other.MinValue && s.MaxValue == other.MaxValue && s.IncrementBy == other.IncrementBy &&
+		s.CacheSize == other.CacheSize && s.Cycle == other.Cycle && s.Order == other.Order
+}
+
+func (v View) equal(other View) bool {
+	return strings.Join(v.Columns, ",") == strings.Join(other.Columns, ",") &&
+		collapseSpace(v.Text) == collapseSpace(other.Text)
+}
+
+// collapseSpace replaces the runs of whitespace with a single space.
+func collapseSpace(s string) string { return strings.Join(strings.Fields(s), " ") }
+
+var rePlainIdent = regexp.MustCompile(`^[A-Z][A-Z0-9_$#]*$`)
+
+// ident returns the normalized name as an identifier, quoted if needed.
+func ident(name string) string {
+	if rePlainIdent.MatchString(name) {
+		return name
+	}
+	return `"` + name + `"`
+}
+
+// qualified returns the name, qualified with the owner if that is not empty.
+func qualified(owner, name string) string {
+	if owner == "" {
+		return ident(name)
+	}
+	return ident(owner) + "." + ident(name)
+}
+
+func identList(names []string) string {
+	ids := make([]string, len(names))
+	for i, name := range names {
+		ids[i] = ident(name)
+	}
+	return strings.Join(ids, ", ")
+}
+
+// definition returns the constraint clause of the constraint, without its name.
+func (c Constraint) definition() string {
+	switch c.Type {
+	case "P":
+		return "PRIMARY KEY (" + identList(c.Columns) + ")"
+	case "U":
+		return "UNIQUE (" + identList(c.Columns) + ")"
+	case "R":
+		s := "FOREIGN KEY (" + identList(c.Columns) + ") REFERENCES " + qualified(c.RefOwner, c.RefTable)
+		if len(c.RefColumns) != 0 {
+			s += " (" + identList(c.RefColumns) + ")"
+		}
+		if c.DeleteRule != "" {
+			s += " ON DELETE " + c.DeleteRule
+		}
+		return s
+	case "C":
+		return "CHECK (" + collapseSpace(c.Condition) + ")"
+	}
+	return c.Type
+}
+
+// clause returns the constraint as in CREATE TABLE or ALTER TABLE ADD.
+func (c Constraint) clause() string {
+	if c.Name == "" {
+		return c.definition()
+	}
+	return "CONSTRAINT " + ident(c.Name) + " " + c.definition()
+}
+
+// definition returns the column as in CREATE TABLE: its name, type, default and NOT NULL.
+func (c Column) definition() string {
+	s := ident(c.Name) + " " + c.String()
+	if c.Default != "" {
+		s += " DEFAULT " + c.Default
+	}
+	if !c.Nullable {
+		s += " NOT NULL"
+	}
+	return s
+}
+
+// modification returns what has changed of the column, as in ALTER TABLE MODIFY.
+func (c Column) modification(old Column) string {
+	s := ident(c.Name)
+	if c.String() != old.String() {
+		s += " " + c.String()
+	}
+	if collapseSpace(c.Default) != collapseSpace(old.Default) {
+		if c.Default == "" {
+			s += " DEFAULT NULL"
+		} else {
+			s += " DEFAULT " + c.Default
+		}
+	}
+	if c.Nullable != old.Nullable {
+		if c.Nullable {
+			s += " NULL"
+		} else {
+			s += " NOT NULL"
+		}
+	}
+	return s
+}
+
+// options returns the options of the sequence, as in CREATE SEQUENCE, and the ones differing from old if that is not nil.
+func (s Sequence) options(old *Sequence) string {
+	var opts []string
+	if old == nil && s.StartWith != "" {
+		opts = append(opts, "START WITH "+s.StartWith)
+	}
+	if old == nil || s.IncrementBy != old.IncrementBy {
+		opts = append(opts, "INCREMENT BY "+s.IncrementBy)
+	}
+	if old == nil || s.MinValue != old.MinValue {
+		opts = append(opts, "MINVALUE "+s.MinValue)
+	}
+	if old == nil || s.MaxValue != old.MaxValue {
+		opts = append(opts, "MAXVALUE "+s.MaxValue)
+	}
+	if old == nil || s.CacheSize != old.CacheSize {
+		if s.CacheSize == 0 {
+			opts = append(opts, "NOCACHE")
+		} else {
+			opts = append(opts, fmt.Sprintf("CACHE %d", s.CacheSize))
+		}
+	}
+	flag := func(b bool, name string) string {
+		if b {
+			return name
+		}
+		return "NO" + name
+	}
+	if old == nil || s.Cycle != old.Cycle {
+		opts = append(opts, flag(s.Cycle, "CYCLE"))
+	}
+	if old == nil || s.Order != old.Order {
+		opts = append(opts, flag(s.Order, "ORDER"))
+	}
+	return strings.Join(opts, " ")
+}
+
+func (x Index) create() string {
+	s := "CREATE "
+	if x.Unique {
+		s += "UNIQUE "
+	}
+	return s + "INDEX " + qualified(x.Owner, x.Name) + " ON " + qualified(x.TableOwner, x.Table) +
+		" (" + strings.Join(x.Columns, ", ") + ")"
+}
+
+func (v View) create() string {
+	s := "CREATE OR REPLACE VIEW " + qualified(v.Owner, v.Name)
+	if len(v.Columns) != 0 {
+		s += " (" + identList(v.Columns) + ")"
+	}
+	return s + " AS\n" + strings.TrimSpace(v.Text)
+}
+
+// String lists the changes, one per line.
+func (d SchemaDiff) String() string {
+	var lines []string
+	add := func(format string, args ...interface{}) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	for _, t := range d.Tables {
+		switch t.Op {
+		case Added:
+			add("added table %s", qualified(t.New.Owner, t.New.Name))
+		case Dropped:
+			add("dropped table %s", qualified(t.Old.Owner, t.Old.Name))
+		}
+		for _, c := range t.Columns {
+			name := qualified(t.Old.Owner, t.Old.Name)
+			switch c.Op {
+			case Added:
+				add("added column %s.%s", name, c.New.definition())
+			case Dropped:
+				add("dropped column %s.%s", name, ident(c.Old.Name))
+			case Changed:
+				add("changed column %s.%s: %s -> %s", name, ident(c.Old.Name),
+					strings.TrimPrefix(c.Old.definition(), ident(c.Old.Name)+" "),
+					strings.TrimPrefix(c.New.definition(), ident(c.New.Name)+" "))
+			}
+		}
+	}
+	for _, c := range d.Constraints {
+		x := c.New
+		if x == nil {
+			x = c.Old
+		}
+		name := "constraint"
+		if x.Name != "" {
+			name += " " + qualified(x.Owner, x.Name)
+		}
+		name += " of " + qualified(x.Owner, x.Table)
+		switch c.Op {
+		case Changed:
+			add("changed %s: %s -> %s", name, c.Old.definition(), c.New.definition())
+		default:
+			add("%s %s: %s", c.Op, name, x.definition())
+		}
+	}
+	for _, x := range d.Indexes {
+		switch x.Op {
+		case Added:
+			add("added index %s", strings.TrimPrefix(x.New.create(), "CREATE "))
+		case Dropped:
+			add("dropped index %s", qualified(x.Old.Owner, x.Old.Name))
+		case Changed:
+			add("changed index %s -> %s", strings.TrimPrefix(x.Old.create(), "CREATE "), strings.TrimPrefix(x.New.create(), "CREATE "))
+		}
+	}
+	for _, s := range d.Sequences {
+		switch s.Op {
+		case Added:
+			add("added sequence %s", qualified(s.New.Owner, s.New.Name))
+		case Dropped:
+			add("dropped sequence %s", qualified(s.Old.Owner, s.Old.Name))
+		case Changed:
+			add("changed sequence %s: %s -> %s", qualified(s.New.Owner, s.New.Name), s.Old.options(s.New), s.New.options(s.Old))
+		}
+	}
+	for _, v := range d.Views {
+		x := v.New
+		if x == nil {
+			x = v.Old
+		}
+		add("%s view %s", v.Op, qualified(x.Owner, x.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Script returns the statements migrating the old schema to the new one, each terminated by a semicolon.
+//
+// The order is:
+//   - the dropped views; the dropped and changed foreign keys, then the other constraints; the dropped and changed indexes,
+//   - the sequences,
+//   - the new tables, the added, modified, then dropped columns of the changed tables, the dropped tables,
+//   - the added and changed indexes; the added and changed constraints, the foreign keys last,
+//   - the added and changed views.
+//
+// The changed constraints and indexes are dropped and created again.
+// The unnamed check and foreign key constraints cannot be dropped by DDL, they are noted in comments.
+func (d SchemaDiff) Script() string {
+	var w strings.Builder
+	stmt := func(format string, args ...interface{}) { fmt.Fprintf(&w, format+";\n", args...) }
+
+	for _, v := range d.Views {
+		if v.Op == Dropped {
+			stmt("DROP VIEW %s", qualified(v.Old.Owner, v.Old.Name))
+		}
+	}
+	for _, fk := range []bool{true, false} {
+		for _, c := range d.Constraints {
+			if c.Op == Added || (c.Old.Type == "R") != fk {
+				continue
+			}
+			x := c.Old
+			table := qualified(x.Owner, x.Table)
+			switch {
+			case x.Name != "":
+				stmt("ALTER TABLE %s DROP CONSTRAINT %s", table, ident(x.Name))
+			case x.Type == "P":
+				stmt("ALTER TABLE %s DROP PRIMARY KEY", table)
+			case x.Type == "U":
+				stmt("ALTER TABLE %s DROP UNIQUE (%s)", table, identList(x.Columns))
+			default:
+				fmt.Fprintf(&w, "-- drop the unnamed constraint %s of %s by its system generated name\n", x.definition(), table)
+			}
+		}
+	}
+	for _, x := range d.Indexes {
+		if x.Op != Added {
+			stmt("DROP INDEX %s", qualified(x.Old.Owner, x.Old.Name))
+		}
+	}
+
+	for _, s := range d.Sequences {
+		switch s.Op {
+		case Added:
+			stmt("CREATE SEQUENCE %s %s", qualified(s.New.Owner, s.New.Name), s.New.options(nil))
+		case Dropped:
+			stmt("DROP SEQUENCE %s", qualified(s.Old.Owner, s.Old.Name))
+		case Changed:
+			stmt("ALTER SEQUENCE %s %s", qualified(s.New.Owner, s.New.Name), s.New.options(s.Old))
+		}
+	}
+
+	for _, t := range d.Tables {
+		if t.Op != Added {
+			continue
+		}
+		defs := make([]string, len(t.New.Columns))
+		for i, c := range t.New.Columns {
+			defs[i] = "  " + c.definition()
+		}
+		stmt("CREATE TABLE %s (\n%s\n)", qualified(t.New.Owner, t.New.Name), strings.Join(defs, ",\n"))
+	}
+	for _, op := range []Op{Added, Changed, Dropped} {
+		for _, t := range d.Tables {
+			if t.Op != Changed {
+				continue
+			}
+			var defs []string
+			for _, c := range t.Columns {
+				if c.Op != op {
+					continue
+				}
+				switch op {
+				case Added:
+					defs = append(defs, c.New.definition())
+				case Changed:
+					defs = append(defs, c.New.modification(*c.Old))
+				case Dropped:
+					defs = append(defs, ident(c.Old.Name))
+				}
+			}
+			if len(defs) == 0 {
+				continue
+			}
+			table := qualified(t.New.Owner, t.New.Name)
+			switch op {
+			case Added:
+				stmt("ALTER TABLE %s ADD (%s)", table, strings.Join(defs, ", "))
+			case Changed:
+				stmt("ALTER TABLE %s MODIFY (%s)", table, strings.Join(defs, ", "))
+			case Dropped:
+				stmt("ALTER TABLE %s DROP (%s)", table, strings.Join(defs, ", "))
+			}
+		}
+	}
+	for _, t := range d.Tables {
+		if t.Op == Dropped {
+			stmt("DROP TABLE %s CASCADE CONSTRAINTS", qualified(t.Old.Owner, t.Old.Name))
+		}
+	}
+
+	for _, x := range d.Indexes {
+		if x.Op != Dropped {
+			stmt("%s", x.New.create())
+		}
+	}
+	for _, fk := range []bool{false, true} {
+		for _, c := range d.Constraints {
+			if c.Op != Dropped && (c.New.Type == "R") == fk {
+				stmt("ALTER TABLE %s ADD %s", qualified(c.New.Owner, c.New.Table), c.New.clause())
+			}
+		}
+	}
+	for _, v := range d.Views {
+		if v.Op != Dropped {
+			stmt("%s", v.New.create())
+		}
+	}
+	return w.String()
+}
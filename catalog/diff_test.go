@@ -0,0 +1,144 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog_test
+
+import (
+	"testing"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+)
+
+func TestCompare(t *testing.T) {
+	old := &catalog.Catalog{
+		Tables: []catalog.Table{
+			{Owner: "HR", Name: "EMP", Columns: []catalog.Column{
+				{Name: "ID", DataType: "NUMBER", Precision: 10},
+				{Name: "NAME", DataType: "VARCHAR2", Length: 50, Nullable: true},
+				{Name: "FAX", DataType: "VARCHAR2", Length: 20, Nullable: true},
+				{Name: "DEPT_ID", DataType: "NUMBER", Nullable: true},
+			}},
+			{Owner: "HR", Name: "DEPT", Columns: []catalog.Column{
+				{Name: "ID", DataType: "NUMBER"},
+			}},
+			{Owner: "HR", Name: "OLD_LOG", Columns: []catalog.Column{
+				{Name: "MSG", DataType: "VARCHAR2", Length: 4000, Nullable: true},
+			}},
+		},
+		Constraints: []catalog.Constraint{
+			{Owner: "HR", Table: "EMP", Name: "EMP_PK", Type: "P", Columns: []string{"ID"}},
+			{Owner: "HR", Table: "DEPT", Name: "DEPT_PK", Type: "P", Columns: []string{"ID"}},
+			{Owner: "HR", Table: "EMP", Name: "EMP_DEPT_FK", Type: "R", Columns: []string{"DEPT_ID"}, RefOwner: "HR", RefTable: "DEPT"},
+			{Owner: "HR", Table: "EMP", Type: "C", Condition: "name  IS NOT NULL"},
+			{Owner: "HR", Table: "OLD_LOG", Type: "C", Condition: "msg IS NOT NULL"},
+		},
+		Indexes: []catalog.Index{
+			{Owner: "HR", Name: "EMP_NAME_I", TableOwner: "HR", Table: "EMP", Columns: []string{"NAME"}},
+			{Owner: "HR", Name: "OLD_LOG_I", TableOwner: "HR", Table: "OLD_LOG", Columns: []string{"MSG"}},
+		},
+		Sequences: []catalog.Sequence{
+			{Owner: "HR", Name: "EMP_SEQ", MinValue: "1", MaxValue: "9999999999999999999999999999", IncrementBy: "1", StartWith: "1", CacheSize: 20},
+		},
+		Views: []catalog.View{
+			{Owner: "HR", Name: "EMP_V", Text: "SELECT id, name\n  FROM emp"},
+		},
+	}
+	new := &catalog.Catalog{
+		Tables: []catalog.Table{
+			{Owner: "HR", Name: "EMP", Columns: []catalog.Column{
+				{Name: "ID", DataType: "NUMBER", Precision: 10},
+				{Name: "NAME", DataType: "VARCHAR2", Length: 100, Default: "'?'"},
+				{Name: "DEPT_ID", DataType: "NUMBER", Nullable: true},
+				{Name: "EMAIL", DataType: "VARCHAR2", Length: 200, Nullable: true},
+			}},
+			{Owner: "HR", Name: "DEPT", Columns: []catalog.Column{
+				{Name: "ID", DataType: "NUMBER"},
+			}},
+			{Owner: "HR", Name: "AUDIT_LOG", Columns: []catalog.Column{
+				{Name: "ID", DataType: "NUMBER"},
+				{Name: "EMP_ID", DataType: "NUMBER", Nullable: true},
+			}},
+		},
+		Constraints: []catalog.Constraint{
+			{Owner: "HR", Table: "EMP", Name: "EMP_PK", Type: "P", Columns: []string{"ID"}},
+			{Owner: "HR", Table: "DEPT", Name: "DEPT_PK", Type: "P", Columns: []string{"ID"}},
+			{Owner: "HR", Table: "EMP", Name: "EMP_DEPT_FK", Type: "R", Columns: []string{"DEPT_ID"}, RefOwner: "HR", RefTable: "DEPT", DeleteRule: "SET NULL"},
+			{Owner: "HR", Table: "EMP", Type: "C", Condition: "name IS NOT NULL"},
+			{Owner: "HR", Table: "EMP", Type: "U", Columns: []string{"EMAIL"}},
+			{Owner: "HR", Table: "AUDIT_LOG", Name: "AUDIT_LOG_EMP_FK", Type: "R", Columns: []string{"EMP_ID"}, RefOwner: "HR", RefTable: "EMP", RefColumns: []string{"ID"}},
+			{Owner: "HR", Table: "AUDIT_LOG", Name: "AUDIT_LOG_PK", Type: "P", Columns: []string{"ID"}},
+		},
+		Indexes: []catalog.Index{
+			{Owner: "HR", Name: "EMP_NAME_I", TableOwner: "HR", Table: "EMP", Columns: []string{"UPPER(NAME)"}},
+		},
+		Sequences: []catalog.Sequence{
+			{Owner: "HR", Name: "EMP_SEQ", MinValue: "1", MaxValue: "9999999999999999999999999999", IncrementBy: "1", StartWith: "1000", CacheSize: 0},
+			{Owner: "HR", Name: "AUDIT_SEQ", MinValue: "1", MaxValue: "9999999999999999999999999999", IncrementBy: "1", StartWith: "1", CacheSize: 20},
+		},
+		Views: []catalog.View{
+			{Owner: "HR", Name: "EMP_V", Text: "SELECT id,  name FROM emp"},
+			{Owner: "HR", Name: "EMP_MAIL_V", Columns: []string{"ID", "MAIL"}, Text: "SELECT id, email FROM emp"},
+		},
+	}
+
+	d := catalog.Compare(old, new)
+	if got, want := d.String(), `added table HR.AUDIT_LOG
+changed column HR.EMP.NAME: VARCHAR2(50) -> VARCHAR2(100) DEFAULT '?' NOT NULL
+dropped column HR.EMP.FAX
+added column HR.EMP.EMAIL VARCHAR2(200)
+dropped table HR.OLD_LOG
+added constraint HR.AUDIT_LOG_EMP_FK of HR.AUDIT_LOG: FOREIGN KEY (EMP_ID) REFERENCES HR.EMP (ID)
+added constraint HR.AUDIT_LOG_PK of HR.AUDIT_LOG: PRIMARY KEY (ID)
+added constraint of HR.EMP: UNIQUE (EMAIL)
+changed constraint HR.EMP_DEPT_FK of HR.EMP: FOREIGN KEY (DEPT_ID) REFERENCES HR.DEPT -> FOREIGN KEY (DEPT_ID) REFERENCES HR.DEPT ON DELETE SET NULL
+changed index INDEX HR.EMP_NAME_I ON HR.EMP (NAME) -> INDEX HR.EMP_NAME_I ON HR.EMP (UPPER(NAME))
+added sequence HR.AUDIT_SEQ
+changed sequence HR.EMP_SEQ: CACHE 20 -> NOCACHE
+added view HR.EMP_MAIL_V`; got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+
+	if got, want := d.Script(), `ALTER TABLE HR.EMP DROP CONSTRAINT EMP_DEPT_FK;
+DROP INDEX HR.EMP_NAME_I;
+CREATE SEQUENCE HR.AUDIT_SEQ START WITH 1 INCREMENT BY 1 MINVALUE 1 MAXVALUE 9999999999999999999999999999 CACHE 20 NOCYCLE NOORDER;
+ALTER SEQUENCE HR.EMP_SEQ NOCACHE;
+CREATE TABLE HR.AUDIT_LOG (
+  ID NUMBER NOT NULL,
+  EMP_ID NUMBER
+);
+ALTER TABLE HR.EMP ADD (EMAIL VARCHAR2(200));
+ALTER TABLE HR.EMP MODIFY (NAME VARCHAR2(100) DEFAULT '?' NOT NULL);
+ALTER TABLE HR.EMP DROP (FAX);
+DROP TABLE HR.OLD_LOG CASCADE CONSTRAINTS;
+CREATE INDEX HR.EMP_NAME_I ON HR.EMP (UPPER(NAME));
+ALTER TABLE HR.AUDIT_LOG ADD CONSTRAINT AUDIT_LOG_PK PRIMARY KEY (ID);
+ALTER TABLE HR.EMP ADD UNIQUE (EMAIL);
+ALTER TABLE HR.AUDIT_LOG ADD CONSTRAINT AUDIT_LOG_EMP_FK FOREIGN KEY (EMP_ID) REFERENCES HR.EMP (ID);
+ALTER TABLE HR.EMP ADD CONSTRAINT EMP_DEPT_FK FOREIGN KEY (DEPT_ID) REFERENCES HR.DEPT ON DELETE SET NULL;
+CREATE OR REPLACE VIEW HR.EMP_MAIL_V (ID, MAIL) AS
+SELECT id, email FROM emp;
+`; got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+
+	if d := catalog.Compare(new, new); d.String() != "" || d.Script() != "" {
+		t.Errorf("no changes wanted, got\n%s", d)
+	}
+}
+
+func TestCompareUnnamed(t *testing.T) {
+	old := &catalog.Catalog{Constraints: []catalog.Constraint{
+		{Owner: "HR", Table: "EMP", Type: "P", Columns: []string{"ID"}},
+		{Owner: "HR", Table: "EMP", Type: "U", Columns: []string{"NAME", "BIRTH"}},
+		{Owner: "HR", Table: "EMP", Type: "C", Condition: "salary > 0"},
+		{Owner: "HR", Table: "EMP", Name: "emp_lower", Type: "C", Condition: "1 = 1"},
+	}}
+	if got, want := catalog.Compare(old, nil).Script(), `ALTER TABLE HR.EMP DROP CONSTRAINT "emp_lower";
+-- drop the unnamed constraint CHECK (salary > 0) of HR.EMP by its system generated name
+ALTER TABLE HR.EMP DROP PRIMARY KEY;
+ALTER TABLE HR.EMP DROP UNIQUE (NAME, BIRTH);
+`; got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+}
@@ -0,0 +1,529 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// Diagnostic is a problem found in the source, with its position.
+type Diagnostic struct {
+	Chunk
+	// File is set when several files are analysed together.
+	File string
+	// Line and Column are 1-based.
+	Line, Column int
+	Message      string
+}
+
+func (d Diagnostic) String() string {
+	if d.File != "" {
+		return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s", d.Line, d.Column, d.Message)
+}
+
+func newDiagnostic(ctx antlr.ParserRuleContext, format string, args ...interface{}) Diagnostic {
+	return Diagnostic{
+		Chunk: ctxChunk(ctx), Line: ctx.GetStart().GetLine(), Column: ctx.GetStart().GetColumn() + 1,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Check the DML statements of the text against the catalog.
+//
+// It reports unknown tables and columns, ambiguous column references,
+// INSERT column/value count mismatches, literals not matching the column's type
+// and NOT NULL columns missing from INSERTs.
+//
+// The returned error is only for syntax errors.
+func Check(stmt string, cat *catalog.Catalog, opts ...Options) ([]Diagnostic, error) {
+	parser := options(opts).newParser(stmt)
+	cl := &checkListener{
+		BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()},
+		Catalog:          cat,
+		queries:          make(map[string]struct{}),
+	}
+	parser.AddErrorListener(cl)
+	tree := parser.Sql_script()
+	if cl.Err != nil {
+		return nil, cl.Err
+	}
+	antlr.ParseTreeWalkerDefault.Walk(cl, tree)
+	return cl.Diagnostics, nil
+}
+
+type checkListener struct {
+	BaseWalkListener
+	Catalog     *catalog.Catalog
+	Diagnostics []Diagnostic
+
+	// scopes is the stack of the open query scopes, innermost last
+	scopes []*checkScope
+	// done are the closed scopes of the current statement
+	done []*checkScope
+	// queries are the names defined in WITH clauses
+	queries map[string]struct{}
+	// plsql is the depth of PL/SQL code, where unknown identifiers may be variables
+	plsql int
+	// into is the depth of INTO clauses, which contain variables, not columns
+	into int
+}
+
+type checkScope struct {
+	parent  *checkScope
+	tables  []checkTable
+	aliases map[string]struct{}
+	refs    []columnRef
+
+	// target of an INSERT
+	target *checkTable
+	// selected is the number of selected columns of the first query in an INSERT ... SELECT,
+	// -1 for *
+	selected *int
+}
+
+type checkTable struct {
+	Alias, Name string
+	// Table is nil if the table is not in the catalog
+	Table *catalog.Table
+}
+
+type columnRef struct {
+	Qualifier, Name string
+	ctx             antlr.ParserRuleContext
+}
+
+// pseudoColumns can be referenced without any table.
+var pseudoColumns = map[string]bool{
+	"ROWNUM": true, "ROWID": true, "LEVEL": true, "ORA_ROWSCN": true,
+	"SYSDATE": true, "SYSTIMESTAMP": true, "CURRENT_DATE": true, "CURRENT_TIMESTAMP": true, "LOCALTIMESTAMP": true,
+	"USER": true, "UID": true, "NULL": true, "TRUE": true, "FALSE": true,
+	"SQLCODE": true, "SQLERRM": true, "COLUMN_VALUE": true, "OBJECT_VALUE": true,
+	"CONNECT_BY_ISLEAF": true, "CONNECT_BY_ISCYCLE": true,
+}
+
+func (cl *checkListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	cl.AddError(fmt.Errorf("%d:%d: %s", line, column+1, msg))
+}
+
+func (cl *checkListener) addDiagnostic(ctx antlr.ParserRuleContext, format string, args ...interface{}) {
+	cl.Diagnostics = append(cl.Diagnostics, newDiagnostic(ctx, format, args...))
+}
+
+func (cl *checkListener) push() {
+	s := &checkScope{aliases: make(map[string]struct{})}
+	if n := len(cl.scopes); n != 0 {
+		s.parent = cl.scopes[n-1]
+	}
+	cl.scopes = append(cl.scopes, s)
+}
+func (cl *checkListener) top() *checkScope {
+	if len(cl.scopes) == 0 {
+		return nil
+	}
+	return cl.scopes[len(cl.scopes)-1]
+}
+
+// pop the innermost scope, and resolve the column references
+// when the outermost scope of the statement is closed,
+// as a scalar subquery in the select list can refer to tables of the FROM clause that follows.
+func (cl *checkListener) pop() {
+	cl.done = append(cl.done, cl.top())
+	cl.scopes = cl.scopes[:len(cl.scopes)-1]
+	if len(cl.scopes) != 0 {
+		return
+	}
+	for _, s := range cl.done {
+		for _, ref := range s.refs {
+			cl.resolve(s, ref)
+		}
+	}
+	cl.done = cl.done[:0]
+}
+
+func (cl *checkListener) resolve(s *checkScope, ref columnRef) {
+	if ref.Qualifier != "" {
+		for sc := s; sc != nil; sc = sc.parent {
+			for _, t := range sc.tables {
+				if t.Alias == ref.Qualifier || t.Alias == "" && t.Name == ref.Qualifier {
+					if t.Table != nil && t.Table.Column(ref.Name) == nil {
+						cl.addDiagnostic(ref.ctx, "unknown column %s.%s", ref.Qualifier, ref.Name)
+					}
+					return
+				}
+			}
+		}
+		// not a table: package variable, sequence, object attribute...
+		return
+	}
+	if _, ok := s.aliases[ref.Name]; ok || pseudoColumns[ref.Name] {
+		return
+	}
+	for sc := s; sc != nil; sc = sc.parent {
+		var found []string
+		var opaque bool
+		for _, t := range sc.tables {
+			if t.Table == nil {
+				opaque = true
+			} else if t.Table.Column(ref.Name) != nil {
+				found = append(found, t.Name)
+			}
+		}
+		if len(found) > 1 {
+			cl.addDiagnostic(ref.ctx, "column %s is ambiguous (%s)", ref.Name, strings.Join(found, ", "))
+			return
+		}
+		if len(found) == 1 || opaque {
+			return
+		}
+	}
+	if cl.plsql == 0 {
+		cl.addDiagnostic(ref.ctx, "unknown column %s", ref.Name)
+	}
+}
+
+// table resolves the table expression in the catalog.
+func (cl *checkListener) table(dml plsql.IDml_table_expression_clauseContext, alias plsql.ITable_aliasContext) checkTable {
+	var t checkTable
+	if alias != nil {
+		t.Alias = catalog.Normalize(alias.GetText())
+	}
+	d, ok := dml.(*plsql.Dml_table_expression_clauseContext)
+	if !ok || d.Tableview_name() == nil {
+		// inline view or collection
+		return t
+	}
+	tv := d.Tableview_name().(*plsql.Tableview_nameContext)
+	if tv.Identifier() == nil || tv.AT_SIGN() != nil {
+		// XMLTABLE or remote table
+		return t
+	}
+	name := tv.Identifier().GetText()
+	t.Name = catalog.Normalize(name)
+	if id := tv.Id_expression(); id != nil {
+		t.Name = catalog.Normalize(id.GetText())
+		name += "." + id.GetText()
+	}
+	if _, ok := cl.queries[t.Name]; ok {
+		return t
+	}
+	if t.Table = cl.Catalog.Table(name); t.Table == nil {
+		cl.addDiagnostic(tv, "unknown table %s", name)
+	}
+	return t
+}
+
+func (cl *checkListener) EnterSeq_of_statements(ctx *plsql.Seq_of_statementsContext) { cl.plsql++ }
+func (cl *checkListener) ExitSeq_of_statements(ctx *plsql.Seq_of_statementsContext)  { cl.plsql-- }
+func (cl *checkListener) EnterInto_clause(ctx *plsql.Into_clauseContext)             { cl.into++ }
+func (cl *checkListener) ExitInto_clause(ctx *plsql.Into_clauseContext)              { cl.into-- }
+
+func (cl *checkListener) EnterFactoring_element(ctx *plsql.Factoring_elementContext) {
+	cl.queries[catalog.Normalize(ctx.Query_name().GetText())] = struct{}{}
+}
+
+func (cl *checkListener) EnterQuery_block(ctx *plsql.Query_blockContext) { cl.push() }
+func (cl *checkListener) ExitQuery_block(ctx *plsql.Query_blockContext) {
+	if p := cl.top().parent; p != nil && p.target != nil && p.selected == nil {
+		sl := ctx.Selected_list().(*plsql.Selected_listContext)
+		n := -1
+		if sl.ASTERISK() == nil {
+			n = len(sl.AllSelect_list_elements())
+		}
+		p.selected = &n
+	}
+	cl.pop()
+}
+
+func (cl *checkListener) EnterUpdate_statement(ctx *plsql.Update_statementContext) { cl.push() }
+func (cl *checkListener) ExitUpdate_statement(ctx *plsql.Update_statementContext)  { cl.pop() }
+func (cl *checkListener) EnterDelete_statement(ctx *plsql.Delete_statementContext) { cl.push() }
+func (cl *checkListener) ExitDelete_statement(ctx *plsql.Delete_statementContext)  { cl.pop() }
+func (cl *checkListener) EnterSingle_table_insert(ctx *plsql.Single_table_insertContext) {
+	cl.push()
+	cl.top().target = &checkTable{}
+}
+
+func (cl *checkListener) ExitGeneral_table_ref(ctx *plsql.General_table_refContext) {
+	s := cl.top()
+	if s == nil {
+		return
+	}
+	t := cl.table(ctx.Dml_table_expression_clause(), ctx.Table_alias())
+	if _, ok := ctx.GetParent().(*plsql.Insert_into_clauseContext); ok && s.target != nil {
+		*s.target = t
+		return
+	}
+	s.tables = append(s.tables, t)
+}
+
+func (cl *checkListener) ExitTable_ref_aux(ctx *plsql.Table_ref_auxContext) {
+	s := cl.top()
+	if s == nil {
+		return
+	}
+	var dml plsql.IDml_table_expression_clauseContext
+	switch x := ctx.Table_ref_aux_internal().(type) {
+	case *plsql.Table_ref_aux_internal_oneContext:
+		dml = x.Dml_table_expression_clause()
+	case *plsql.Table_ref_aux_internal_threeContext:
+		dml = x.Dml_table_expression_clause()
+	default:
+		// parenthesized table_ref: its tables are already registered
+		return
+	}
+	s.tables = append(s.tables, cl.table(dml, ctx.Table_alias()))
+}
+
+func (cl *checkListener) ExitSelect_list_elements(ctx *plsql.Select_list_elementsContext) {
+	s := cl.top()
+	if s == nil || ctx.Column_alias() == nil {
+		return
+	}
+	ca := ctx.Column_alias().(*plsql.Column_aliasContext)
+	if ca.Identifier() != nil {
+		s.aliases[catalog.Normalize(ca.Identifier().GetText())] = struct{}{}
+	} else if ca.Quoted_string() != nil {
+		s.aliases[catalog.Normalize(ca.Quoted_string().GetText())] = struct{}{}
+	}
+}
+
+func (cl *checkListener) ExitGeneral_element(ctx *plsql.General_elementContext) {
+	s := cl.top()
+	if s == nil || cl.into != 0 {
+		return
+	}
+	var names []string
+	for _, p := range ctx.AllGeneral_element_part() {
+		p := p.(*plsql.General_element_partContext)
+		if p.Function_argument() != nil || p.AT_SIGN() != nil {
+			// function call or remote object
+			return
+		}
+		for _, id := range p.AllId_expression() {
+			names = append(names, catalog.Normalize(id.GetText()))
+		}
+	}
+	cl.addRef(s, ctx, names)
+}
+
+func (cl *checkListener) ExitTable_element(ctx *plsql.Table_elementContext) {
+	s := cl.top()
+	if s == nil {
+		return
+	}
+	var names []string
+	for _, id := range ctx.AllId_expression() {
+		names = append(names, catalog.Normalize(id.GetText()))
+	}
+	cl.addRef(s, ctx, names)
+}
+
+func (cl *checkListener) addRef(s *checkScope, ctx antlr.ParserRuleContext, names []string) {
+	switch len(names) {
+	case 1:
+		s.refs = append(s.refs, columnRef{Name: names[0], ctx: ctx})
+	case 2, 3:
+		last := names[len(names)-1]
+		if last == "NEXTVAL" || last == "CURRVAL" {
+			return
+		}
+		s.refs = append(s.refs, columnRef{Qualifier: names[len(names)-2], Name: last, ctx: ctx})
+	}
+}
+
+func (cl *checkListener) ExitColumn_based_update_set_clause(ctx *plsql.Column_based_update_set_clauseContext) {
+	s := cl.top()
+	if s == nil || len(s.tables) == 0 || s.tables[0].Table == nil {
+		return
+	}
+	tbl := s.tables[0].Table
+	if cn := ctx.Column_name(); cn != nil {
+		if col := cl.column(tbl, cn); col != nil && ctx.Expression() != nil {
+			cl.checkValue(col, ctx.Expression())
+		}
+		return
+	}
+	if pcl := ctx.Paren_column_list(); pcl != nil {
+		for _, cn := range pcl.(*plsql.Paren_column_listContext).Column_list().(*plsql.Column_listContext).AllColumn_name() {
+			cl.column(tbl, cn)
+		}
+	}
+}
+
+func (cl *checkListener) ExitSingle_table_insert(ctx *plsql.Single_table_insertContext) {
+	s := cl.top()
+	defer cl.pop()
+	if s.target == nil || s.target.Table == nil {
+		return
+	}
+	tbl := s.target.Table
+
+	var cols []*catalog.Column
+	iic := ctx.Insert_into_clause().(*plsql.Insert_into_clauseContext)
+	if pcl := iic.Paren_column_list(); pcl != nil {
+		listed := make(map[string]struct{})
+		for _, cn := range pcl.(*plsql.Paren_column_listContext).Column_list().(*plsql.Column_listContext).AllColumn_name() {
+			col := cl.column(tbl, cn)
+			cols = append(cols, col)
+			if col != nil {
+				listed[col.Name] = struct{}{}
+			}
+		}
+		for _, col := range tbl.Columns {
+			if _, ok := listed[col.Name]; !ok && !col.Nullable && col.Default == "" {
+				cl.addDiagnostic(iic, "NOT NULL column %s of %s is missing", col.Name, tbl.Name)
+			}
+		}
+	} else {
+		for i := range tbl.Columns {
+			cols = append(cols, &tbl.Columns[i])
+		}
+	}
+
+	if vc := ctx.Values_clause(); vc != nil {
+		exprs := vc.(*plsql.Values_clauseContext).Expressions()
+		if exprs == nil {
+			// VALUES record
+			return
+		}
+		values := exprs.(*plsql.ExpressionsContext).AllExpression()
+		if len(values) != len(cols) {
+			cl.addDiagnostic(vc, "INSERT INTO %s has %d columns but %d values", tbl.Name, len(cols), len(values))
+		}
+		for i, v := range values {
+			if i < len(cols) && cols[i] != nil {
+				cl.checkValue(cols[i], v)
+			}
+		}
+	} else if s.selected != nil && *s.selected >= 0 && *s.selected != len(cols) {
+		cl.addDiagnostic(ctx, "INSERT INTO %s has %d columns but %d selected", tbl.Name, len(cols), *s.selected)
+	}
+}
+
+// column returns the named column of the table, or adds a diagnostic.
+func (cl *checkListener) column(tbl *catalog.Table, cn plsql.IColumn_nameContext) *catalog.Column {
+	ids := cn.(*plsql.Column_nameContext).AllId_expression()
+	name := cn.(*plsql.Column_nameContext).Identifier().GetText()
+	if len(ids) != 0 {
+		name = ids[len(ids)-1].GetText()
+	}
+	col := tbl.Column(name)
+	if col == nil {
+		cl.addDiagnostic(cn, "unknown column %s.%s", tbl.Name, catalog.Normalize(name))
+	}
+	return col
+}
+
+// checkValue checks whether the value is a literal fitting into the column.
+func (cl *checkListener) checkValue(col *catalog.Column, value antlr.ParserRuleContext) {
+	c := literal(value)
+	if c == nil {
+		return
+	}
+	class := typeClass(col.DataType)
+	switch {
+	case c.NULL_() != nil:
+		if !col.Nullable {
+			cl.addDiagnostic(value, "NULL into NOT NULL column %s", col.Name)
+		}
+
+	case c.DATE() != nil || c.TIMESTAMP() != nil:
+		if class != 'D' && class != 0 {
+			cl.addDiagnostic(value, "datetime literal %s into %s column %s", c.GetText(), col, col.Name)
+		}
+
+	case c.Numeric() != nil:
+		if class == 'D' {
+			cl.addDiagnostic(value, "number %s into %s column %s", c.GetText(), col, col.Name)
+		} else if class == 'N' && col.Precision != 0 {
+			digits := strings.TrimLeft(c.GetText(), "0")
+			if i := strings.IndexAny(digits, ".EeDdFf"); i >= 0 {
+				digits = digits[:i]
+			}
+			if len(digits) > col.Precision-col.Scale {
+				cl.addDiagnostic(value, "number %s is too large for %s column %s", c.GetText(), col, col.Name)
+			}
+		}
+
+	case len(c.AllQuoted_string()) != 0:
+		qs := c.Quoted_string(0).(*plsql.Quoted_stringContext)
+		var s string
+		if t := qs.CHAR_STRING(); t != nil {
+			s = unquote(t.GetText())
+		} else if t := qs.NATIONAL_CHAR_STRING_LIT(); t != nil {
+			s = unquote(t.GetText()[1:])
+		} else {
+			return
+		}
+		switch class {
+		case 'N':
+			if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+				cl.addDiagnostic(value, "string %s into %s column %s", qs.GetText(), col, col.Name)
+			}
+		case 'C':
+			if col.Length != 0 && utf8.RuneCountInString(s) > col.Length {
+				cl.addDiagnostic(value, "string %s is too long for %s column %s", qs.GetText(), col, col.Name)
+			}
+		}
+	}
+}
+
+// literal returns the constant if the tree is just a (possibly signed) constant.
+func literal(tree antlr.Tree) *plsql.ConstantContext {
+	for tree != nil {
+		switch x := tree.(type) {
+		case *plsql.ConstantContext:
+			return x
+		case *plsql.Unary_expressionContext:
+			if x.GetChildCount() == 2 {
+				if t, ok := x.GetChild(0).(antlr.TerminalNode); ok && (t.GetText() == "-" || t.GetText() == "+") {
+					tree = x.GetChild(1)
+					continue
+				}
+			}
+		}
+		if tree.GetChildCount() != 1 {
+			return nil
+		}
+		tree = tree.GetChild(0)
+	}
+	return nil
+}
+
+// typeClass returns 'N' for numeric, 'C' for character and 'D' for datetime types, 0 for others.
+func typeClass(dataType string) byte {
+	switch {
+	case dataType == "NUMBER" || dataType == "FLOAT" || dataType == "INTEGER" ||
+		dataType == "BINARY_FLOAT" || dataType == "BINARY_DOUBLE":
+		return 'N'
+	case dataType == "VARCHAR2" || dataType == "NVARCHAR2" || dataType == "VARCHAR" ||
+		dataType == "CHAR" || dataType == "NCHAR" ||
+		dataType == "CLOB" || dataType == "NCLOB" || dataType == "LONG":
+		return 'C'
+	case dataType == "DATE" || strings.HasPrefix(dataType, "TIMESTAMP"):
+		return 'D'
+	}
+	return 0
+}
+
+// unquote the 'string' or q'[string]' literal.
+func unquote(s string) string {
+	if len(s) > 4 && (s[0] == 'Q' || s[0] == 'q') && s[1] == '\'' {
+		return s[3 : len(s)-2]
+	}
+	if len(s) < 2 {
+		return s
+	}
+	return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+)
+
+var testCatalog = &catalog.Catalog{
+	Tables: []catalog.Table{
+		{Owner: "SCOTT", Name: "EMP", Columns: []catalog.Column{
+			{Name: "EMPNO", DataType: "NUMBER", Precision: 4},
+			{Name: "ENAME", DataType: "VARCHAR2", Length: 10, Nullable: true},
+			{Name: "HIREDATE", DataType: "DATE", Nullable: true},
+			{Name: "DEPTNO", DataType: "NUMBER", Precision: 2, Nullable: true},
+		}},
+		{Owner: "SCOTT", Name: "DEPT", Columns: []catalog.Column{
+			{Name: "DEPTNO", DataType: "NUMBER", Precision: 2},
+			{Name: "DNAME", DataType: "VARCHAR2", Length: 14, Nullable: true},
+		}},
+	},
+}
+
+func TestCheck(t *testing.T) {
+	for i, tc := range []struct {
+		Stmt string
+		Want []string
+	}{
+		{Stmt: "INSERT INTO emp (empno, ename) VALUES (1, 'KING');"},
+		{Stmt: "SELECT e.ename, d.dname FROM emp e JOIN dept d ON d.deptno = e.deptno;"},
+		{Stmt: "SELECT ename FROM emps;", Want: []string{"unknown table EMPS"}},
+		{Stmt: "SELECT e.enam FROM emp e;", Want: []string{"unknown column E.ENAM"}},
+		{Stmt: "SELECT deptno FROM emp, dept;", Want: []string{"column DEPTNO is ambiguous"}},
+		{Stmt: "INSERT INTO emp (empno, ename) VALUES (1);", Want: []string{"has 2 columns but 1 values"}},
+		{Stmt: "INSERT INTO emp (ename) VALUES ('KING');", Want: []string{"NOT NULL column EMPNO"}},
+		{Stmt: "INSERT INTO emp (empno, hiredate) VALUES ('x', 1);", Want: []string{
+			"string 'x' into NUMBER(4) column EMPNO",
+			"number 1 into DATE column HIREDATE",
+		}},
+		{Stmt: "UPDATE emp SET ename = 'TOO LONG NAME' WHERE empno = 1;", Want: []string{"too long"}},
+		{Stmt: "INSERT INTO emp (empno, ename) SELECT deptno FROM dept;", Want: []string{"has 2 columns but 1 selected"}},
+	} {
+		diags, err := plsqlparser.Check(tc.Stmt, testCatalog)
+		if err != nil {
+			t.Fatalf("%d. %q: %+v", i, tc.Stmt, err)
+		}
+		if len(diags) != len(tc.Want) {
+			t.Errorf("%d. %q: got %v, wanted %q", i, tc.Stmt, diags, tc.Want)
+			continue
+		}
+		for j, d := range diags {
+			if !strings.Contains(d.Message, tc.Want[j]) {
+				t.Errorf("%d. %q: got %q, wanted %q", i, tc.Stmt, d.Message, tc.Want[j])
+			}
+		}
+	}
+}
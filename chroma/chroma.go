@@ -0,0 +1,170 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chroma registers the chroma lexer of Oracle SQL, PL/SQL and SQL*Plus scripts.
+package chroma
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/UNO-SOFT/plsql-parser/internal/casefold"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+)
+
+// Lexer is a chroma.Lexer for Oracle SQL, PL/SQL and SQL*Plus scripts, backed by the PlSqlLexer,
+// registered in the chroma lexers as "plsql" and "oracle".
+//
+// The token classes come from the token vocabulary of the lexer (PlSqlLexer.tokens):
+// the keywords (the data types as KeywordType, TRUE, FALSE and NULL as KeywordConstant),
+// identifiers, bind variables, string (including q'[...]') and numeric literals, operators, punctuation,
+// comments (including REM lines), and the SQL*Plus commands (PROMPT, @file, a lone /) as CommentPreproc.
+//
+// The characters the PlSqlLexer cannot tokenize are returned as Error tokens.
+var Lexer = lexers.Register(chromaLexer{config: &chroma.Config{
+	Name:            "plsql",
+	Aliases:         []string{"oracle"},
+	Filenames:       []string{"*.pks", "*.pkb", "*.pls", "*.plb", "*.prc", "*.fnc", "*.trg"},
+	MimeTypes:       []string{"text/x-plsql"},
+	CaseInsensitive: true,
+}})
+
+type chromaLexer struct{ config *chroma.Config }
+
+func (l chromaLexer) Config() *chroma.Config { return l.config }
+
+func (l chromaLexer) Tokenise(options *chroma.TokeniseOptions, text string) (chroma.Iterator, error) {
+	if options != nil && options.EnsureLF {
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	}
+	lexer := plsql.NewPlSqlLexer(casefold.NewStream(text))
+	lexer.RemoveErrorListeners()
+	types := chromaTypes(lexer.SymbolicNames, lexer.LiteralNames)
+
+	rs := []rune(text)
+	var tokens []chroma.Token
+	var pos int
+	add := func(typ chroma.TokenType, start, stop int) {
+		if pos < start {
+			// skipped by the lexer
+			tokens = append(tokens, chroma.Token{Type: chroma.Error, Value: string(rs[pos:start])})
+		}
+		tokens = append(tokens, chroma.Token{Type: typ, Value: string(rs[start : stop+1])})
+		pos = stop + 1
+	}
+	for _, tok := range lexer.GetAllTokens() {
+		start, stop := tok.GetStart(), tok.GetStop()
+		if stop < start || start < pos || stop >= len(rs) {
+			continue
+		}
+		typ := chroma.Other
+		if tt := tok.GetTokenType(); tt > 0 && tt < len(types) {
+			typ = types[tt]
+		}
+		if tok.GetTokenType() == plsql.PlSqlLexerSOLIDUS && lineAlone(rs, start, stop) {
+			typ = chroma.CommentPreproc
+		}
+		add(typ, start, stop)
+	}
+	if pos < len(rs) {
+		tokens = append(tokens, chroma.Token{Type: chroma.Error, Value: string(rs[pos:])})
+	}
+	return chroma.Literator(tokens...), nil
+}
+
+// lineAlone reports whether the start-stop runes are alone on their line, but for spaces.
+func lineAlone(rs []rune, start, stop int) bool {
+	for i := start - 1; i >= 0 && rs[i] != '\n'; i-- {
+		if !unicode.IsSpace(rs[i]) {
+			return false
+		}
+	}
+	for i := stop + 1; i < len(rs) && rs[i] != '\n'; i++ {
+		if !unicode.IsSpace(rs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	chromaTypesOnce sync.Once
+	chromaTypesMap  []chroma.TokenType
+)
+
+// chromaTypes returns the chroma token types of the lexer's token types.
+func chromaTypes(symbolicNames, literalNames []string) []chroma.TokenType {
+	chromaTypesOnce.Do(func() {
+		chromaTypesMap = make([]chroma.TokenType, len(symbolicNames))
+		for i, name := range symbolicNames {
+			var lit string
+			if i < len(literalNames) {
+				lit = strings.Trim(literalNames[i], "'")
+			}
+			chromaTypesMap[i] = chromaTokenType(name, lit)
+		}
+	})
+	return chromaTypesMap
+}
+
+// chromaDataTypes are the tokens of the data types.
+var chromaDataTypes = map[string]bool{
+	"BFILE": true, "BINARY_DOUBLE": true, "BINARY_FLOAT": true, "BINARY_INTEGER": true, "BLOB": true,
+	"BOOLEAN": true, "CHAR": true, "CHARACTER": true, "CLOB": true, "DATE": true, "DEC": true, "DECIMAL": true,
+	"FLOAT": true, "INT": true, "INTEGER": true, "INTERVAL": true, "LONG": true, "NATURAL": true, "NATURALN": true,
+	"NCHAR": true, "NCLOB": true, "NUMBER": true, "NUMERIC": true, "NVARCHAR2": true, "PLS_INTEGER": true,
+	"POSITIVE": true, "POSITIVEN": true, "RAW": true, "REAL": true, "ROWID": true, "SIGNTYPE": true,
+	"SIMPLE_INTEGER": true, "SMALLINT": true, "STRING": true, "TIMESTAMP": true, "UROWID": true,
+	"VARCHAR": true, "VARCHAR2": true, "XMLTYPE": true,
+}
+
+// chromaTokenType returns the chroma token type of the lexer token, by its symbolic and literal name.
+func chromaTokenType(name, lit string) chroma.TokenType {
+	switch name {
+	case "SPACES":
+		return chroma.TextWhitespace
+	case "SINGLE_LINE_COMMENT", "REMARK_COMMENT":
+		return chroma.CommentSingle
+	case "MULTI_LINE_COMMENT":
+		return chroma.CommentMultiline
+	case "PROMPT_MESSAGE", "START_CMD":
+		return chroma.CommentPreproc
+	case "CHAR_STRING", "NATIONAL_CHAR_STRING_LIT":
+		return chroma.LiteralStringSingle
+	case "BIT_STRING_LIT":
+		return chroma.LiteralNumberBin
+	case "HEX_STRING_LIT":
+		return chroma.LiteralNumberHex
+	case "UNSIGNED_INTEGER":
+		return chroma.LiteralNumberInteger
+	case "APPROXIMATE_NUM_LIT":
+		return chroma.LiteralNumberFloat
+	case "REGULAR_ID", "DELIMITED_ID":
+		return chroma.Name
+	case "BINDVAR":
+		return chroma.NameVariable
+	case "TRUE", "FALSE", "NULL_":
+		return chroma.KeywordConstant
+	}
+	switch {
+	case chromaDataTypes[name]:
+		return chroma.KeywordType
+	case lit == "":
+		if strings.HasPrefix(name, "PERCENT_") { // %TYPE, %ROWTYPE, %FOUND, ...
+			return chroma.Keyword
+		}
+		if strings.HasSuffix(name, "_OP") {
+			return chroma.Operator
+		}
+		return chroma.Other
+	case unicode.IsLetter(rune(lit[0])):
+		return chroma.Keyword
+	case lit == "(" || lit == ")" || lit == "[" || lit == "]" || lit == "," || lit == ";" || lit == "." || lit == "..":
+		return chroma.Punctuation
+	}
+	return chroma.Operator
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package chroma_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+
+	plsqlchroma "github.com/UNO-SOFT/plsql-parser/chroma"
+)
+
+func TestLexer(t *testing.T) {
+	for _, name := range []string{"plsql", "oracle", "PLSQL"} {
+		if l := lexers.Get(name); l != plsqlchroma.Lexer {
+			t.Errorf("%q: got %v", name, l)
+		}
+	}
+	if l := lexers.Match("pkg.pkb"); l != plsqlchroma.Lexer {
+		t.Errorf("pkg.pkb: got %v", l)
+	}
+
+	const text = "REM header\nPROMPT creating\nSELECT q'[it's]', 1.5, :b, \"Col\", NVL(x, 0) FROM t; -- end\n/\nDECLARE v NUMBER := TRUE; BEGIN NULL; END;\n"
+	it, err := plsqlchroma.Lexer.Tokenise(&chroma.TokeniseOptions{EnsureLF: true}, text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	var got []string
+	for tok := it(); tok != chroma.EOF; tok = it() {
+		buf.WriteString(tok.Value)
+		if tok.Type != chroma.TextWhitespace {
+			got = append(got, fmt.Sprintf("%s:%s", tok.Type, strings.TrimSpace(tok.Value)))
+		}
+	}
+	if buf.String() != text {
+		t.Errorf("the tokens are %q", buf.String())
+	}
+	want := []string{
+		"CommentSingle:REM header", "CommentPreproc:PROMPT creating",
+		"Keyword:SELECT", "LiteralStringSingle:q'[it's]'", "Punctuation:,",
+		"LiteralNumberFloat:1.5", "Punctuation:,", "NameVariable::b", "Punctuation:,",
+		`Name:"Col"`, "Punctuation:,", "Keyword:NVL", "Punctuation:(", "Name:x", "Punctuation:,",
+		"LiteralNumberInteger:0", "Punctuation:)", "Keyword:FROM", "Name:t", "Punctuation:;",
+		"CommentSingle:-- end", "CommentPreproc:/",
+		"Keyword:DECLARE", "Name:v", "KeywordType:NUMBER", "Operator::=", "KeywordConstant:TRUE", "Punctuation:;",
+		"Keyword:BEGIN", "KeywordConstant:NULL", "Punctuation:;", "Keyword:END", "Punctuation:;",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwanted\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
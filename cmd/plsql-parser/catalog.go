@@ -0,0 +1,51 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	_ "github.com/godror/godror"
+)
+
+func catalogMain(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "dump" {
+		return fmt.Errorf("usage: catalog dump [-connect user/passw@sid] [-o catalog.json] [SCHEMA...]")
+	}
+	fs := flag.NewFlagSet("catalog dump", flag.ContinueOnError)
+	flagConnect := fs.String("connect", os.Getenv("DB_ID"), "database connection string")
+	flagOut := fs.String("o", "-", "output file")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *flagConnect == "" {
+		return fmt.Errorf("-connect is required")
+	}
+	db, err := sql.Open("godror", *flagConnect)
+	if err != nil {
+		return fmt.Errorf("connect to %q: %w", *flagConnect, err)
+	}
+	defer db.Close()
+
+	cat, err := catalog.LoadFromDB(ctx, db, fs.Args()...)
+	if err != nil {
+		return err
+	}
+	if *flagOut == "" || *flagOut == "-" {
+		return cat.WriteJSON(os.Stdout)
+	}
+	fh, err := os.Create(*flagOut)
+	if err != nil {
+		return err
+	}
+	if err := cat.WriteJSON(fh); err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
@@ -3,24 +3,25 @@
 package main
 
 import (
-	"log"
+	"fmt"
+	"io"
 
 	"github.com/alecthomas/chroma"
-	"github.com/alecthomas/chroma/lexers"
-	"github.com/alecthomas/chroma/lexers/p"
-	"github.com/alecthomas/chroma/lexers/s"
+
+	plsqlchroma "github.com/UNO-SOFT/plsql-parser/chroma"
 )
 
-func ChromaParse(text string) error {
-	lexer := chroma.DelegatingLexer(s.SQL,
-		chroma.DelegatingLexer(p.PLpgSQL, lexers.Fallback))
+// ChromaParse writes the tokens of the text to w.
+func ChromaParse(w io.Writer, text string) error {
 	opts := chroma.TokeniseOptions{EnsureLF: true}
-	it, err := lexer.Tokenise(&opts, text)
+	it, err := plsqlchroma.Lexer.Tokenise(&opts, text)
 	if err != nil {
 		return err
 	}
 	for tok := it(); tok != chroma.EOF; tok = it() {
-		log.Println(tok)
+		if _, err := fmt.Fprintln(w, tok); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,99 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+// sourceExts are the extensions of the PL/SQL source files searched for in directories.
+var sourceExts = map[string]bool{
+	".pks": true, ".pkb": true, ".pls": true, ".plb": true, ".sql": true,
+	".prc": true, ".fnc": true, ".trg": true,
+}
+
+// readSources reads the named files, and the PL/SQL sources under the named directories.
+func readSources(paths []string) ([]plsqlparser.File, error) {
+	var files []plsqlparser.File
+	read := func(fn string) error {
+		b, err := os.ReadFile(fn)
+		if err != nil {
+			return err
+		}
+		files = append(files, plsqlparser.File{Name: fn, Text: string(b)})
+		return nil
+	}
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			if err := read(p); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := filepath.WalkDir(p, func(fn string, de fs.DirEntry, err error) error {
+			if err != nil || de.IsDir() || !sourceExts[strings.ToLower(filepath.Ext(fn))] {
+				return err
+			}
+			return read(fn)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func deadcodeMain(args []string) error {
+	fs := flag.NewFlagSet("deadcode", flag.ContinueOnError)
+	flagAllow := fs.String("allow", "", "comma-separated list of entry points (PKG.MAIN, PKG.*)")
+	flagAllowFile := fs.String("allow-file", "", "file listing the entry points, one per line")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: deadcode [-allow PKG.MAIN,...] [-allow-file allow.txt] DIR|FILE...")
+	}
+	var allow []string
+	if *flagAllow != "" {
+		allow = strings.Split(*flagAllow, ",")
+	}
+	if *flagAllowFile != "" {
+		fh, err := os.Open(*flagAllowFile)
+		if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(fh)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+				allow = append(allow, line)
+			}
+		}
+		fh.Close()
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+	files, err := readSources(fs.Args())
+	if err != nil {
+		return err
+	}
+	ds, err := plsqlparser.DeadCodeOptions(files, allow, parseOpts)
+	if err != nil {
+		return err
+	}
+	for _, d := range ds {
+		fmt.Println(d)
+	}
+	return nil
+}
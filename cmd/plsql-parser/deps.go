@@ -0,0 +1,52 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func depsMain(args []string) error {
+	fs := flag.NewFlagSet("deps", flag.ContinueOnError)
+	flagEdges := fs.Bool("edges", false, "print the dependencies of each object after it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: deps [-edges] DIR|FILE...")
+	}
+	files, err := readSources(fs.Args())
+	if err != nil {
+		return err
+	}
+	g, err := plsqlparser.Dependencies(files, parseOpts)
+	if err != nil {
+		return err
+	}
+	order, cycles := g.Order()
+	for _, i := range order {
+		o := g.Objects[i]
+		fmt.Printf("%s\t%s:%d\n", o, o.File, o.Line)
+		if *flagEdges {
+			for _, d := range g.Dependencies {
+				if d.From == i {
+					fmt.Printf("\t%s\n", g.Describe(d))
+				}
+			}
+		}
+	}
+	for _, cycle := range cycles {
+		fmt.Fprintln(os.Stderr, "dependency cycle:")
+		for _, d := range cycle {
+			fmt.Fprintf(os.Stderr, "\t%s\n", g.Describe(d))
+		}
+	}
+	if len(cycles) != 0 {
+		return fmt.Errorf("found %d dependency cycles", len(cycles))
+	}
+	return nil
+}
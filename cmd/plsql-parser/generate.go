@@ -0,0 +1,55 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func generateMain(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	var opts plsqlparser.GenerateOptions
+	fs.Int64Var(&opts.Seed, "seed", 1, "seed of the random generator")
+	fs.IntVar(&opts.MaxDepth, "depth", 12, "depth of rules after which the shortest alternatives are chosen")
+	fs.IntVar(&opts.MaxSize, "size", 200, "number of tokens after which the shortest alternatives are chosen")
+	fs.IntVar(&opts.MaxRepeat, "repeat", 3, "maximum number of repetitions")
+	flagWeights := fs.String("weight", "", "weights of the alternatives referencing rules or tokens: sql_plus_command=0,select_statement=5")
+	flagN := fs.Int("n", 10, "number of texts to generate")
+	flagRule := fs.String("rule", "unit_statement", "grammar rule to generate")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *flagWeights != "" {
+		opts.Weights = make(map[string]float64)
+		for _, kv := range strings.Split(*flagWeights, ",") {
+			k, v, _ := strings.Cut(kv, "=")
+			w, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("weight %q: %w", kv, err)
+			}
+			opts.Weights[strings.TrimSpace(k)] = w
+		}
+	}
+	g := plsqlparser.NewGenerator(opts)
+	bw := bufio.NewWriter(os.Stdout)
+	defer bw.Flush()
+	for i := 0; i < *flagN; i++ {
+		text, err := g.Generate(*flagRule)
+		if err != nil {
+			return err
+		}
+		bw.WriteString(text)
+		if !strings.HasSuffix(text, ";") {
+			bw.WriteString("\n;")
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
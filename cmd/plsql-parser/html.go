@@ -0,0 +1,40 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func htmlMain(args []string) error {
+	fs := flag.NewFlagSet("html", flag.ContinueOnError)
+	flagOut := fs.String("o", "html", "write the pages into this directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: html [-o DIR] DIR|FILE...")
+	}
+	files, err := readSources(fs.Args())
+	if err != nil {
+		return err
+	}
+	pages, err := plsqlparser.RenderHTML(files, parseOpts)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*flagOut, 0755); err != nil {
+		return err
+	}
+	for _, p := range pages {
+		if err := os.WriteFile(filepath.Join(*flagOut, p.Name), []byte(p.Text), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
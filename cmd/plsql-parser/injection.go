@@ -0,0 +1,30 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func injectionMain(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: injection DIR|FILE...")
+	}
+	files, err := readSources(args)
+	if err != nil {
+		return err
+	}
+	injections, err := plsqlparser.FindInjections(files, parseOpts)
+	if err != nil {
+		return err
+	}
+	for _, inj := range injections {
+		fmt.Println(inj)
+	}
+	if len(injections) != 0 {
+		return fmt.Errorf("found %d possible SQL injections", len(injections))
+	}
+	return nil
+}
@@ -3,19 +3,110 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
 
 	plsqlparser "github.com/UNO-SOFT/plsql-parser"
 )
 
+// parseOpts are the Options of the library calls.
+var parseOpts plsqlparser.Options
+
 func main() {
 	if err := Main(); err != nil {
 		log.Fatalf("ERROR: %+v", err)
 	}
 }
 func Main() error {
-	text, _ := io.ReadAll(os.Stdin)
-	return plsqlparser.ChromaParse(string(text))
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `Usage of %s:
+	%[1]s [-v] [-trace] COMMAND ...
+		-v and -trace log the parsing to stderr
+	%[1]s < file.sql
+		print the highlighter tokens of the source read from stdin
+	%[1]s catalog dump [-connect user/passw@sid] [-o catalog.json] [SCHEMA...]
+		dump the data dictionary into the offline JSON catalog
+	%[1]s rename [-w] SYMBOL NEW_NAME FILE...
+		rename the symbol (PKG.PROC.V_X or TABLE.COLUMN) in the files,
+		printing the changes, or writing them back with -w
+	%[1]s metrics [-format json|csv] [-max-complexity N] [-max-nesting N] [-max-loc N] ... FILE...
+		report the code metrics of each procedure, function and trigger,
+		or the ones over the given limits, failing if there is any
+	%[1]s deadcode [-allow PKG.MAIN,...] [-allow-file allow.txt] DIR|FILE...
+		report the uncalled subprograms, unused variables, unreachable statements
+		and handlers of never raised exceptions of the sources (.pks, .pkb, ...)
+	%[1]s injection DIR|FILE...
+		report the flows of parameters into dynamic SQL without DBMS_ASSERT or bind variables,
+		failing if there is any
+	%[1]s profile [-n 20] DIR|FILE...
+		report the time spent in the grammar rules and decisions parsing the sources,
+		the SLL to LL fallbacks and the ambiguities
+	%[1]s generate [-seed 1] [-n 10] [-rule unit_statement] [-depth 12] [-size 200] [-weight RULE=W,...]
+		print random, syntactically valid texts of the grammar rule, separated by ;
+	%[1]s pg [-o DIR] DIR|FILE...
+		translate the sources to PostgreSQL PL/pgSQL, printing them or writing them into DIR,
+		and report what could not be translated to stderr
+	%[1]s html [-o html] DIR|FILE...
+		render the sources into cross-referenced, highlighted HTML pages in the directory,
+		with a "called by" panel for each subprogram and an index page per schema
+	%[1]s schemadiff OLD_DIR|OLD_FILE NEW_DIR|NEW_FILE
+		compare the tables, constraints, indexes, sequences and views created by the DDL scripts,
+		printing the changes as comments, then the ALTER/CREATE/DROP statements of the migration
+	%[1]s deps [-edges] DIR|FILE...
+		print the objects created by the sources in deployment order (-edges: with their dependencies),
+		and report the dependency cycles to stderr, failing if there is any
+`, os.Args[0])
+		flag.PrintDefaults()
+	}
+	flagVerbose := flag.Bool("v", false, "log the syntax errors, ambiguities and parse times to stderr")
+	flagTrace := flag.Bool("trace", false, "log each rule entered and exited, too (implies -v)")
+	flag.Parse()
+	if *flagVerbose || *flagTrace {
+		level := slog.LevelDebug
+		if *flagTrace {
+			level = plsqlparser.LevelTrace
+		}
+		parseOpts.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		text, _ := io.ReadAll(os.Stdin)
+		return ChromaParse(os.Stdout, string(text))
+	}
+	switch args[0] {
+	case "catalog":
+		return catalogMain(ctx, args[1:])
+	case "rename":
+		return renameMain(args[1:])
+	case "metrics":
+		return metricsMain(args[1:])
+	case "deadcode":
+		return deadcodeMain(args[1:])
+	case "injection":
+		return injectionMain(args[1:])
+	case "profile":
+		return profileMain(args[1:])
+	case "generate":
+		return generateMain(args[1:])
+	case "pg":
+		return pgMain(args[1:])
+	case "html":
+		return htmlMain(args[1:])
+	case "schemadiff":
+		return schemadiffMain(args[1:])
+	case "deps":
+		return depsMain(args[1:])
+	}
+	flag.Usage()
+	return fmt.Errorf("unknown command %q", args[0])
 }
@@ -0,0 +1,89 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+type fileMetric struct {
+	File string
+	plsqlparser.Metric
+}
+
+func metricsMain(args []string) error {
+	fs := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	flagFormat := fs.String("format", "json", "output format: json or csv")
+	var limit plsqlparser.Metric
+	fs.IntVar(&limit.LOC, "max-loc", 0, "maximal lines of code")
+	fs.IntVar(&limit.Complexity, "max-complexity", 0, "maximal cyclomatic complexity")
+	fs.IntVar(&limit.Nesting, "max-nesting", 0, "maximal nesting depth")
+	fs.IntVar(&limit.Params, "max-params", 0, "maximal number of parameters")
+	fs.IntVar(&limit.SQL, "max-sql", 0, "maximal number of SQL statements")
+	fs.Float64Var(&limit.Volume, "max-volume", 0, "maximal Halstead volume")
+	fs.Float64Var(&limit.Effort, "max-effort", 0, "maximal Halstead effort")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: metrics [-format json|csv] [-max-complexity N ...] FILE...")
+	}
+	var metrics []fileMetric
+	for _, fn := range fs.Args() {
+		b, err := os.ReadFile(fn)
+		if err != nil {
+			return err
+		}
+		ms, err := plsqlparser.Metrics(string(b), parseOpts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fn, err)
+		}
+		for _, m := range ms {
+			metrics = append(metrics, fileMetric{File: fn, Metric: m})
+		}
+	}
+
+	if limit != (plsqlparser.Metric{}) {
+		var n int
+		for _, m := range metrics {
+			for _, over := range m.Exceeds(limit) {
+				fmt.Printf("%s:%d: %s %s: %s\n", m.File, m.Line, m.Kind, m.Name, over)
+				n++
+			}
+		}
+		if n != 0 {
+			return fmt.Errorf("%d metrics over the limits", n)
+		}
+		return nil
+	}
+
+	switch *flagFormat {
+	case "json":
+		return json.MarshalWrite(os.Stdout, metrics, jsontext.WithIndent("  "))
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"file", "name", "kind", "line", "loc", "complexity", "nesting", "params", "sql",
+			"operators", "operands", "distinct_operators", "distinct_operands", "volume", "difficulty", "effort"})
+		for _, m := range metrics {
+			w.Write([]string{m.File, m.Name, m.Kind,
+				strconv.Itoa(m.Line), strconv.Itoa(m.LOC), strconv.Itoa(m.Complexity), strconv.Itoa(m.Nesting),
+				strconv.Itoa(m.Params), strconv.Itoa(m.SQL),
+				strconv.Itoa(m.Operators), strconv.Itoa(m.Operands),
+				strconv.Itoa(m.DistinctOperators), strconv.Itoa(m.DistinctOperands),
+				strconv.FormatFloat(m.Volume, 'f', 2, 64), strconv.FormatFloat(m.Difficulty, 'f', 2, 64),
+				strconv.FormatFloat(m.Effort, 'f', 2, 64),
+			})
+		}
+		w.Flush()
+		return w.Error()
+	}
+	return fmt.Errorf("unknown format %q", *flagFormat)
+}
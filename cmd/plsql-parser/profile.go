@@ -0,0 +1,64 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func profileMain(args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	flagTop := fs.Int("n", 20, "number of the slowest rules and decisions to print (0 for all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: profile [-n 20] DIR|FILE...")
+	}
+	files, err := readSources(fs.Args())
+	if err != nil {
+		return err
+	}
+	p, err := plsqlparser.ProfileParse(files, parseOpts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	top := func(n int) int {
+		if *flagTop > 0 && *flagTop < n {
+			return *flagTop
+		}
+		return n
+	}
+	ms := func(d time.Duration) string { return fmt.Sprintf("%.3f", float64(d)/float64(time.Millisecond)) }
+
+	fmt.Printf("parsed %d files in %s\n\n", len(files), p.Time)
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "rule\tinvocations\ttime (ms)\t")
+	for _, r := range p.Rules[:top(len(p.Rules))] {
+		fmt.Fprintf(w, "%s\t%d\t%s\t\n", r.Rule, r.Invocations, ms(r.Time))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "decision\trule\tinvocations\ttime (ms)\tSLL\tLL\tcontext sensitive\tambiguous\tavg lookahead\tmax lookahead\t")
+	for _, d := range p.Decisions[:top(len(p.Decisions))] {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%d\t%d\t%d\t%d\t%.1f\t%d\t\n",
+			d.Decision, d.Rule, d.Invocations, ms(d.Time),
+			d.Invocations-d.LLFallbacks, d.LLFallbacks, d.ContextSensitivities, d.Ambiguities,
+			float64(d.TotalLookahead)/float64(d.Invocations), d.MaxLookahead)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(p.Ambiguities) != 0 {
+		fmt.Printf("\n%d ambiguities:\n", len(p.Ambiguities))
+		for _, a := range p.Ambiguities {
+			fmt.Println(a)
+		}
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func renameMain(args []string) error {
+	fs := flag.NewFlagSet("rename", flag.ContinueOnError)
+	flagWrite := fs.Bool("w", false, "write the changes back to the files")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 3 {
+		return fmt.Errorf("usage: rename [-w] SYMBOL NEW_NAME FILE...")
+	}
+	symbol, newName := fs.Arg(0), fs.Arg(1)
+	files := make([]plsqlparser.File, 0, fs.NArg()-2)
+	for _, fn := range fs.Args()[2:] {
+		b, err := os.ReadFile(fn)
+		if err != nil {
+			return err
+		}
+		files = append(files, plsqlparser.File{Name: fn, Text: string(b)})
+	}
+	edits, err := plsqlparser.Rename(files, symbol, newName, parseOpts)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		var fileEdits []plsqlparser.Edit
+		for _, e := range edits {
+			if e.File == f.Name {
+				fileEdits = append(fileEdits, e)
+			}
+		}
+		if len(fileEdits) == 0 {
+			continue
+		}
+		if !*flagWrite {
+			rs := []rune(f.Text)
+			for _, e := range fileEdits {
+				before := string(rs[:e.Start])
+				line := 1 + strings.Count(before, "\n")
+				col := len([]rune(before[strings.LastIndexByte(before, '\n')+1:])) + 1
+				fmt.Printf("%s:%d:%d: %s -> %s\n", f.Name, line, col, e.Text, e.NewText)
+			}
+			continue
+		}
+		fi, err := os.Stat(f.Name)
+		if err != nil {
+			return err
+		}
+		text, err := plsqlparser.ApplyEdits(f.Text, fileEdits)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(f.Name, []byte(text), fi.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+)
+
+func schemadiffMain(args []string) error {
+	fs := flag.NewFlagSet("schemadiff", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: schemadiff OLD_DIR|OLD_FILE NEW_DIR|NEW_FILE")
+	}
+	var cats [2]*catalog.Catalog
+	for i, p := range fs.Args() {
+		files, err := readSources([]string{p})
+		if err != nil {
+			return err
+		}
+		if cats[i], err = plsqlparser.ParseDDL(files, parseOpts); err != nil {
+			return err
+		}
+	}
+	d := catalog.Compare(cats[0], cats[1])
+	if s := d.String(); s != "" {
+		fmt.Printf("-- %s\n\n", strings.ReplaceAll(s, "\n", "\n-- "))
+	}
+	fmt.Print(d.Script())
+	return nil
+}
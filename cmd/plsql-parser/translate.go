@@ -0,0 +1,49 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func pgMain(args []string) error {
+	fs := flag.NewFlagSet("pg", flag.ContinueOnError)
+	flagOut := fs.String("o", "", "write the translations into this directory (as FILE.sql), instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: pg [-o DIR] DIR|FILE...")
+	}
+	files, err := readSources(fs.Args())
+	if err != nil {
+		return err
+	}
+	out, ds, err := plsqlparser.TranslateToPostgres(files, parseOpts)
+	if err != nil {
+		return err
+	}
+	for _, d := range ds {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	if *flagOut != "" {
+		if err := os.MkdirAll(*flagOut, 0755); err != nil {
+			return err
+		}
+	}
+	for _, f := range out {
+		if *flagOut == "" {
+			fmt.Printf("-- %s\n%s\n", f.Name, f.Text)
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(*flagOut, filepath.Base(f.Name)+".sql"), []byte(f.Text), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
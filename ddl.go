@@ -0,0 +1,296 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// ParseDDL reads the tables, constraints, indexes, sequences and views created by the DDL scripts into a catalog,
+// to be compared with catalog.Compare.
+//
+// CREATE TABLE, CREATE INDEX, CREATE SEQUENCE and CREATE VIEW are read, and the columns and constraints
+// added by ALTER TABLE ADD. The objects without owner get an empty Owner.
+//
+// The columns are nullable unless declared NOT NULL: a primary key does not change that here.
+// The defaults, check conditions, index expressions and view queries are kept as written.
+// The sequences get the Oracle defaults of the options not given.
+func ParseDDL(files []File, opts ...Options) (*catalog.Catalog, error) {
+	trees, err := parseFiles(files, options(opts))
+	if err != nil {
+		return nil, err
+	}
+	dl := &ddlListener{BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}, cat: &catalog.Catalog{}}
+	for i, tree := range trees {
+		dl.src = []rune(files[i].Text)
+		antlr.ParseTreeWalkerDefault.Walk(dl, tree)
+	}
+	return dl.cat, nil
+}
+
+// ddlListener collects the objects created by DDL statements.
+type ddlListener struct {
+	BaseWalkListener
+	cat *catalog.Catalog
+	src []rune
+}
+
+// original returns the text of the node as written.
+func (dl *ddlListener) original(node antlr.ParserRuleContext) string {
+	return string(dl.src[node.GetStart().GetStart() : node.GetStop().GetStop()+1])
+}
+
+// ownerName returns the normalized owner (or "") and name of the table or view.
+func ownerName(tvn plsql.ITableview_nameContext) (string, string) {
+	tv := tvn.(*plsql.Tableview_nameContext)
+	if id := tv.Id_expression(); id != nil {
+		return catalog.Normalize(tv.Identifier().GetText()), catalog.Normalize(id.GetText())
+	}
+	return "", catalog.Normalize(tv.Identifier().GetText())
+}
+
+// table returns the table, adding it to the catalog if it is not there yet.
+func (dl *ddlListener) table(owner, name string) *catalog.Table {
+	for i, t := range dl.cat.Tables {
+		if t.Owner == owner && t.Name == name {
+			return &dl.cat.Tables[i]
+		}
+	}
+	dl.cat.Tables = append(dl.cat.Tables, catalog.Table{Owner: owner, Name: name})
+	return &dl.cat.Tables[len(dl.cat.Tables)-1]
+}
+
+// tableOf returns the owner and name of the table the node is in: created or altered.
+func tableOf(node antlr.Tree) (string, string, bool) {
+	for ; node != nil; node = node.GetParent() {
+		switch x := node.(type) {
+		case *plsql.Create_tableContext:
+			owner, name := ownerName(x.Tableview_name())
+			return owner, name, true
+		case *plsql.Alter_tableContext:
+			owner, name := ownerName(x.Tableview_name())
+			return owner, name, true
+		case *plsql.Create_viewContext:
+			return "", "", false
+		}
+	}
+	return "", "", false
+}
+
+func columnNames(pcl plsql.IParen_column_listContext) []string {
+	var names []string
+	for _, cn := range pcl.(*plsql.Paren_column_listContext).Column_list().(*plsql.Column_listContext).AllColumn_name() {
+		names = append(names, catalog.Normalize(cn.GetText()))
+	}
+	return names
+}
+
+func (dl *ddlListener) EnterCreate_table(ctx *plsql.Create_tableContext) {
+	dl.table(ownerName(ctx.Tableview_name()))
+}
+
+func (dl *ddlListener) ExitColumn_definition(ctx *plsql.Column_definitionContext) {
+	owner, name, ok := tableOf(ctx)
+	if !ok {
+		return
+	}
+	col := catalog.Column{Name: catalog.Normalize(ctx.Column_name().GetText()), Nullable: true}
+	if dt := ctx.Datatype(); dt != nil {
+		t := typeOfDatatype(dt.(*plsql.DatatypeContext))
+		col.DataType, col.Length, col.Precision, col.Scale = t.Name, t.Length, t.Precision, t.Scale
+		if col.String() == col.DataType && (col.Length != 0 || col.Precision != 0) {
+			// TIMESTAMP(6), FLOAT(126): the precision is part of the type name, as in ALL_TAB_COLUMNS
+			size := col.Length + col.Precision
+			if col.Scale != 0 {
+				col.DataType += fmt.Sprintf("(%d,%d)", size, col.Scale)
+			} else {
+				col.DataType += fmt.Sprintf("(%d)", size)
+			}
+			col.Length, col.Precision, col.Scale = 0, 0, 0
+		}
+	} else if tn := ctx.Type_name(); tn != nil {
+		col.DataType = catalog.Normalize(tn.GetText())
+	}
+	if e := ctx.Expression(); e != nil {
+		col.Default = dl.original(e)
+	}
+	for _, ic := range ctx.AllInline_constraint() {
+		ic := ic.(*plsql.Inline_constraintContext)
+		c := catalog.Constraint{Owner: owner, Table: name, Columns: []string{col.Name}}
+		if cn := ic.Constraint_name(); cn != nil {
+			c.Name = catalog.Normalize(cn.GetText())
+		}
+		switch {
+		case ic.NULL_() != nil:
+			col.Nullable = ic.NOT() == nil
+			continue
+		case ic.PRIMARY() != nil:
+			c.Type = "P"
+		case ic.UNIQUE() != nil:
+			c.Type = "U"
+		case ic.References_clause() != nil:
+			c.Type = "R"
+			dl.references(&c, ic.References_clause())
+		case ic.Check_constraint() != nil:
+			c.Type = "C"
+			c.Columns = nil
+			c.Condition = dl.original(ic.Check_constraint().(*plsql.Check_constraintContext).Condition())
+		default:
+			continue
+		}
+		dl.cat.Constraints = append(dl.cat.Constraints, c)
+	}
+
+	t := dl.table(owner, name)
+	for i, c := range t.Columns {
+		if c.Name == col.Name {
+			t.Columns[i] = col
+			return
+		}
+	}
+	t.Columns = append(t.Columns, col)
+}
+
+// references sets the referenced key of the foreign key.
+func (dl *ddlListener) references(c *catalog.Constraint, rc plsql.IReferences_clauseContext) {
+	r := rc.(*plsql.References_clauseContext)
+	c.RefOwner, c.RefTable = ownerName(r.Tableview_name())
+	if c.RefOwner == "" {
+		c.RefOwner = c.Owner
+	}
+	if pcl := r.Paren_column_list(); pcl != nil {
+		c.RefColumns = columnNames(pcl)
+	}
+}
+
+func (dl *ddlListener) ExitOut_of_line_constraint(ctx *plsql.Out_of_line_constraintContext) {
+	owner, name, ok := tableOf(ctx)
+	if !ok {
+		return
+	}
+	c := catalog.Constraint{Owner: owner, Table: name}
+	if cn := ctx.Constraint_name(); cn != nil {
+		c.Name = catalog.Normalize(cn.GetText())
+	}
+	switch {
+	case ctx.PRIMARY() != nil, ctx.UNIQUE() != nil:
+		c.Type = "U"
+		if ctx.PRIMARY() != nil {
+			c.Type = "P"
+		}
+		for _, cn := range ctx.AllColumn_name() {
+			c.Columns = append(c.Columns, catalog.Normalize(cn.GetText()))
+		}
+	case ctx.Foreign_key_clause() != nil:
+		fk := ctx.Foreign_key_clause().(*plsql.Foreign_key_clauseContext)
+		c.Type = "R"
+		c.Columns = columnNames(fk.Paren_column_list())
+		dl.references(&c, fk.References_clause())
+		if od, ok := fk.On_delete_clause().(*plsql.On_delete_clauseContext); ok {
+			c.DeleteRule = "SET NULL"
+			if od.CASCADE() != nil {
+				c.DeleteRule = "CASCADE"
+			}
+		}
+	case ctx.CHECK() != nil:
+		c.Type = "C"
+		c.Condition = dl.original(ctx.Expression())
+	default:
+		return
+	}
+	dl.cat.Constraints = append(dl.cat.Constraints, c)
+}
+
+func (dl *ddlListener) EnterCreate_index(ctx *plsql.Create_indexContext) {
+	tic, ok := ctx.Table_index_clause().(*plsql.Table_index_clauseContext)
+	if !ok {
+		return
+	}
+	in := ctx.Index_name().(*plsql.Index_nameContext)
+	x := catalog.Index{Name: catalog.Normalize(in.Identifier().GetText()), Unique: ctx.UNIQUE() != nil}
+	if id := in.Id_expression(); id != nil {
+		x.Owner, x.Name = x.Name, catalog.Normalize(id.GetText())
+	}
+	x.TableOwner, x.Table = ownerName(tic.Tableview_name())
+	for _, ch := range tic.GetChildren() {
+		switch ch := ch.(type) {
+		case *plsql.Index_exprContext:
+			if cn := ch.Column_name(); cn != nil {
+				x.Columns = append(x.Columns, catalog.Normalize(cn.GetText()))
+			} else {
+				x.Columns = append(x.Columns, dl.original(ch))
+			}
+		case antlr.TerminalNode:
+			if ch.GetSymbol().GetTokenType() == plsql.PlSqlParserDESC && len(x.Columns) != 0 {
+				x.Columns[len(x.Columns)-1] += " DESC"
+			}
+		}
+	}
+	dl.cat.Indexes = append(dl.cat.Indexes, x)
+}
+
+func (dl *ddlListener) EnterCreate_sequence(ctx *plsql.Create_sequenceContext) {
+	ids := ctx.Sequence_name().(*plsql.Sequence_nameContext).AllId_expression()
+	s := catalog.Sequence{Name: catalog.Normalize(ids[len(ids)-1].GetText()),
+		MinValue: "1", MaxValue: "9999999999999999999999999999", IncrementBy: "1", CacheSize: 20}
+	if len(ids) > 1 {
+		s.Owner = catalog.Normalize(ids[len(ids)-2].GetText())
+	}
+	for _, sc := range ctx.AllSequence_start_clause() {
+		s.StartWith = sc.(*plsql.Sequence_start_clauseContext).UNSIGNED_INTEGER().GetText()
+	}
+	for _, ss := range ctx.AllSequence_spec() {
+		ss := ss.(*plsql.Sequence_specContext)
+		var n string
+		if u := ss.UNSIGNED_INTEGER(); u != nil {
+			n = u.GetText()
+		}
+		switch {
+		case ss.INCREMENT() != nil:
+			s.IncrementBy = n
+		case ss.MINVALUE() != nil:
+			s.MinValue = n
+		case ss.NOMINVALUE() != nil:
+			s.MinValue = "1"
+		case ss.MAXVALUE() != nil:
+			s.MaxValue = n
+		case ss.NOMAXVALUE() != nil:
+			s.MaxValue = "9999999999999999999999999999"
+		case ss.CACHE() != nil:
+			s.CacheSize, _ = strconv.Atoi(n)
+		case ss.NOCACHE() != nil:
+			s.CacheSize = 0
+		case ss.CYCLE() != nil, ss.NOCYCLE() != nil:
+			s.Cycle = ss.CYCLE() != nil
+		case ss.ORDER() != nil, ss.NOORDER() != nil:
+			s.Order = ss.ORDER() != nil
+		}
+	}
+	if s.StartWith == "" {
+		s.StartWith = s.MinValue
+	}
+	dl.cat.Sequences = append(dl.cat.Sequences, s)
+}
+
+func (dl *ddlListener) EnterCreate_view(ctx *plsql.Create_viewContext) {
+	v := catalog.View{Text: dl.original(ctx.Select_only_statement())}
+	v.Owner, v.Name = ownerName(ctx.Tableview_name())
+	if src := ctx.Subquery_restriction_clause(); src != nil {
+		v.Text += " " + dl.original(src)
+	}
+	if vo, ok := ctx.View_options().(*plsql.View_optionsContext); ok {
+		if vac, ok := vo.View_alias_constraint().(*plsql.View_alias_constraintContext); ok {
+			for _, ta := range vac.AllTable_alias() {
+				v.Columns = append(v.Columns, catalog.Normalize(ta.GetText()))
+			}
+		}
+	}
+	dl.cat.Views = append(dl.cat.Views, v)
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"reflect"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+)
+
+func TestParseDDL(t *testing.T) {
+	cat, err := plsqlparser.ParseDDL([]plsqlparser.File{{Name: "hr.sql", Text: `CREATE TABLE hr.dept (
+  id NUMBER(10) CONSTRAINT dept_pk PRIMARY KEY,
+  name VARCHAR2(50) NOT NULL
+);
+CREATE TABLE hr.emp (
+  id NUMBER(10) NOT NULL,
+  name VARCHAR2(100) DEFAULT 'n/a',
+  hired TIMESTAMP(6),
+  dept_id NUMBER(10) REFERENCES hr.dept,
+  salary NUMBER(12,2) CHECK (salary > 0),
+  CONSTRAINT emp_pk PRIMARY KEY (id),
+  CONSTRAINT emp_dept_fk FOREIGN KEY (dept_id) REFERENCES hr.dept (id) ON DELETE CASCADE
+);
+ALTER TABLE hr.emp ADD (email VARCHAR2(200));
+ALTER TABLE hr.emp ADD CONSTRAINT emp_email_uk UNIQUE (email);
+CREATE UNIQUE INDEX hr.emp_name_i ON hr.emp (UPPER(name), hired DESC);
+CREATE SEQUENCE hr.emp_seq START WITH 1000 INCREMENT BY 10 NOCACHE;
+CREATE OR REPLACE VIEW hr.emp_v (id, name) AS SELECT id, name FROM hr.emp WITH READ ONLY;
+`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &catalog.Catalog{
+		Tables: []catalog.Table{
+			{Owner: "HR", Name: "DEPT", Columns: []catalog.Column{
+				{Name: "ID", DataType: "NUMBER", Precision: 10, Nullable: true},
+				{Name: "NAME", DataType: "VARCHAR2", Length: 50},
+			}},
+			{Owner: "HR", Name: "EMP", Columns: []catalog.Column{
+				{Name: "ID", DataType: "NUMBER", Precision: 10},
+				{Name: "NAME", DataType: "VARCHAR2", Length: 100, Nullable: true, Default: "'n/a'"},
+				{Name: "HIRED", DataType: "TIMESTAMP(6)", Nullable: true},
+				{Name: "DEPT_ID", DataType: "NUMBER", Precision: 10, Nullable: true},
+				{Name: "SALARY", DataType: "NUMBER", Precision: 12, Scale: 2, Nullable: true},
+				{Name: "EMAIL", DataType: "VARCHAR2", Length: 200, Nullable: true},
+			}},
+		},
+		Constraints: []catalog.Constraint{
+			{Owner: "HR", Table: "DEPT", Name: "DEPT_PK", Type: "P", Columns: []string{"ID"}},
+			{Owner: "HR", Table: "EMP", Type: "R", Columns: []string{"DEPT_ID"}, RefOwner: "HR", RefTable: "DEPT"},
+			{Owner: "HR", Table: "EMP", Type: "C", Condition: "salary > 0"},
+			{Owner: "HR", Table: "EMP", Name: "EMP_PK", Type: "P", Columns: []string{"ID"}},
+			{Owner: "HR", Table: "EMP", Name: "EMP_DEPT_FK", Type: "R", Columns: []string{"DEPT_ID"},
+				RefOwner: "HR", RefTable: "DEPT", RefColumns: []string{"ID"}, DeleteRule: "CASCADE"},
+			{Owner: "HR", Table: "EMP", Name: "EMP_EMAIL_UK", Type: "U", Columns: []string{"EMAIL"}},
+		},
+		Indexes: []catalog.Index{
+			{Owner: "HR", Name: "EMP_NAME_I", TableOwner: "HR", Table: "EMP", Unique: true, Columns: []string{"UPPER(name)", "HIRED DESC"}},
+		},
+		Sequences: []catalog.Sequence{
+			{Owner: "HR", Name: "EMP_SEQ", MinValue: "1", MaxValue: "9999999999999999999999999999", IncrementBy: "10", StartWith: "1000"},
+		},
+		Views: []catalog.View{
+			{Owner: "HR", Name: "EMP_V", Columns: []string{"ID", "NAME"}, Text: "SELECT id, name FROM hr.emp WITH READ ONLY"},
+		},
+	}
+	if !reflect.DeepEqual(cat, want) {
+		t.Errorf("got\n%+v\nwanted\n%+v", cat, want)
+	}
+
+	if d := catalog.Compare(cat, cat); d.Script() != "" {
+		t.Errorf("no changes wanted, got\n%s", d.Script())
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// DeadCode reports the dead code of the files, analysed together:
+//
+//   - subprograms never called: the private ones, and the public ones (declared in
+//     a package specification, or standalone) not listed in allow,
+//   - local and private variables, constants and cursors never used,
+//   - statements unreachable after RETURN, RAISE, RAISE_APPLICATION_ERROR or GOTO,
+//   - exception handlers for declared exceptions never raised (nor bound with EXCEPTION_INIT).
+//
+// The allow list holds the qualified names of the entry points (PKG.MAIN),
+// possibly with path.Match patterns (PKG.*).
+// Recursive calls do not count as calls.
+func DeadCode(files []File, allow ...string) ([]Diagnostic, error) {
+	return DeadCodeOptions(files, allow)
+}
+
+// DeadCodeOptions is DeadCode, parsing the files with the Options.
+func DeadCodeOptions(files []File, allow []string, opts ...Options) ([]Diagnostic, error) {
+	trees, err := parseFiles(files, options(opts))
+	if err != nil {
+		return nil, err
+	}
+	st := NewSymbolTable(trees...)
+
+	type usage struct {
+		decls, uses, handlers []Reference
+		raised                bool
+	}
+	usages := make(map[*Symbol]*usage)
+	var symbols []*Symbol
+	for _, ref := range st.References {
+		u := usages[ref.Symbol]
+		if u == nil {
+			u = &usage{}
+			usages[ref.Symbol] = u
+			symbols = append(symbols, ref.Symbol)
+		}
+		if ref.Decl {
+			u.decls = append(u.decls, ref)
+			continue
+		}
+		if _, ok := ref.Node.(*plsql.Label_nameContext); ok || st.within(ref.Scope, ref.Symbol) {
+			// END name, or recursion
+			continue
+		}
+		u.uses = append(u.uses, ref)
+		if en, ok := ref.Node.GetParent().(*plsql.Exception_nameContext); ok {
+			switch en.GetParent().(type) {
+			case *plsql.Raise_statementContext, *plsql.Pragma_declarationContext:
+				u.raised = true
+			case *plsql.Exception_handlerContext:
+				u.handlers = append(u.handlers, ref)
+			}
+		}
+	}
+
+	var ds []Diagnostic
+	add := func(ref Reference, format string, args ...interface{}) {
+		d := newDiagnostic(ref.Node, format, args...)
+		d.File = files[ref.Tree].Name
+		ds = append(ds, d)
+	}
+	for _, sym := range symbols {
+		u := usages[sym]
+		if len(u.decls) == 0 {
+			continue
+		}
+		public := sym.Scope == st.Root || isSpec(sym.Scope)
+		switch sym.Kind {
+		case ProcedureSym, FunctionSym:
+			if len(u.uses) != 0 {
+				continue
+			}
+			if !public {
+				add(u.decls[0], "%s %s is never called", sym.Kind, sym.Name)
+			} else if name := st.qualifiedName(sym); !allowed(name, allow) {
+				add(u.decls[0], "%s %s has no callers", sym.Kind, name)
+			}
+		case VariableSym, ConstantSym, CursorSym:
+			if public || len(u.uses) != 0 {
+				continue
+			}
+			if _, ok := u.decls[0].Node.GetParent().(*plsql.Cursor_loop_paramContext); !ok {
+				add(u.decls[0], "%s %s is never used", sym.Kind, sym.Name)
+			}
+		case ExceptionSym:
+			if !u.raised {
+				for _, ref := range u.handlers {
+					add(ref, "exception %s is never raised", sym.Name)
+				}
+			}
+		}
+	}
+
+	for i, tree := range trees {
+		ul := &unreachableListener{BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}}
+		antlr.ParseTreeWalkerDefault.Walk(ul, tree)
+		for _, d := range ul.Diagnostics {
+			d.File = files[i].Name
+			ds = append(ds, d)
+		}
+	}
+
+	order := make(map[string]int, len(files))
+	for i, f := range files {
+		order[f.Name] = i
+	}
+	sort.SliceStable(ds, func(i, j int) bool {
+		if ds[i].File != ds[j].File {
+			return order[ds[i].File] < order[ds[j].File]
+		}
+		return ds[i].Start < ds[j].Start
+	})
+	return ds, nil
+}
+
+// isSpec reports whether the scope is a package specification.
+func isSpec(s *Scope) bool {
+	_, ok := s.node.(*plsql.Create_packageContext)
+	return ok
+}
+
+// within reports whether the scope is inside one of the declarations of the subprogram.
+func (st *SymbolTable) within(s *Scope, sym *Symbol) bool {
+	for ; s != nil; s = s.Parent {
+		if s.owner != nil && st.Canonical(s.owner) == sym {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedName returns the name of the symbol prefixed with the names of the enclosing packages and subprograms.
+func (st *SymbolTable) qualifiedName(sym *Symbol) string {
+	names := []string{sym.Name}
+	for s := sym.Scope; s != nil && s != st.Root; s = s.Parent {
+		if spec := st.specs[s]; spec != nil {
+			s = spec
+		}
+		if s.owner != nil {
+			names = append([]string{s.owner.Name}, names...)
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+func allowed(name string, allow []string) bool {
+	for _, pattern := range allow {
+		if ok, _ := path.Match(strings.ToUpper(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unreachableListener finds the statements following a RETURN, RAISE or GOTO
+// in the same sequence, without a label between them.
+type unreachableListener struct {
+	BaseWalkListener
+	Diagnostics []Diagnostic
+}
+
+func (ul *unreachableListener) ExitSeq_of_statements(ctx *plsql.Seq_of_statementsContext) {
+	var after string
+	var reported bool
+	for _, ch := range ctx.GetChildren() {
+		switch x := ch.(type) {
+		case *plsql.Label_declarationContext:
+			after, reported = "", false
+		case *plsql.StatementContext:
+			if after == "" {
+				after = jump(x)
+			} else if !reported {
+				// report only the first one
+				ul.Diagnostics = append(ul.Diagnostics, newDiagnostic(x, "unreachable statement after %s", after))
+				reported = true
+			}
+		}
+	}
+}
+
+// jump returns the name of the statement that never continues with the next one, or "".
+func jump(stmt *plsql.StatementContext) string {
+	var rn plsql.IRoutine_nameContext
+	switch {
+	case stmt.Return_statement() != nil:
+		return "RETURN"
+	case stmt.Raise_statement() != nil:
+		return "RAISE"
+	case stmt.Goto_statement() != nil:
+		return "GOTO"
+	case stmt.Procedure_call() != nil:
+		rn = stmt.Procedure_call().(*plsql.Procedure_callContext).Routine_name()
+	case stmt.Function_call() != nil:
+		rn = stmt.Function_call().(*plsql.Function_callContext).Routine_name()
+	}
+	if rn != nil && catalog.Normalize(rn.GetText()) == "RAISE_APPLICATION_ERROR" {
+		return "RAISE_APPLICATION_ERROR"
+	}
+	return ""
+}
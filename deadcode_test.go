@@ -0,0 +1,80 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestDeadCode(t *testing.T) {
+	files := []plsqlparser.File{
+		{Name: "pkg.pks", Text: `CREATE OR REPLACE PACKAGE pkg IS
+  PROCEDURE main;
+  PROCEDURE unused_public;
+END pkg;
+`},
+		{Name: "pkg.pkb", Text: `CREATE OR REPLACE PACKAGE BODY pkg IS
+  e_never EXCEPTION;
+  e_raised EXCEPTION;
+  g_unused NUMBER;
+
+  PROCEDURE helper IS
+  BEGIN
+    RAISE e_raised;
+  END helper;
+
+  PROCEDURE unused_private(p_n IN NUMBER) IS
+  BEGIN
+    IF p_n > 0 THEN
+      unused_private(p_n - 1);
+    END IF;
+  END unused_private;
+
+  PROCEDURE main IS
+    v_unused NUMBER;
+    CURSOR c_unused IS SELECT 1 FROM DUAL;
+    v_n NUMBER := 1;
+  BEGIN
+    FOR i IN 1..v_n LOOP
+      helper;
+    END LOOP;
+    RETURN;
+    v_n := 2;
+  EXCEPTION
+    WHEN e_never THEN NULL;
+    WHEN e_raised THEN
+      RAISE_APPLICATION_ERROR(-20000, 'raised');
+      NULL;
+  END main;
+
+  PROCEDURE unused_public IS BEGIN NULL; END unused_public;
+END pkg;
+`},
+	}
+	ds, err := plsqlparser.DeadCode(files, "pkg.main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range ds {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"pkg.pks:3:13: procedure PKG.UNUSED_PUBLIC has no callers",
+		"pkg.pkb:4:3: variable G_UNUSED is never used",
+		"pkg.pkb:11:13: procedure UNUSED_PRIVATE is never called",
+		"pkg.pkb:19:5: variable V_UNUSED is never used",
+		"pkg.pkb:20:12: cursor C_UNUSED is never used",
+		"pkg.pkb:27:5: unreachable statement after RETURN",
+		"pkg.pkb:29:10: exception E_NEVER is never raised",
+		"pkg.pkb:32:7: unreachable statement after RAISE_APPLICATION_ERROR",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwanted\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
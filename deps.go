@@ -0,0 +1,527 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// SchemaObject is a table, view, package, type... created by a statement of the sources.
+type SchemaObject struct {
+	// Owner is empty for the objects created without owner, PUBLIC for the public synonyms.
+	Owner, Name string
+	// Type is as in ALL_OBJECTS: TABLE, VIEW, INDEX, SEQUENCE, SYNONYM, PACKAGE, PACKAGE BODY,
+	// PROCEDURE, FUNCTION, TRIGGER, TYPE or TYPE BODY.
+	Type string
+	// File and Line of the creating statement, which is the Chunk.
+	File string
+	Line int
+	Chunk
+}
+
+func (o SchemaObject) String() string {
+	if o.Owner == "" {
+		return o.Type + " " + o.Name
+	}
+	return o.Type + " " + o.Owner + "." + o.Name
+}
+
+// Dependency of the From object on the To one (their indexes in DependencyGraph.Objects),
+// at the first reference in the From object: the Chunk at Line and Column (1-based) of File.
+type Dependency struct {
+	From, To int
+	File     string
+	Chunk
+	Line, Column int
+}
+
+// DependencyGraph of the schema objects.
+type DependencyGraph struct {
+	Objects      []SchemaObject
+	Dependencies []Dependency
+}
+
+// Dependencies returns the dependencies between the objects created by the files:
+// of the views, triggers and indexes on their tables, of the tables on the referenced tables and the types of their columns,
+// of the packages, subprograms, triggers and types on the tables, views, sequences, packages, subprograms
+// and types they use, of a package (type) body on its specification, and of a synonym on its target.
+//
+// The names are resolved in the owner of the referring object, then among the objects created without owner,
+// then among all the objects. The local declarations of the PL/SQL code hide the objects.
+// A bare name without arguments may be a column, so it refers to a function only.
+// The references to objects not created by the files are ignored.
+// The tables of the dynamic SQL known at parse time (see FindDynamicSQL) are dependencies, too.
+//
+// The returned error is only for syntax errors.
+func Dependencies(files []File, opts ...Options) (*DependencyGraph, error) {
+	o := options(opts)
+	trees, err := parseFiles(files, o)
+	if err != nil {
+		return nil, err
+	}
+	g := &DependencyGraph{}
+	var units []antlr.ParserRuleContext
+	for i, tree := range trees {
+		src := []rune(files[i].Text)
+		for _, ch := range tree.GetChildren() {
+			us, ok := ch.(*plsql.Unit_statementContext)
+			if !ok || us.GetChildCount() == 0 {
+				continue
+			}
+			unit, ok := us.GetChild(0).(antlr.ParserRuleContext)
+			if !ok {
+				continue
+			}
+			if o, ok := schemaObject(unit); ok {
+				o.File, o.Line, o.Chunk = files[i].Name, unit.GetStart().GetLine(), originalChunk(src, unit)
+				g.Objects = append(g.Objects, o)
+				units = append(units, unit)
+			}
+		}
+	}
+
+	dl := depListener{BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()},
+		g: g, st: NewSymbolTable(trees...), byName: make(map[string][]int), seen: make(map[[2]int]bool)}
+	for i, o := range g.Objects {
+		switch o.Type {
+		case "PACKAGE BODY", "TYPE BODY", "TRIGGER", "INDEX":
+			// nothing refers to them
+		default:
+			dl.byName[o.Name] = append(dl.byName[o.Name], i)
+		}
+	}
+	fileIndex := make(map[string]int, len(files))
+	for i, f := range files {
+		fileIndex[f.Name] = i
+	}
+	for i, unit := range units {
+		dl.from, dl.src = i, []rune(files[fileIndex[g.Objects[i].File]].Text)
+		switch g.Objects[i].Type {
+		case "PACKAGE BODY":
+			dl.implicit(unit.(*plsql.Create_package_bodyContext).Package_name(0), "PACKAGE")
+		case "TYPE BODY":
+			dl.implicit(unit.(*plsql.Create_typeContext).Type_body().(*plsql.Type_bodyContext).Type_name(), "TYPE")
+		case "SYNONYM":
+			dl.synonym(unit.(*plsql.Create_synonymContext))
+		}
+		WalkDynamicSQL(&dl, unit, findDynamicSQL(unit, dl.src, dl.src, func(i int) int { return i }, o))
+	}
+	return g, nil
+}
+
+// originalChunk returns the chunk of the node, with its text as written.
+func originalChunk(src []rune, node antlr.ParserRuleContext) Chunk {
+	c := ctxChunk(node)
+	if c.Start >= 0 && c.Stop >= c.Start && c.Stop < len(src) {
+		c.Text = string(src[c.Start : c.Stop+1])
+	}
+	return c
+}
+
+// schemaObject returns the object created by the unit statement.
+func schemaObject(unit antlr.ParserRuleContext) (SchemaObject, bool) {
+	var o SchemaObject
+	// name sets the owner and the name from the dotted name parts
+	name := func(parts ...antlr.Tree) {
+		var ids []string
+		for _, p := range parts {
+			if p, ok := p.(antlr.ParseTree); ok && p != nil {
+				ids = append(ids, catalog.Normalize(p.GetText()))
+			}
+		}
+		if len(ids) > 1 {
+			o.Owner = ids[len(ids)-2]
+		}
+		if len(ids) != 0 {
+			o.Name = ids[len(ids)-1]
+		}
+	}
+	switch x := unit.(type) {
+	case *plsql.Create_tableContext:
+		o.Type = "TABLE"
+		name(tableviewParts(x.Tableview_name())...)
+	case *plsql.Create_viewContext:
+		o.Type = "VIEW"
+		name(tableviewParts(x.Tableview_name())...)
+	case *plsql.Create_indexContext:
+		o.Type = "INDEX"
+		in := x.Index_name().(*plsql.Index_nameContext)
+		name(in.Identifier(), in.Id_expression())
+	case *plsql.Create_sequenceContext:
+		o.Type = "SEQUENCE"
+		ids := x.Sequence_name().(*plsql.Sequence_nameContext).AllId_expression()
+		parts := make([]antlr.Tree, len(ids))
+		for i, id := range ids {
+			parts[i] = id
+		}
+		name(parts...)
+	case *plsql.Create_synonymContext:
+		o.Type = "SYNONYM"
+		if x.PUBLIC() != nil {
+			name(x.Synonym_name())
+			o.Owner = "PUBLIC"
+		} else if sn := x.Schema_name(0); sn != nil && sn.GetStop().GetStop() < x.FOR().GetSymbol().GetStart() {
+			name(sn, x.Synonym_name())
+		} else {
+			name(x.Synonym_name())
+		}
+	case *plsql.Create_packageContext:
+		o.Type = "PACKAGE"
+		name(x.Schema_object_name(), x.Package_name(0))
+	case *plsql.Create_package_bodyContext:
+		o.Type = "PACKAGE BODY"
+		name(x.Schema_object_name(), x.Package_name(0))
+	case *plsql.Create_procedure_bodyContext:
+		o.Type = "PROCEDURE"
+		pn := x.Procedure_name().(*plsql.Procedure_nameContext)
+		name(pn.Identifier(), pn.Id_expression())
+	case *plsql.Create_function_bodyContext:
+		o.Type = "FUNCTION"
+		fn := x.Function_name().(*plsql.Function_nameContext)
+		name(fn.Identifier(), fn.Id_expression())
+	case *plsql.Create_triggerContext:
+		o.Type = "TRIGGER"
+		tn := x.Trigger_name().(*plsql.Trigger_nameContext)
+		name(tn.Identifier(), tn.Id_expression())
+	case *plsql.Create_typeContext:
+		var tn plsql.IType_nameContext
+		if td, ok := x.Type_definition().(*plsql.Type_definitionContext); ok {
+			o.Type, tn = "TYPE", td.Type_name()
+		} else if tb, ok := x.Type_body().(*plsql.Type_bodyContext); ok {
+			o.Type, tn = "TYPE BODY", tb.Type_name()
+		}
+		if tn != nil {
+			var parts []antlr.Tree
+			for _, id := range tn.(*plsql.Type_nameContext).AllId_expression() {
+				parts = append(parts, id)
+			}
+			name(parts...)
+		}
+	}
+	return o, o.Type != "" && o.Name != ""
+}
+
+// tableviewParts returns the owner (if given) and the name of the table or view.
+func tableviewParts(tvn plsql.ITableview_nameContext) []antlr.Tree {
+	tv, ok := tvn.(*plsql.Tableview_nameContext)
+	if !ok || tv.Identifier() == nil {
+		return nil
+	}
+	if id := tv.Id_expression(); id != nil {
+		return []antlr.Tree{tv.Identifier(), id}
+	}
+	return []antlr.Tree{tv.Identifier()}
+}
+
+// depListener collects the dependencies of a unit statement.
+type depListener struct {
+	BaseWalkListener
+	g      *DependencyGraph
+	st     *SymbolTable
+	byName map[string][]int
+	seen   map[[2]int]bool
+	from   int
+	src    []rune
+	// dynamic is the stack of the dynamic SQL walked into.
+	dynamic []DynamicSQL
+}
+
+func (dl *depListener) EnterDynamicSQL(ds DynamicSQL) { dl.dynamic = append(dl.dynamic, ds) }
+func (dl *depListener) ExitDynamicSQL(ds DynamicSQL)  { dl.dynamic = dl.dynamic[:len(dl.dynamic)-1] }
+
+// lookup the object (of the types, if given) referred to by the owner (may be empty) and name, from the current object.
+func (dl *depListener) lookup(owner, name string, types ...string) int {
+	cands := dl.byName[name]
+	if len(types) != 0 {
+		var typed []int
+		for _, i := range cands {
+			for _, typ := range types {
+				if dl.g.Objects[i].Type == typ {
+					typed = append(typed, i)
+				}
+			}
+		}
+		cands = typed
+	}
+	if owner != "" {
+		for _, i := range cands {
+			if dl.g.Objects[i].Owner == owner {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, own := range []string{dl.g.Objects[dl.from].Owner, ""} {
+		for _, i := range cands {
+			if dl.g.Objects[i].Owner == own {
+				return i
+			}
+		}
+	}
+	if len(cands) != 0 {
+		return cands[0]
+	}
+	return -1
+}
+
+// add the dependency on the object to, referred to by the nodes.
+func (dl *depListener) add(to int, first, last antlr.ParserRuleContext) {
+	if to < 0 || to == dl.from || dl.seen[[2]int{dl.from, to}] {
+		return
+	}
+	dl.seen[[2]int{dl.from, to}] = true
+	d := Dependency{From: dl.from, To: to, File: dl.g.Objects[dl.from].File}
+	start, stop := first.GetStart().GetStart(), last.GetStop().GetStop()
+	if n := len(dl.dynamic); n != 0 {
+		// the text is of the dynamic SQL, the positions are mapped into the unit
+		ds := dl.dynamic[n-1]
+		d.Text = string([]rune(ds.Text)[start : stop+1])
+		d.Start, d.Stop = ds.Position(start), ds.Position(stop)
+	} else {
+		d.Text = string(dl.src[start : stop+1])
+		d.Start, d.Stop = start, stop
+	}
+	d.Line, d.Column = lineColumn(dl.src, d.Start)
+	dl.g.Dependencies = append(dl.g.Dependencies, d)
+}
+
+// resolve the dotted name: OWNER.OBJECT or OBJECT (of the types, if given), followed by anything.
+func (dl *depListener) resolve(ids []antlr.ParserRuleContext, types ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	first := catalog.Normalize(ids[0].GetText())
+	if len(ids) > 1 {
+		if to := dl.lookup(first, catalog.Normalize(ids[1].GetText()), types...); to >= 0 {
+			dl.add(to, ids[0], ids[1])
+			return
+		}
+	}
+	if sym := dl.st.ScopeOf(ids[0]).Lookup(first); sym != nil && sym.Scope != dl.st.Root {
+		// a local declaration
+		return
+	}
+	dl.add(dl.lookup("", first, types...), ids[0], ids[0])
+}
+
+// implicit adds the dependency of a body on its specification, referred to by the name.
+func (dl *depListener) implicit(name antlr.ParserRuleContext, typ string) {
+	o := dl.g.Objects[dl.from]
+	for _, i := range dl.byName[o.Name] {
+		if s := dl.g.Objects[i]; s.Owner == o.Owner && s.Type == typ {
+			dl.add(i, name, name)
+			return
+		}
+	}
+}
+
+func (dl *depListener) synonym(ctx *plsql.Create_synonymContext) {
+	if ctx.Link_name() != nil {
+		return
+	}
+	son := ctx.Schema_object_name()
+	owner, first := "", antlr.ParserRuleContext(son)
+	for _, sn := range ctx.AllSchema_name() {
+		if sn.GetStart().GetStart() > ctx.FOR().GetSymbol().GetStart() {
+			owner, first = catalog.Normalize(sn.GetText()), sn
+		}
+	}
+	if to := dl.lookup(owner, catalog.Normalize(son.GetText())); to >= 0 {
+		dl.add(to, first, son)
+	}
+}
+
+func (dl *depListener) EnterTableview_name(ctx *plsql.Tableview_nameContext) {
+	var ids []antlr.ParserRuleContext
+	for _, p := range tableviewParts(ctx) {
+		ids = append(ids, p.(antlr.ParserRuleContext))
+	}
+	dl.resolve(ids)
+}
+
+func (dl *depListener) EnterGeneral_element(ctx *plsql.General_elementContext) {
+	var ids []antlr.ParserRuleContext
+	var call bool
+	for _, p := range ctx.AllGeneral_element_part() {
+		gep := p.(*plsql.General_element_partContext)
+		for _, id := range gep.AllId_expression() {
+			ids = append(ids, id)
+		}
+		call = call || gep.Function_argument() != nil
+	}
+	if len(ids) == 1 && !call {
+		// a column (such as CUSTOMER of a table beside the CUSTOMER table), a variable or a function
+		dl.resolve(ids, "FUNCTION")
+		return
+	}
+	dl.resolve(ids)
+}
+
+func (dl *depListener) EnterType_name(ctx *plsql.Type_nameContext) {
+	if _, ok := ctx.GetParent().(*plsql.Type_definitionContext); ok {
+		return
+	}
+	if _, ok := ctx.GetParent().(*plsql.Type_bodyContext); ok {
+		return
+	}
+	var ids []antlr.ParserRuleContext
+	for _, id := range ctx.AllId_expression() {
+		ids = append(ids, id)
+	}
+	dl.resolve(ids)
+}
+
+func (dl *depListener) EnterRoutine_name(ctx *plsql.Routine_nameContext) {
+	ids := []antlr.ParserRuleContext{ctx.Identifier()}
+	for _, id := range ctx.AllId_expression() {
+		ids = append(ids, id)
+	}
+	dl.resolve(ids)
+}
+
+// Order returns the indexes of the Objects in deployment order, each object after the ones it depends on,
+// and the dependency cycles, each as the Dependencies around it.
+//
+// The order of the independent objects is kept. A cycle is broken at its first object
+// waiting for the fewest dependencies.
+func (g *DependencyGraph) Order() ([]int, [][]Dependency) {
+	n := len(g.Objects)
+	deps := make([][]int, n) // the dependencies of each object
+	users := make([][]int, n)
+	for _, d := range g.Dependencies {
+		deps[d.From] = append(deps[d.From], d.To)
+		users[d.To] = append(users[d.To], d.From)
+	}
+	pending := make([]int, n)
+	for i := range pending {
+		pending[i] = len(deps[i])
+	}
+	done := make([]bool, n)
+	order := make([]int, 0, n)
+	for len(order) < n {
+		next := -1
+		for i, p := range pending {
+			if !done[i] && p == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// a cycle: take the first object waiting for the fewest dependencies
+			for i, p := range pending {
+				if !done[i] && (next < 0 || p < pending[next]) {
+					next = i
+				}
+			}
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, u := range users[next] {
+			pending[u]--
+		}
+	}
+	return order, g.cycles(deps)
+}
+
+// cycles returns a cycle of each strongly connected component (Tarjan) of more than one object.
+func (g *DependencyGraph) cycles(deps [][]int) [][]Dependency {
+	n := len(deps)
+	index, low := make([]int, n), make([]int, n)
+	onStack := make([]bool, n)
+	for i := range index {
+		index[i] = -1
+	}
+	var stack []int
+	var components [][]int
+	var counter int
+	var strongConnect func(v int)
+	strongConnect = func(v int) {
+		index[v], low[v] = counter, counter
+		counter++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range deps[v] {
+			if index[w] < 0 {
+				strongConnect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] != index[v] {
+			return
+		}
+		var comp []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			comp = append(comp, w)
+			if w == v {
+				break
+			}
+		}
+		if len(comp) > 1 {
+			sort.Ints(comp)
+			components = append(components, comp)
+		}
+	}
+	for v := 0; v < n; v++ {
+		if index[v] < 0 {
+			strongConnect(v)
+		}
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+
+	edge := make(map[[2]int]Dependency, len(g.Dependencies))
+	for _, d := range g.Dependencies {
+		edge[[2]int{d.From, d.To}] = d
+	}
+	var cycles [][]Dependency
+	for _, comp := range components {
+		in := make(map[int]bool, len(comp))
+		for _, v := range comp {
+			in[v] = true
+		}
+		// the shortest way back to the first object of the component
+		start := comp[0]
+		prev := map[int]int{start: -1}
+		queue := []int{start}
+	BFS:
+		for len(queue) != 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, w := range deps[v] {
+				if w == start {
+					prev[start] = v
+					break BFS
+				}
+				if _, ok := prev[w]; !ok && in[w] {
+					prev[w] = v
+					queue = append(queue, w)
+				}
+			}
+		}
+		var cycle []Dependency
+		for to, from := start, prev[start]; ; to, from = from, prev[from] {
+			cycle = append([]Dependency{edge[[2]int{from, to}]}, cycle...)
+			if from == start {
+				break
+			}
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// Describe returns the dependency as FILE:LINE:COL: FROM depends on TO (TEXT).
+func (g *DependencyGraph) Describe(d Dependency) string {
+	return fmt.Sprintf("%s:%d:%d: %s depends on %s (%s)", d.File, d.Line, d.Column, g.Objects[d.From], g.Objects[d.To], d.Text)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestDependencies(t *testing.T) {
+	g, err := plsqlparser.Dependencies([]plsqlparser.File{
+		{Name: "api.pkb", Text: `CREATE OR REPLACE PACKAGE BODY hr.api IS
+  PROCEDURE hire(p_name IN VARCHAR2) IS
+    v_id NUMBER;
+  BEGIN
+    v_id := hr.emp_seq.NEXTVAL;
+    INSERT INTO emp_v (id, name) VALUES (v_id, p_name);
+  END hire;
+END api;`},
+		{Name: "api.pks", Text: `CREATE OR REPLACE PACKAGE hr.api IS
+  PROCEDURE hire(p_name IN VARCHAR2);
+END api;`},
+		{Name: "emp.sql", Text: `CREATE OR REPLACE VIEW hr.emp_v AS SELECT id, name FROM hr.emp;
+CREATE TABLE hr.emp (id NUMBER, name VARCHAR2(100));
+CREATE SEQUENCE hr.emp_seq;
+CREATE OR REPLACE TRIGGER hr.emp_bi BEFORE INSERT ON hr.emp FOR EACH ROW
+BEGIN
+  hr.api.hire(:NEW.name);
+END;
+CREATE PUBLIC SYNONYM api FOR hr.api;`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, cycles := g.Order()
+	var got []string
+	for _, i := range order {
+		got = append(got, g.Objects[i].String())
+	}
+	want := []string{
+		"TABLE HR.EMP", "VIEW HR.EMP_V", "SEQUENCE HR.EMP_SEQ", "PACKAGE HR.API",
+		"PACKAGE BODY HR.API", "TRIGGER HR.EMP_BI", "SYNONYM PUBLIC.API",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got order\n%q\nwanted\n%q", got, want)
+	}
+	if len(cycles) != 0 {
+		t.Errorf("no cycles wanted, got %v", cycles)
+	}
+	for _, d := range g.Dependencies {
+		if g.Objects[d.From].Type == "PACKAGE BODY" && g.Objects[d.To].Type == "VIEW" {
+			if want := "api.pkb:6:17: PACKAGE BODY HR.API depends on VIEW HR.EMP_V (emp_v)"; g.Describe(d) != want {
+				t.Errorf("got %q, wanted %q", g.Describe(d), want)
+			}
+		}
+	}
+}
+
+func TestDependenciesColumnNamedAsTable(t *testing.T) {
+	g, err := plsqlparser.Dependencies([]plsqlparser.File{
+		{Name: "orders.sql", Text: `CREATE TABLE customer (id NUMBER, name VARCHAR2(100));
+CREATE OR REPLACE VIEW orders_v AS SELECT id, customer, next_id FROM orders;
+CREATE TABLE orders (id NUMBER, customer NUMBER);
+CREATE OR REPLACE FUNCTION next_id RETURN NUMBER IS BEGIN RETURN 1; END;`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range g.Dependencies {
+		got = append(got, g.Objects[d.From].String()+" -> "+g.Objects[d.To].String())
+	}
+	want := []string{"VIEW ORDERS_V -> FUNCTION NEXT_ID", "VIEW ORDERS_V -> TABLE ORDERS"}
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestDependencyOrder(t *testing.T) {
+	g := &plsqlparser.DependencyGraph{
+		Objects: []plsqlparser.SchemaObject{
+			{Type: "VIEW", Name: "A"},
+			{Type: "VIEW", Name: "B"},
+			{Type: "TABLE", Name: "T"},
+			{Type: "VIEW", Name: "C"},
+			{Type: "SEQUENCE", Name: "S"},
+		},
+		Dependencies: []plsqlparser.Dependency{
+			{From: 0, To: 2, File: "a.sql", Line: 1, Column: 30, Chunk: plsqlparser.Chunk{Text: "t"}},
+			{From: 0, To: 3, File: "a.sql", Line: 1, Column: 40, Chunk: plsqlparser.Chunk{Text: "c"}},
+			{From: 1, To: 0, File: "b.sql", Line: 2, Column: 7, Chunk: plsqlparser.Chunk{Text: "a"}},
+			{From: 3, To: 1, File: "c.sql", Line: 3, Column: 9, Chunk: plsqlparser.Chunk{Text: "b"}},
+		},
+	}
+	order, cycles := g.Order()
+	if want := []int{2, 4, 0, 1, 3}; !reflect.DeepEqual(order, want) {
+		t.Errorf("got order %v, wanted %v", order, want)
+	}
+	if len(cycles) != 1 {
+		t.Fatalf("wanted 1 cycle, got %v", cycles)
+	}
+	var lines []string
+	for _, d := range cycles[0] {
+		lines = append(lines, g.Describe(d))
+	}
+	if got, want := strings.Join(lines, "\n"), `a.sql:1:40: VIEW A depends on VIEW C (c)
+c.sql:3:9: VIEW C depends on VIEW B (b)
+b.sql:2:7: VIEW B depends on VIEW A (a)`; got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+}
+
+func TestDependenciesDynamicSQL(t *testing.T) {
+	const proc = `CREATE OR REPLACE PROCEDURE purge IS
+BEGIN
+  EXECUTE IMMEDIATE 'DELETE FROM ' || 'audit_log WHERE ts < SYSDATE - 30';
+END;
+`
+	g, err := plsqlparser.Dependencies([]plsqlparser.File{
+		{Name: "purge.prc", Text: proc},
+		{Name: "audit.sql", Text: "CREATE TABLE audit_log (ts DATE);\n"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Dependencies) != 1 {
+		t.Fatalf("got %+v", g.Dependencies)
+	}
+	d := g.Dependencies[0]
+	if got, want := g.Describe(d), "purge.prc:3:40: PROCEDURE PURGE depends on TABLE AUDIT_LOG (audit_log)"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	if d.Start != strings.Index(proc, "audit_log") {
+		t.Errorf("got start %d, wanted %d", d.Start, strings.Index(proc, "audit_log"))
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/UNO-SOFT/plsql-parser/internal/casefold"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// Document is an SQL script for editors, which is parsed incrementally:
+// after an edit, only the changed top-level unit (a statement of the script,
+// or a subprogram, type, cursor... of a package body) is lexed and parsed again,
+// and spliced into the tree.
+//
+// The tokens of the unchanged units are kept, with their positions shifted,
+// so the nodes of the tree stay valid, only the ones of the reparsed unit are replaced.
+// Edits outside of the units (e.g. between the statements), or changing the extent of the unit,
+// parse the whole text again.
+type Document struct {
+	text   []rune
+	input  *casefold.Stream
+	lines  []int
+	tree   antlr.ParserRuleContext
+	tokens []antlr.Token
+	segs   []*segment
+	errors []docError
+	opts   Options
+}
+
+// NewDocument parses the text.
+func NewDocument(text string, opts ...Options) *Document {
+	d := &Document{text: []rune(text), opts: options(opts)}
+	d.update()
+	d.parseAll()
+	return d
+}
+
+// Text returns the current text.
+func (d *Document) Text() string { return string(d.text) }
+
+// Tree returns the parse tree (an Sql_scriptContext).
+func (d *Document) Tree() antlr.ParserRuleContext { return d.tree }
+
+// Tokens returns all the tokens, including the hidden ones, indexed by GetTokenIndex.
+func (d *Document) Tokens() []antlr.Token { return d.tokens }
+
+// Errors returns the syntax errors.
+func (d *Document) Errors() []Diagnostic {
+	ds := make([]Diagnostic, 0, len(d.errors))
+	for _, e := range d.errors {
+		pos := e.position()
+		line, col := d.lineColumn(pos)
+		ds = append(ds, Diagnostic{Chunk: Chunk{Start: pos, Stop: pos}, Line: line, Column: col + 1, Message: e.msg})
+	}
+	sort.SliceStable(ds, func(i, j int) bool { return ds[i].Start < ds[j].Start })
+	return ds
+}
+
+// Apply the edit (its File and Text are not used), and returns the node parsed again:
+// a Package_obj_bodyContext, Unit_statementContext or Sql_plus_commandContext,
+// or the new tree if the whole text has been parsed.
+//
+// An edit out of the text (such as a stale one) returns an error, and the document is not changed.
+func (d *Document) Apply(e Edit) (antlr.ParserRuleContext, error) {
+	if e.Start < 0 || e.Stop < e.Start-1 || e.Stop >= len(d.text) {
+		return nil, fmt.Errorf("edit %d-%d is out of the text of %d characters", e.Start, e.Stop, len(d.text))
+	}
+	newText := []rune(e.NewText)
+	delta := len(newText) - (e.Stop + 1 - e.Start)
+	unit := d.unitOf(e.Start, e.Stop)
+
+	d.text = append(d.text[:e.Start:e.Start], append(newText, d.text[e.Stop+1:]...)...)
+	d.update()
+	if unit == nil {
+		d.parseAll()
+		return d.tree, nil
+	}
+
+	start, stop := unit.GetStart().GetStart(), unit.GetStop().GetStop()
+	errors := d.errors[:0:0]
+	for _, de := range d.errors {
+		if pos := de.position(); pos < start || stop < pos {
+			errors = append(errors, de)
+		}
+	}
+	for _, s := range d.segs {
+		if s.start > stop {
+			s.start += delta
+		}
+	}
+
+	seg := &segment{doc: d, start: start}
+	parser, el := d.newParser(string(d.text[start:stop+delta+1]), seg)
+	var node antlr.ParserRuleContext
+	switch unit.(type) {
+	case *plsql.Package_obj_bodyContext:
+		node = parser.Package_obj_body()
+	case *plsql.Unit_statementContext:
+		node = parser.Unit_statement()
+	case *plsql.Sql_plus_commandContext:
+		node = parser.Sql_plus_command()
+	}
+	if node == nil || parser.GetCurrentToken().GetTokenType() != antlr.TokenEOF {
+		// the unit has changed its extent
+		d.parseAll()
+		return d.tree, nil
+	}
+
+	// splice the node into the tree
+	parent := unit.GetParent().(antlr.ParserRuleContext)
+	children := parent.GetChildren()
+	for i, ch := range children {
+		if ch == unit {
+			children[i] = node
+			break
+		}
+	}
+	node.SetParent(parent)
+	if parent.GetStart() == unit.GetStart() {
+		parent.SetStart(node.GetStart())
+	}
+	if parent.GetStop() == unit.GetStop() {
+		parent.SetStop(node.GetStop())
+	}
+
+	// splice the tokens
+	tokens := parser.GetTokenStream().(*antlr.CommonTokenStream).GetAllTokens()
+	tokens = tokens[:len(tokens)-1] // EOF
+	from, to := unit.GetStart().GetTokenIndex(), unit.GetStop().GetTokenIndex()
+	d.tokens = append(d.tokens[:from:from], append(tokens, d.tokens[to+1:]...)...)
+	for i, t := range d.tokens {
+		t.SetTokenIndex(i)
+	}
+
+	d.errors = append(errors, el.errors...)
+	d.resegment()
+	return node, nil
+}
+
+// unitOf returns the innermost unit containing the start-stop range, or nil.
+// The edit must not touch the first and the last character of the unit, to keep its extent.
+func (d *Document) unitOf(start, stop int) antlr.ParserRuleContext {
+	var found antlr.ParserRuleContext
+	d.units(func(unit antlr.ParserRuleContext) {
+		if unit.GetStart().GetStart() < start && stop < unit.GetStop().GetStop() {
+			found = unit
+		}
+	})
+	return found
+}
+
+// units calls f for each top-level unit, and then for its package body members.
+func (d *Document) units(f func(antlr.ParserRuleContext)) {
+	for _, ch := range d.tree.GetChildren() {
+		unit, ok := ch.(antlr.ParserRuleContext)
+		if !ok || !complete(unit) {
+			continue
+		}
+		f(unit)
+		if us, ok := unit.(*plsql.Unit_statementContext); ok {
+			if pb, ok := us.Create_package_body().(*plsql.Create_package_bodyContext); ok {
+				for _, m := range pb.AllPackage_obj_body() {
+					if m := m.(antlr.ParserRuleContext); complete(m) {
+						f(m)
+					}
+				}
+			}
+		}
+	}
+}
+
+// complete reports whether the node has tokens (a node of a syntax error may have none).
+func complete(node antlr.ParserRuleContext) bool {
+	return node.GetStart() != nil && node.GetStop() != nil &&
+		node.GetStart().GetTokenIndex() <= node.GetStop().GetTokenIndex()
+}
+
+// update the input and the line starts after a change of the text.
+func (d *Document) update() {
+	d.input = casefold.NewStream(string(d.text))
+	d.lines = append(d.lines[:0], 0)
+	for i, r := range d.text {
+		if r == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+}
+
+// lineColumn returns the 1-based line and 0-based column of the position.
+func (d *Document) lineColumn(pos int) (int, int) {
+	i := sort.Search(len(d.lines), func(i int) bool { return d.lines[i] > pos }) - 1
+	if i < 0 {
+		i = 0
+	}
+	return i + 1, pos - d.lines[i]
+}
+
+// parseAll parses the whole text.
+func (d *Document) parseAll() {
+	seg := &segment{doc: d}
+	parser, el := d.newParser(string(d.text), seg)
+	d.tree = parser.Sql_script().(antlr.ParserRuleContext)
+	stream := parser.GetTokenStream().(*antlr.CommonTokenStream)
+	stream.Fill()
+	d.tokens = stream.GetAllTokens()
+	d.errors = el.errors
+	d.segs = []*segment{seg}
+	d.resegment()
+}
+
+// newParser returns a parser for the text of a unit starting at the segment.
+func (d *Document) newParser(text string, seg *segment) (*plsql.PlSqlParser, *docErrorListener) {
+	lexer := plsql.NewPlSqlLexer(casefold.NewStream(text))
+	stream := antlr.NewCommonTokenStream(&docTokenSource{PlSqlLexer: lexer, seg: seg}, antlr.TokenDefaultChannel)
+	parser := plsql.NewPlSqlParser(stream)
+	parser.BuildParseTrees = true
+	el := &docErrorListener{DefaultErrorListener: antlr.NewDefaultErrorListener(), seg: seg, text: []rune(text)}
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(el)
+	parser.RemoveErrorListeners()
+	parser.AddErrorListener(el)
+	d.opts.trace(parser)
+	return parser, el
+}
+
+// resegment splits the tokens into segments at the bounds of the units,
+// so the tokens of a unit are in segments of their own, which can be shifted.
+func (d *Document) resegment() {
+	bounds := make(map[int]bool)
+	d.units(func(unit antlr.ParserRuleContext) {
+		bounds[unit.GetStart().GetTokenIndex()] = true
+		bounds[unit.GetStop().GetTokenIndex()+1] = true
+	})
+	d.segs = d.segs[:0]
+	var seg *segment
+	for i, t := range d.tokens {
+		dt := t.(*docToken)
+		start, stop := dt.GetStart(), dt.GetStop()
+		if seg == nil || bounds[i] {
+			seg = &segment{doc: d, start: start}
+			d.segs = append(d.segs, seg)
+		}
+		dt.seg, dt.start, dt.stop = seg, start-seg.start, stop-seg.start
+	}
+	for i := range d.errors {
+		e := &d.errors[i]
+		pos := e.position()
+		j := sort.Search(len(d.segs), func(j int) bool { return d.segs[j].start > pos }) - 1
+		if j < 0 {
+			j = 0
+		}
+		e.seg, e.offset = d.segs[j], pos-d.segs[j].start
+	}
+}
+
+// segment is a range of the text, which shifts as a whole.
+type segment struct {
+	doc   *Document
+	start int
+}
+
+// docToken is a token with a position relative to its segment.
+type docToken struct {
+	antlr.Token
+	seg         *segment
+	start, stop int
+}
+
+func (t *docToken) GetStart() int { return t.seg.start + t.start }
+func (t *docToken) GetStop() int  { return t.seg.start + t.stop }
+func (t *docToken) GetLine() int {
+	line, _ := t.seg.doc.lineColumn(t.GetStart())
+	return line
+}
+func (t *docToken) GetColumn() int {
+	_, col := t.seg.doc.lineColumn(t.GetStart())
+	return col
+}
+
+// GetInputStream returns the input of the whole document, as the positions are in it.
+func (t *docToken) GetInputStream() antlr.CharStream { return t.seg.doc.input }
+
+// docTokenSource creates docTokens.
+type docTokenSource struct {
+	*plsql.PlSqlLexer
+	seg *segment
+}
+
+func (ts *docTokenSource) NextToken() antlr.Token {
+	t := ts.PlSqlLexer.NextToken()
+	return &docToken{Token: t, seg: ts.seg, start: t.GetStart(), stop: t.GetStop()}
+}
+
+type docError struct {
+	seg    *segment
+	offset int
+	msg    string
+}
+
+func (e docError) position() int { return e.seg.start + e.offset }
+
+// docErrorListener collects the lexer and parser errors with their positions in the segment.
+type docErrorListener struct {
+	*antlr.DefaultErrorListener
+	seg    *segment
+	text   []rune
+	errors []docError
+}
+
+func (el *docErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	var offset int
+	if t, ok := offendingSymbol.(*docToken); ok {
+		offset = t.start
+	} else {
+		for l := 1; offset < len(el.text) && l < line; offset++ {
+			if el.text[offset] == '\n' {
+				l++
+			}
+		}
+		offset += column
+	}
+	el.errors = append(el.errors, docError{seg: el.seg, offset: offset, msg: msg})
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+func TestDocument(t *testing.T) {
+	const src = `CREATE OR REPLACE PACKAGE BODY pkg IS
+  PROCEDURE a IS
+  BEGIN
+    NULL;
+  END a;
+
+  PROCEDURE b IS
+  BEGIN
+    NULL;
+  END b;
+END pkg;
+`
+	d := plsqlparser.NewDocument(src)
+	if errs := d.Errors(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	bodies := func() []*plsql.Procedure_bodyContext {
+		var bodies []*plsql.Procedure_bodyContext
+		var walk func(antlr.Tree)
+		walk = func(node antlr.Tree) {
+			if pb, ok := node.(*plsql.Procedure_bodyContext); ok {
+				bodies = append(bodies, pb)
+			}
+			for _, ch := range node.GetChildren() {
+				walk(ch)
+			}
+		}
+		walk(d.Tree())
+		return bodies
+	}
+	before := bodies()
+	if len(before) != 2 {
+		t.Fatalf("got %d procedure bodies, wanted 2", len(before))
+	}
+
+	edit := func(old string, i int, newText string) antlr.ParserRuleContext {
+		t.Helper()
+		text := d.Text()
+		start := strings.Index(text[i:], old) + i
+		e := plsqlparser.Edit{
+			Chunk:   plsqlparser.Chunk{Start: len([]rune(text[:start])), Stop: len([]rune(text[:start+len(old)])) - 1},
+			NewText: newText,
+		}
+		node, err := d.Apply(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := plsqlparser.ApplyEdits(text, []plsqlparser.Edit{e})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.Text(); got != want {
+			t.Fatalf("got text\n%s\nwanted\n%s", got, want)
+		}
+		return node
+	}
+
+	node := edit("NULL;", 0, "DBMS_OUTPUT.PUT_LINE('a');\n    NULL;")
+	if _, ok := node.(*plsql.Package_obj_bodyContext); !ok {
+		t.Errorf("reparsed %T, wanted a package body member", node)
+	}
+	after := bodies()
+	if after[0] == before[0] || after[1] != before[1] {
+		t.Error("wanted only the first procedure to be parsed again")
+	}
+	b := after[1]
+	if got, want := b.GetStart().GetStart(), strings.Index(d.Text(), "PROCEDURE b"); got != want {
+		t.Errorf("start of b: got %d, wanted %d", got, want)
+	}
+	if got := b.GetStart().GetLine(); got != 8 {
+		t.Errorf("line of b: got %d, wanted 8", got)
+	}
+	if got := b.GetStop().GetTokenIndex(); d.Tokens()[got] != b.GetStop() {
+		t.Errorf("token index of the end of b is %d", got)
+	}
+
+	edit("NULL;", strings.Index(d.Text(), "PROCEDURE b"), "NULL")
+	if errs := d.Errors(); len(errs) == 0 {
+		t.Error("wanted a syntax error")
+	}
+	edit("NULL", strings.Index(d.Text(), "PROCEDURE b"), "NULL;")
+	if errs := d.Errors(); len(errs) != 0 {
+		t.Error(errs)
+	}
+
+	if _, ok := edit("END pkg;", 0, "END pkg;\nCREATE TABLE t (x NUMBER);").(*plsql.Sql_scriptContext); !ok {
+		t.Error("wanted the whole text to be parsed again")
+	}
+	text := d.Text()
+	if _, err := d.Apply(plsqlparser.Edit{Chunk: plsqlparser.Chunk{Start: len(text), Stop: len(text) + 5}}); err == nil {
+		t.Error("wanted error for a stale edit")
+	}
+	if d.Text() != text {
+		t.Error("the stale edit changed the text")
+	}
+}
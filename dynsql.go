@@ -0,0 +1,335 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"strings"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// DynamicSQL is the SQL of an EXECUTE IMMEDIATE, OPEN FOR or DBMS_SQL.PARSE,
+// known at parse time: a string literal, or a concatenation of literals and constants.
+type DynamicSQL struct {
+	// Node is the expression of the dynamic SQL in the enclosing unit (or dynamic SQL).
+	Node antlr.ParserRuleContext
+	// Text is the SQL, unquoted.
+	Text string
+	// Tree is the parse tree of the Text.
+	Tree antlr.Tree
+	// Tables are the tables and views the SQL reads or writes.
+	Tables []string
+	// Diagnostics are the syntax errors of the Text, with positions in the unit.
+	Diagnostics []Diagnostic
+	// Children is the dynamic SQL of the dynamic SQL (e.g. an EXECUTE IMMEDIATE in an anonymous block).
+	Children []DynamicSQL
+
+	// positions are the positions in the unit of the runes of Text.
+	positions []int
+}
+
+// Position returns the position in the unit of the rune offset of the Text,
+// such as the Start of a token of the Tree.
+func (ds DynamicSQL) Position(offset int) int {
+	if len(ds.positions) == 0 {
+		return -1
+	}
+	if offset >= len(ds.positions) {
+		return ds.positions[len(ds.positions)-1] + 1 + offset - len(ds.positions)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return ds.positions[offset]
+}
+
+// Chunk returns the node of the Tree with its positions in the unit.
+//
+// The Stop of a chunk spanning several literals is in the last literal.
+func (ds DynamicSQL) Chunk(node antlr.ParserRuleContext) Chunk {
+	c := ctxChunk(node)
+	c.Start, c.Stop = ds.Position(c.Start), ds.Position(c.Stop)
+	return c
+}
+
+// ParseDynamicSQL parses the unit, and returns its dynamic SQL parsed recursively.
+//
+// The syntax errors of the unit are returned as error,
+// the syntax errors of the dynamic SQL are in its Diagnostics.
+func ParseDynamicSQL(unit string, opts ...Options) ([]DynamicSQL, error) {
+	o := options(opts)
+	parser := o.newParser(unit)
+	el := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	parser.AddErrorListener(el)
+	tree := parser.Sql_script()
+	if len(el.slice) != 0 {
+		return nil, &el.Errors
+	}
+	return FindDynamicSQL(tree, unit, o), nil
+}
+
+// FindDynamicSQL returns the dynamic SQL of the tree parsed from the text, parsed recursively.
+func FindDynamicSQL(tree antlr.Tree, text string, opts ...Options) []DynamicSQL {
+	rs := []rune(text)
+	return findDynamicSQL(tree, rs, rs, func(i int) int { return i }, options(opts))
+}
+
+// DynamicSQLListener is a listener notified by WalkDynamicSQL when it descends into a dynamic SQL.
+type DynamicSQLListener interface {
+	antlr.ParseTreeListener
+	EnterDynamicSQL(ds DynamicSQL)
+	ExitDynamicSQL(ds DynamicSQL)
+}
+
+// WalkDynamicSQL walks the tree as antlr.ParseTreeWalkerDefault does, but descends into its dynamic SQL, too:
+// the Tree of each is walked as the last child of its Node.
+//
+// The positions of the tokens of the dynamic SQL are in its Text,
+// a DynamicSQLListener can map them into the unit with DynamicSQL.Chunk.
+func WalkDynamicSQL(listener antlr.ParseTreeListener, tree antlr.Tree, dss []DynamicSQL) {
+	byNode := make(map[antlr.Tree][]DynamicSQL, len(dss))
+	for _, ds := range dss {
+		byNode[ds.Node] = append(byNode[ds.Node], ds)
+	}
+	walkDynamicSQL(listener, tree, byNode)
+}
+
+func walkDynamicSQL(listener antlr.ParseTreeListener, tree antlr.Tree, byNode map[antlr.Tree][]DynamicSQL) {
+	switch x := tree.(type) {
+	case antlr.ErrorNode:
+		listener.VisitErrorNode(x)
+		return
+	case antlr.TerminalNode:
+		listener.VisitTerminal(x)
+		return
+	}
+	rn := tree.(antlr.RuleNode)
+	antlr.ParseTreeWalkerDefault.EnterRule(listener, rn)
+	for i := 0; i < tree.GetChildCount(); i++ {
+		walkDynamicSQL(listener, tree.GetChild(i), byNode)
+	}
+	dl, _ := listener.(DynamicSQLListener)
+	for _, ds := range byNode[tree] {
+		if dl != nil {
+			dl.EnterDynamicSQL(ds)
+		}
+		WalkDynamicSQL(listener, ds.Tree, ds.Children)
+		if dl != nil {
+			dl.ExitDynamicSQL(ds)
+		}
+	}
+	antlr.ParseTreeWalkerDefault.ExitRule(listener, rn)
+}
+
+// findDynamicSQL finds the dynamic SQL in the tree of the text, with pos mapping the positions of text into the unit.
+func findDynamicSQL(tree antlr.Tree, unit, text []rune, pos func(int) int, o Options) []DynamicSQL {
+	st := NewSymbolTable(tree)
+	dl := &dynamicListener{
+		BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()},
+		st:               st,
+		refs:             make(map[antlr.Tree]*Symbol),
+		constants:        make(map[*Symbol]antlr.Tree),
+	}
+	for _, ref := range st.References {
+		if !ref.Decl {
+			dl.refs[ref.Node] = ref.Symbol
+		}
+	}
+	antlr.ParseTreeWalkerDefault.Walk(dl, tree)
+
+	var dss []DynamicSQL
+	for _, node := range dl.sinks {
+		var f folder
+		if !f.fold(dl, node, text, 0) {
+			continue
+		}
+		ds := DynamicSQL{Node: node, Text: string(f.text), positions: make([]int, len(f.positions))}
+		for i, p := range f.positions {
+			ds.positions[i] = pos(p)
+		}
+		parser := o.newParser(ds.Text)
+		el := &dynamicErrorListener{DefaultErrorListener: antlr.NewDefaultErrorListener(), unit: unit, ds: &ds}
+		parser.AddErrorListener(el)
+		ds.Tree = parser.Sql_script()
+		tl := &tableListener{BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}}
+		antlr.ParseTreeWalkerDefault.Walk(tl, ds.Tree)
+		ds.Tables = tl.tables
+		ds.Children = findDynamicSQL(ds.Tree, unit, f.text, ds.Position, o)
+		dss = append(dss, ds)
+	}
+	return dss
+}
+
+type dynamicListener struct {
+	BaseWalkListener
+	st        *SymbolTable
+	refs      map[antlr.Tree]*Symbol
+	constants map[*Symbol]antlr.Tree
+	sinks     []antlr.ParserRuleContext
+}
+
+func (dl *dynamicListener) ExitVariable_declaration(ctx *plsql.Variable_declarationContext) {
+	dv, ok := ctx.Default_value_part().(*plsql.Default_value_partContext)
+	if !ok || ctx.CONSTANT() == nil {
+		return
+	}
+	if sym := dl.st.Canonical(dl.st.decls[ctx.Identifier()]); sym != nil {
+		dl.constants[sym] = dv.Expression()
+	}
+}
+
+func (dl *dynamicListener) ExitExecute_immediate(ctx *plsql.Execute_immediateContext) {
+	dl.sinks = append(dl.sinks, ctx.Expression())
+}
+
+func (dl *dynamicListener) ExitOpen_for_statement(ctx *plsql.Open_for_statementContext) {
+	if e := ctx.Expression(); e != nil {
+		dl.sinks = append(dl.sinks, e)
+	}
+}
+
+func (dl *dynamicListener) ExitProcedure_call(ctx *plsql.Procedure_callContext) {
+	if fa := ctx.Function_argument(); fa != nil && routineName(ctx.Routine_name()) == "DBMS_SQL.PARSE" {
+		if e := dbmsSQLStatement(fa); e != nil {
+			dl.sinks = append(dl.sinks, e)
+		}
+	}
+}
+
+// routineName returns the normalized, dotted name of the routine.
+func routineName(rn plsql.IRoutine_nameContext) string {
+	r := rn.(*plsql.Routine_nameContext)
+	ids := qualifiedIDs(r.Identifier(), r.AllId_expression())
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = catalog.Normalize(id.GetText())
+	}
+	return strings.TrimPrefix(strings.Join(names, "."), "SYS.")
+}
+
+// dbmsSQLStatement returns the statement argument of DBMS_SQL.PARSE: the second, or the one named STATEMENT.
+func dbmsSQLStatement(fa plsql.IFunction_argumentContext) antlr.ParserRuleContext {
+	for i, a := range fa.(*plsql.Function_argumentContext).AllArgument() {
+		a := a.(*plsql.ArgumentContext)
+		if id := a.Identifier(); id != nil && catalog.Normalize(id.GetText()) == "STATEMENT" || id == nil && i == 1 {
+			return a.Expression()
+		}
+	}
+	return nil
+}
+
+// folder concatenates the string literals, keeping the position of each rune.
+type folder struct {
+	text      []rune
+	positions []int
+}
+
+// fold the expression into a string, reporting whether it is constant.
+func (f *folder) fold(dl *dynamicListener, node antlr.Tree, text []rune, depth int) bool {
+	if depth > 16 {
+		return false
+	}
+	switch x := node.(type) {
+	case *plsql.ConcatenationContext:
+		if x.BAR(0) != nil {
+			return f.fold(dl, x.Concatenation(0), text, depth) && f.fold(dl, x.Concatenation(1), text, depth)
+		}
+	case *plsql.Quoted_stringContext:
+		if t := x.CHAR_STRING(); t != nil {
+			f.literal(text, t.GetSymbol())
+			return true
+		} else if t := x.NATIONAL_CHAR_STRING_LIT(); t != nil {
+			f.literal(text, t.GetSymbol())
+			return true
+		}
+	case antlr.ParserRuleContext:
+		if sym := dl.refs[x]; sym != nil {
+			if e := dl.constants[sym]; e != nil {
+				return f.fold(dl, e, text, depth+1)
+			}
+			return false
+		}
+	}
+	// single-child chains, such as expression -> ... -> constant -> quoted_string
+	if children := node.GetChildren(); len(children) == 1 {
+		if _, ok := children[0].(antlr.ParserRuleContext); ok {
+			return f.fold(dl, children[0], text, depth)
+		}
+	}
+	return false
+}
+
+// literal appends the value of the 'string', N'string' or q'[string]' literal token.
+func (f *folder) literal(text []rune, t antlr.Token) {
+	start, stop := t.GetStart(), t.GetStop()+1
+	if text[start] == 'N' || text[start] == 'n' {
+		start++
+	}
+	if text[start] == 'Q' || text[start] == 'q' {
+		for i := start + 3; i < stop-2; i++ {
+			f.text = append(f.text, text[i])
+			f.positions = append(f.positions, i)
+		}
+		return
+	}
+	for i := start + 1; i < stop-1; i++ {
+		f.text = append(f.text, text[i])
+		f.positions = append(f.positions, i)
+		if text[i] == '\'' {
+			i++ // doubled quote
+		}
+	}
+}
+
+// dynamicErrorListener collects the syntax errors of the dynamic SQL with positions in the unit.
+type dynamicErrorListener struct {
+	*antlr.DefaultErrorListener
+	unit []rune
+	ds   *DynamicSQL
+}
+
+func (el *dynamicErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	var offset int
+	if t, ok := offendingSymbol.(antlr.Token); ok && t.GetTokenType() != antlr.TokenEOF {
+		offset = t.GetStart()
+	} else {
+		// offset of the line:column in the text
+		text := []rune(el.ds.Text)
+		for l := 1; offset < len(text) && l < line; offset++ {
+			if text[offset] == '\n' {
+				l++
+			}
+		}
+		offset += column
+	}
+	pos := el.ds.Position(offset)
+	d := Diagnostic{Chunk: Chunk{Start: pos, Stop: pos}, Message: "dynamic SQL: " + msg}
+	if t, ok := offendingSymbol.(antlr.Token); ok {
+		d.Text = t.GetText()
+	}
+	d.Line, d.Column = lineColumn(el.unit, pos)
+	el.ds.Diagnostics = append(el.ds.Diagnostics, d)
+}
+
+// tableListener collects the tables of the DML statements.
+type tableListener struct {
+	BaseWalkListener
+	tables []string
+}
+
+func (tl *tableListener) ExitDml_table_expression_clause(ctx *plsql.Dml_table_expression_clauseContext) {
+	if tv := ctx.Tableview_name(); tv != nil {
+		if name := tableviewName(tv); name != "" {
+			for _, t := range tl.tables {
+				if t == name {
+					return
+				}
+			}
+			tl.tables = append(tl.tables, name)
+		}
+	}
+}
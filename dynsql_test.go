@@ -0,0 +1,71 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestParseDynamicSQL(t *testing.T) {
+	const unit = `CREATE OR REPLACE PROCEDURE upd(p_id IN NUMBER) IS
+  c_table CONSTANT VARCHAR2(30) := 'emp';
+BEGIN
+  EXECUTE IMMEDIATE 'UPDATE ' || c_table || ' SET name = ''x'' WHERE id = :1' USING p_id;
+  EXECUTE IMMEDIATE q'[DELETE FROM dept WHERE name = 'y']';
+  EXECUTE IMMEDIATE 'BEGIN EXECUTE IMMEDIATE ''TRUNCATE TABLE tmp''; END;';
+  EXECUTE IMMEDIATE 'SELEC * FROM t';
+  EXECUTE IMMEDIATE 'DELETE FROM ' || p_id;
+END;
+`
+	dss, err := plsqlparser.ParseDynamicSQL(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	for _, ds := range dss {
+		texts = append(texts, ds.Text)
+	}
+	if got, want := strings.Join(texts, "\n"), `UPDATE emp SET name = 'x' WHERE id = :1
+DELETE FROM dept WHERE name = 'y'
+BEGIN EXECUTE IMMEDIATE 'TRUNCATE TABLE tmp'; END;
+SELEC * FROM t`; got != want {
+		t.Fatalf("got\n%s\nwanted\n%s", got, want)
+	}
+
+	for i, tc := range []struct {
+		ds     plsqlparser.DynamicSQL
+		offset int
+		want   int
+	}{
+		{dss[0], 0, strings.Index(unit, "UPDATE")},
+		{dss[0], strings.Index(dss[0].Text, "emp"), strings.Index(unit, "emp")},
+		{dss[0], strings.Index(dss[0].Text, "'x'"), strings.Index(unit, "''x''")},
+		{dss[0], strings.Index(dss[0].Text, ":1"), strings.Index(unit, ":1")},
+		{dss[1], 0, strings.Index(unit, "DELETE FROM dept")},
+		{dss[2].Children[0], 0, strings.Index(unit, "TRUNCATE")},
+	} {
+		if got := tc.ds.Position(tc.offset); got != tc.want {
+			t.Errorf("%d. %q[%d]: got %d, wanted %d", i, tc.ds.Text, tc.offset, got, tc.want)
+		}
+	}
+
+	if got := strings.Join(dss[0].Tables, ","); got != "EMP" {
+		t.Errorf("tables of %q: got %q", dss[0].Text, got)
+	}
+	if got := strings.Join(dss[1].Tables, ","); got != "DEPT" {
+		t.Errorf("tables of %q: got %q", dss[1].Text, got)
+	}
+	for _, ds := range dss[:3] {
+		if len(ds.Diagnostics) != 0 {
+			t.Errorf("%q: %v", ds.Text, ds.Diagnostics)
+		}
+	}
+	if len(dss[3].Diagnostics) == 0 || dss[3].Diagnostics[0].Line != 7 {
+		t.Errorf("%q: got %v, wanted syntax error in line 7", dss[3].Text, dss[3].Diagnostics)
+	}
+}
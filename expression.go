@@ -0,0 +1,418 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// Parse the INSERT statement with the grammar.
+//
+// The positions of the expressions are rune offsets in the text.
+func (ii *InsertInto) Parse(text string, opts ...Options) error {
+	tree, err := parseRule(text, options(opts), func(p *plsql.PlSqlParser) antlr.ParserRuleContext { return p.Insert_statement() })
+	if err != nil {
+		return err
+	}
+	sti, ok := tree.(*plsql.Insert_statementContext).Single_table_insert().(*plsql.Single_table_insertContext)
+	if !ok {
+		return errors.New("multi-table INSERT is not supported")
+	}
+	iic := sti.Insert_into_clause().(*plsql.Insert_into_clauseContext)
+	ii.Table = iic.General_table_ref().GetText()
+	ii.Fields = ii.Fields[:0]
+	if pcl := iic.Paren_column_list(); pcl != nil {
+		for _, col := range pcl.(*plsql.Paren_column_listContext).Column_list().(*plsql.Column_listContext).AllColumn_name() {
+			ii.Fields = append(ii.Fields, col.GetText())
+		}
+	}
+	ii.Values, ii.Select, ii.IsSelect = nil, nil, false
+	if vc, ok := sti.Values_clause().(*plsql.Values_clauseContext); ok {
+		if t := vc.REGULAR_ID(); t != nil { // a record
+			ii.Values = append(ii.Values, Expression{Kind: ColumnExpr, Value: t.GetText(), Chunk: terminalChunk(t)})
+		} else if es := vc.Expressions(); es != nil {
+			ii.Values = newExpressions(es.(*plsql.ExpressionsContext).AllExpression())
+		}
+		return nil
+	}
+	if ss, ok := sti.Select_statement().(*plsql.Select_statementContext); ok {
+		sel := newSelectStatement(ss)
+		ii.IsSelect, ii.Select, ii.Values = true, &sel, sel.Fields
+	}
+	return nil
+}
+
+// ParseSelectStatement parses the query with the grammar.
+//
+// The Fields, Aliases, From and Where are of the first query block
+// (the one before UNION, INTERSECT or MINUS, after the WITH clause).
+func ParseSelectStatement(text string, opts ...Options) (SelectStatement, error) {
+	tree, err := parseRule(text, options(opts), func(p *plsql.PlSqlParser) antlr.ParserRuleContext { return p.Select_statement() })
+	if err != nil {
+		return SelectStatement{}, err
+	}
+	return newSelectStatement(tree.(*plsql.Select_statementContext)), nil
+}
+
+// ParseExpression parses the SQL or PL/SQL expression with the grammar.
+func ParseExpression(text string, opts ...Options) (Expression, error) {
+	tree, err := parseRule(text, options(opts), func(p *plsql.PlSqlParser) antlr.ParserRuleContext { return p.Expression() })
+	if err != nil {
+		return Expression{}, err
+	}
+	return newExpression(tree), nil
+}
+
+// parseRule parses the uppercased text with the rule,
+// as the Expression values have always been uppercase (the lexer would not need it), returning the syntax errors, and an error if the rule does not consume the text (except a final semicolon).
+func parseRule(text string, o Options, rule func(*plsql.PlSqlParser) antlr.ParserRuleContext) (antlr.ParserRuleContext, error) {
+	parser := o.newParser(upper(text))
+	el := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	parser.AddErrorListener(el)
+	tree := rule(parser)
+	if len(el.slice) == 0 {
+		if t := parser.GetCurrentToken(); t.GetTokenType() == plsql.PlSqlParserSEMICOLON {
+			parser.Consume()
+		}
+		if t := parser.GetCurrentToken(); t.GetTokenType() != antlr.TokenEOF {
+			el.Append(fmt.Errorf("%d:%d: unexpected %q", t.GetLine(), t.GetColumn()+1, t.GetText()))
+		}
+	}
+	if len(el.slice) != 0 {
+		return tree, &el.Errors
+	}
+	return tree, nil
+}
+
+func newSelectStatement(ctx *plsql.Select_statementContext) SelectStatement {
+	var ss SelectStatement
+	qb := firstQueryBlock(ctx)
+	if qb == nil {
+		return ss
+	}
+	sl := qb.Selected_list().(*plsql.Selected_listContext)
+	if t := sl.ASTERISK(); t != nil {
+		ss.Fields = append(ss.Fields, Expression{Kind: StarExpr, Value: "*", Chunk: terminalChunk(t)})
+		ss.Aliases = append(ss.Aliases, "")
+	}
+	for _, sle := range sl.AllSelect_list_elements() {
+		sle := sle.(*plsql.Select_list_elementsContext)
+		if tv := sle.Tableview_name(); tv != nil {
+			ss.Fields = append(ss.Fields, Expression{Kind: StarExpr, Value: tv.GetText() + ".*", Chunk: ctxChunk(sle)})
+			ss.Aliases = append(ss.Aliases, "")
+			continue
+		}
+		ss.Fields = append(ss.Fields, newExpression(sle.Expression()))
+		var alias string
+		if ca, ok := sle.Column_alias().(*plsql.Column_aliasContext); ok {
+			if id := ca.Identifier(); id != nil {
+				alias = id.GetText()
+			} else if qs := ca.Quoted_string(); qs != nil {
+				alias = qs.GetText()
+			}
+		}
+		ss.Aliases = append(ss.Aliases, alias)
+	}
+	if fc, ok := qb.From_clause().(*plsql.From_clauseContext); ok {
+		ss.From = fromTables(fc)
+	}
+	if wc, ok := qb.Where_clause().(*plsql.Where_clauseContext); ok && wc.Expression() != nil {
+		where := newExpression(wc.Expression())
+		ss.Where = &where
+	}
+	return ss
+}
+
+// firstQueryBlock returns the first query block of the tree, skipping the WITH clause.
+func firstQueryBlock(tree antlr.Tree) *plsql.Query_blockContext {
+	if qb, ok := tree.(*plsql.Query_blockContext); ok {
+		return qb
+	}
+	for _, ch := range tree.GetChildren() {
+		if _, ok := ch.(*plsql.Subquery_factoring_clauseContext); ok {
+			continue
+		}
+		if qb := firstQueryBlock(ch); qb != nil {
+			return qb
+		}
+	}
+	return nil
+}
+
+func newExpressions(es []plsql.IExpressionContext) Expressions {
+	exprs := make(Expressions, len(es))
+	for i, e := range es {
+		exprs[i] = newExpression(e)
+	}
+	return exprs
+}
+
+// newExpression converts the parse tree of the expression into an Expression.
+//
+// The single-child chains of the grammar (expression -> logical_expression -> ... -> atom)
+// are collapsed: the Chunk of an Expression is the text of its own node.
+func newExpression(tree antlr.Tree) Expression {
+	ctx, ok := tree.(antlr.ParserRuleContext)
+	if !ok {
+		if t, ok := tree.(antlr.TerminalNode); ok {
+			e := Expression{Kind: OtherExpr, Value: t.GetText(), Chunk: terminalChunk(t)}
+			if t.GetSymbol().GetTokenType() == plsql.PlSqlParserASTERISK {
+				e.Kind = StarExpr
+			}
+			return e
+		}
+		return Expression{Kind: OtherExpr}
+	}
+	children := ctx.GetChildren()
+	e := Expression{Kind: OtherExpr, Chunk: ctxChunk(ctx)}
+	e.Value = e.Text
+	switch x := ctx.(type) {
+	case *plsql.ExpressionContext:
+		if x.Cursor_expression() != nil {
+			e.Kind = SubqueryExpr
+			return e
+		}
+
+	case *plsql.Logical_expressionContext,
+		*plsql.Multiset_expressionContext,
+		*plsql.Relational_expressionContext,
+		*plsql.ConcatenationContext:
+		if len(children) == 1 {
+			break
+		}
+		if _, ok := children[0].(*plsql.Model_expressionContext); ok {
+			return e // AT TIME ZONE, interval
+		}
+		return e.operation(children)
+
+	case *plsql.Unary_logical_expressionContext:
+		var not bool
+		if isToken(children[0], plsql.PlSqlParserNOT) {
+			not, children = true, children[1:]
+		}
+		operand, op := newExpression(children[0]), "IS"
+		for _, ch := range children[1:] {
+			if isToken(ch, plsql.PlSqlParserNOT) {
+				op = "IS NOT"
+			} else if lo, ok := ch.(*plsql.Logical_operationContext); ok {
+				c := ctxChunk(lo)
+				c.Start = operand.Start
+				c.Text = x.GetStart().GetInputStream().GetText(c.Start, c.Stop)
+				operand = Expression{Kind: OperatorExpr, Value: op + " " + strings.Join(strings.Fields(ctxChunk(lo).Text), " "),
+					Expr: Expressions{operand}, Chunk: c}
+				op = "IS"
+			}
+		}
+		if not {
+			e.Kind, e.Value, e.Expr = OperatorExpr, "NOT", Expressions{operand}
+			return e
+		}
+		return operand
+
+	case *plsql.Compound_expressionContext:
+		if len(children) == 1 {
+			break
+		}
+		var op []string
+		e.Kind = OperatorExpr
+		for _, ch := range children {
+			switch ch := ch.(type) {
+			case antlr.TerminalNode:
+				if len(e.Expr) == 1 { // not ESCAPE
+					op = append(op, ch.GetText())
+				}
+			case *plsql.Between_elementsContext:
+				for _, c := range ch.AllConcatenation() {
+					e.Expr = append(e.Expr, newExpression(c))
+				}
+			default:
+				e.Expr = append(e.Expr, newExpression(ch))
+			}
+		}
+		e.Value = strings.Join(op, " ")
+		return e
+
+	case *plsql.In_elementsContext:
+		if x.Subquery() != nil {
+			e.Kind = SubqueryExpr
+			return e
+		}
+		if cs := x.AllConcatenation(); len(cs) != 0 {
+			e.Kind, e.Value = ListExpr, ""
+			for _, c := range cs {
+				e.Expr = append(e.Expr, newExpression(c))
+			}
+			return e
+		}
+
+	case *plsql.Unary_expressionContext:
+		if t, ok := children[0].(antlr.TerminalNode); ok {
+			e.Kind, e.Value, e.Expr = OperatorExpr, t.GetText(), Expressions{newExpression(children[1])}
+			return e
+		}
+
+	case *plsql.Quantified_expressionContext:
+		e.Kind, e.Value = OperatorExpr, children[0].(antlr.TerminalNode).GetText()
+		if s := x.Select_only_statement(); s != nil {
+			sub := Expression{Kind: SubqueryExpr, Chunk: ctxChunk(s)}
+			sub.Value = sub.Text
+			e.Expr = Expressions{sub}
+		} else {
+			e.Expr = Expressions{newExpression(x.Expression())}
+		}
+		return e
+
+	case *plsql.Case_statementContext, *plsql.Model_expressionContext:
+		if len(children) != 1 {
+			return e
+		}
+
+	case *plsql.String_functionContext, *plsql.Numeric_functionContext, *plsql.Other_functionContext:
+		return e.function(children)
+
+	case *plsql.AtomContext:
+		switch {
+		case x.Outer_join_sign() != nil:
+			e.Kind, e.Value = ColumnExpr, x.Table_element().GetText()+"(+)"
+			return e
+		case x.Subquery() != nil:
+			e.Kind = SubqueryExpr
+			return e
+		case x.Expressions() != nil:
+			es := x.Expressions().(*plsql.ExpressionsContext).AllExpression()
+			if len(es) == 1 {
+				return newExpression(es[0])
+			}
+			e.Kind, e.Value, e.Expr = ListExpr, "", newExpressions(es)
+			return e
+		}
+
+	case *plsql.Bind_variableContext:
+		e.Kind, e.Value = BindExpr, x.GetText()
+		return e
+
+	case *plsql.ConstantContext:
+		if len(children) != 1 {
+			e.Kind = LiteralExpr
+			return e
+		}
+	case *plsql.NumericContext:
+		e.Kind = LiteralExpr
+		return e
+	case *plsql.Quoted_stringContext:
+		e.Kind = LiteralExpr
+		if x.Variable_name() != nil {
+			e.Kind, e.Value = ColumnExpr, x.GetText()
+		}
+		return e
+	case *plsql.Table_elementContext:
+		e.Kind, e.Value = ColumnExpr, x.GetText()
+		return e
+
+	case *plsql.General_elementContext:
+		parts := x.AllGeneral_element_part()
+		for _, p := range parts[:len(parts)-1] {
+			if p.(*plsql.General_element_partContext).Function_argument() != nil {
+				return e // attribute of a function result
+			}
+		}
+		last := parts[len(parts)-1].(*plsql.General_element_partContext)
+		fa, ok := last.Function_argument().(*plsql.Function_argumentContext)
+		if !ok {
+			e.Kind, e.Value = ColumnExpr, x.GetText()
+			return e
+		}
+		e.Kind = FunctionExpr
+		e.Value = strings.TrimSuffix(x.GetText(), fa.GetText())
+		e.arguments(fa.GetChildren())
+		return e
+	}
+
+	if len(children) == 1 {
+		return newExpression(children[0])
+	}
+	return e
+}
+
+// operation makes e an operator of the rule children, with the terminals as the operator.
+func (e Expression) operation(children []antlr.Tree) Expression {
+	var op []string
+	e.Kind = OperatorExpr
+	for _, ch := range children {
+		switch ch := ch.(type) {
+		case antlr.TerminalNode:
+			op = append(op, ch.GetText())
+		case *plsql.Relational_operatorContext:
+			op = append(op, ch.GetText())
+		default:
+			e.Expr = append(e.Expr, newExpression(ch))
+		}
+	}
+	e.Value = strings.Join(op, " ")
+	if e.Value == "| |" {
+		e.Value = "||"
+	}
+	return e
+}
+
+// function makes e a function call: the first child is the name, the others contain the arguments.
+func (e Expression) function(children []antlr.Tree) Expression {
+	for _, ch := range children {
+		if t, ok := ch.(antlr.TerminalNode); ok {
+			switch t.GetSymbol().GetTokenType() {
+			case plsql.PlSqlParserPERCENT_FOUND, plsql.PlSqlParserPERCENT_NOTFOUND,
+				plsql.PlSqlParserPERCENT_ISOPEN, plsql.PlSqlParserPERCENT_ROWCOUNT:
+				e.Kind = ColumnExpr // cursor attribute
+				return e
+			}
+		}
+	}
+	if len(children) == 1 {
+		return newExpression(children[0])
+	}
+	e.Kind, e.Value = FunctionExpr, children[0].(antlr.ParseTree).GetText()
+	e.arguments(children[1:])
+	return e
+}
+
+// arguments appends the arguments among the children to e.Expr.
+func (e *Expression) arguments(children []antlr.Tree) {
+	for _, ch := range children {
+		switch x := ch.(type) {
+		case *plsql.ExpressionContext, *plsql.ConcatenationContext, *plsql.Table_elementContext,
+			*plsql.Quoted_stringContext, *plsql.NumericContext, *plsql.Standard_functionContext:
+			e.Expr = append(e.Expr, newExpression(x))
+		case *plsql.ExpressionsContext, *plsql.Function_argumentContext,
+			*plsql.Function_argument_analyticContext, *plsql.Function_argument_modelingContext:
+			e.arguments(x.GetChildren())
+		case *plsql.ArgumentContext:
+			arg := newExpression(x.Expression())
+			if id := x.Identifier(); id != nil {
+				name := Expression{Kind: ColumnExpr, Value: id.GetText(), Chunk: ctxChunk(id)}
+				arg = Expression{Kind: OperatorExpr, Value: "=>", Expr: Expressions{name, arg}, Chunk: ctxChunk(x)}
+			}
+			e.Expr = append(e.Expr, arg)
+		case antlr.TerminalNode:
+			if x.GetSymbol().GetTokenType() == plsql.PlSqlParserASTERISK {
+				e.Expr = append(e.Expr, newExpression(x))
+			}
+		}
+	}
+}
+
+func isToken(tree antlr.Tree, tokenType int) bool {
+	t, ok := tree.(antlr.TerminalNode)
+	return ok && t.GetSymbol().GetTokenType() == tokenType
+}
+
+func terminalChunk(t antlr.TerminalNode) Chunk {
+	sym := t.GetSymbol()
+	return Chunk{Text: sym.GetText(), Start: sym.GetStart(), Stop: sym.GetStop()}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+// sexpr returns the expression in S-expression form, such as (= (NVL A 0) 1).
+func sexpr(e plsqlparser.Expression) string {
+	if len(e.Expr) == 0 {
+		return e.Value
+	}
+	parts := make([]string, 0, 1+len(e.Expr))
+	if e.Kind != plsqlparser.ListExpr {
+		parts = append(parts, e.Value)
+	}
+	for _, x := range e.Expr {
+		parts = append(parts, sexpr(x))
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func TestParseExpression(t *testing.T) {
+	for _, tc := range []struct {
+		In, Want string
+	}{
+		{"a + b * 2", "(+ A (* B 2))"},
+		{"nvl(t.x, 0) = 1 and not y is null", "(AND (= (NVL T.X 0) 1) (NOT (IS NULL Y)))"},
+		{"x in (1, 2) or z between 1 and :hi", "(OR (IN X (1 2)) (BETWEEN Z 1 :HI))"},
+		{"name not like 'A%' || '_'", "(NOT LIKE NAME (|| 'A%' '_'))"},
+		{"pkg.f(p_a => 1, 'x')", "(PKG.F (=> P_A 1) 'x')"},
+		{"count(*)", "(COUNT *)"},
+		{"-(a - 1)", "(- (- A 1))"},
+	} {
+		e, err := plsqlparser.ParseExpression(tc.In)
+		if err != nil {
+			t.Errorf("%q: %+v", tc.In, err)
+			continue
+		}
+		if got := sexpr(e); got != tc.Want {
+			t.Errorf("%q: got %s, wanted %s", tc.In, got, tc.Want)
+		}
+		if e.Start != 0 || e.Stop != len(tc.In)-1 {
+			t.Errorf("%q: chunk %d-%d", tc.In, e.Start, e.Stop)
+		}
+	}
+	if _, err := plsqlparser.ParseExpression("a +"); err == nil {
+		t.Error("wanted error for a +")
+	}
+}
+
+func TestInsertIntoParse(t *testing.T) {
+	var ii plsqlparser.InsertInto
+	if err := ii.Parse(`INSERT INTO tbl (a, b, c) VALUES (f(x, g(y)), 'z', :1);`); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, len(ii.Values))
+	for i, v := range ii.Values {
+		got[i] = sexpr(v)
+	}
+	if want := "TBL [A B C] [(F X (G Y)) 'z' :1]"; fmt.Sprintf("%s %v %v", ii.Table, ii.Fields, got) != want {
+		t.Errorf("got %s %v %v, wanted %s", ii.Table, ii.Fields, got, want)
+	}
+
+	ii = plsqlparser.InsertInto{}
+	if err := ii.Parse(`INSERT INTO tbl SELECT a.x, (b.y + 1) y FROM tbl2 a, tbl3 b WHERE a.id = b.id`); err != nil {
+		t.Fatal(err)
+	}
+	if !ii.IsSelect || ii.Select == nil || len(ii.Values) != 2 {
+		t.Fatalf("got %+v", ii)
+	}
+	sel := ii.Select
+	if got := fmt.Sprintf("%s %s %v %v %s", sexpr(sel.Fields[0]), sexpr(sel.Fields[1]), sel.Aliases, sel.From, sexpr(*sel.Where)); got != "A.X (+ B.Y 1) [ Y] [{A TBL2} {B TBL3}] (= A.ID B.ID)" {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestParseSelect(t *testing.T) {
+	tokens, err := plsqlparser.Tokenize("a, (b), c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var toks []plsqlparser.Token
+	for _, tok := range tokens {
+		if !tok.Hidden() {
+			toks = append(toks, tok)
+		}
+	}
+	ss, err := plsqlparser.ParseSelect(toks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ss.Fields) != 3 || len(ss.Fields[0].Expr) != 1 || len(ss.Fields[1].Expr) != 1 || len(ss.Fields[1].Expr[0].Expr) != 1 {
+		t.Errorf("got %+v", ss)
+	}
+}
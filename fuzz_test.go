@@ -0,0 +1,113 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+// The regression corpus of the fuzz targets is in testdata/fuzz/FuzzXxx.
+// Run a target with
+//
+//	go test -run '^$' -fuzz '^FuzzLexer$' -fuzztime 1m
+//
+// and minimize the crashers it writes into testdata/fuzz with -run FuzzLexer/NAME.
+//
+// The parse functions do not recover the panics, so every panic is reported as a crasher.
+
+// addSeeds adds the sources of testdata and the statements to the corpus of f.
+func addSeeds(f *testing.F, stmts ...string) {
+	fns, _ := filepath.Glob(filepath.Join("testdata", "*.sql"))
+	for _, fn := range fns {
+		if b, err := os.ReadFile(fn); err == nil {
+			f.Add(string(b))
+		}
+	}
+	for _, s := range stmts {
+		f.Add(s)
+	}
+}
+
+var insertSeeds = []string{
+	"INSERT INTO tbl (a, b, c) VALUES (f(x, g(y)), 'z', :1)",
+	"INSERT INTO tbl SELECT a.x, (b.y + 1) y FROM tbl2 a, tbl3 b WHERE a.id = b.id",
+	"INSERT INTO s.t (a) SELECT CASE WHEN x = 1 THEN 2 END c FROM dual;",
+	"INSERT INTO t (a, b) VALUES (q'[it's]', n'x')",
+}
+
+func FuzzLexer(f *testing.F) {
+	addSeeds(f, "SELECT 'a''b', q'{x}', :1, :\"b\" FROM dual@remote", "@start.sql\nPROMPT hello\n/")
+	f.Fuzz(func(t *testing.T, text string) {
+		lexer := plsqlparser.NewPlSqlStringLexer(strings.ToUpper(text))
+		lexer.RemoveErrorListeners()
+		lexer.GetAllTokens()
+	})
+}
+
+func FuzzTokenizer(f *testing.F) {
+	addSeeds(f, "a.b%TYPE <<lbl>> x := 1.5e3; -- c\n/* d */ REM x")
+	f.Fuzz(func(t *testing.T, text string) {
+		tokens, err := plsqlparser.Tokenize(text)
+		if err != nil {
+			return
+		}
+		var buf strings.Builder
+		for _, tok := range tokens {
+			buf.WriteString(tok.Text)
+		}
+		if buf.String() != text {
+			t.Errorf("the tokens of %q are %q", text, buf.String())
+		}
+	})
+}
+
+func FuzzParseScript(f *testing.F) {
+	addSeeds(f, "BEGIN NULL; END;\n/\nCREATE OR REPLACE PACKAGE p IS PROCEDURE x; END p;\n/")
+	f.Fuzz(func(t *testing.T, text string) {
+		d := plsqlparser.NewDocument(text)
+		for _, e := range d.Errors() {
+			if e.Start < 0 || e.Start > len([]rune(text)) {
+				t.Errorf("%q: error %+v out of the text", text, e)
+			}
+		}
+	})
+}
+
+func FuzzParseToConvertMap(f *testing.F) {
+	addSeeds(f, insertSeeds...)
+	f.Fuzz(func(t *testing.T, text string) {
+		_, _ = plsqlparser.ParseToConvertMap(text)
+	})
+}
+
+func FuzzInsertInto(f *testing.F) {
+	addSeeds(f, insertSeeds...)
+	f.Fuzz(func(t *testing.T, text string) {
+		var ii plsqlparser.InsertInto
+		if err := ii.Parse(text); err != nil {
+			return
+		}
+		n := len([]rune(text))
+		for _, v := range ii.Values {
+			if v.Start < 0 || v.Stop >= n {
+				t.Errorf("%q: value %+v out of the text", text, v)
+			}
+		}
+	})
+}
+
+// FuzzInsertIntoNaive fuzzes InsertInto.ParseNaive, and its gettok.
+func FuzzInsertIntoNaive(f *testing.F) {
+	addSeeds(f, insertSeeds...)
+	f.Fuzz(func(t *testing.T, text string) {
+		var ii plsqlparser.InsertInto
+		_ = ii.ParseNaive(text)
+	})
+}
@@ -0,0 +1,323 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/UNO-SOFT/plsql-parser/internal/g4"
+)
+
+//go:embed PlSqlParser.g4
+var parserGrammar string
+
+// GenerateOptions are the options of a Generator. The zero value is usable.
+type GenerateOptions struct {
+	// Seed of the random source: the same seed generates the same texts.
+	Seed int64
+	// MaxDepth is the depth of rule invocations after which the shortest alternatives are chosen (default 12).
+	MaxDepth int
+	// MaxSize is the number of tokens after which the shortest alternatives are chosen (default 200).
+	// It is a soft limit: the started constructs are still completed.
+	MaxSize int
+	// MaxRepeat is the maximum number of repetitions of a ()* or ()+ (default 3).
+	MaxRepeat int
+	// Weights multiply the weight (1 by default) of the alternatives directly referencing
+	// the named rule or token: 0 excludes them, 10 makes them ten times more likely.
+	Weights map[string]float64
+}
+
+// Generator generates random, syntactically valid PL/SQL from the grammar (PlSqlParser.g4),
+// for fuzzing, round-trip tests and benchmark corpora.
+//
+// The alternatives guarded by a version predicate (isVersion12) are not generated, as the parser
+// does not set the version; the other semantic predicates of the grammar are ignored.
+// The keywords are uppercase.
+type Generator struct {
+	GenerateOptions
+	rnd   *rand.Rand
+	depth int
+	size  int
+	toks  []string
+}
+
+// NewGenerator returns a new Generator.
+func NewGenerator(opts GenerateOptions) *Generator {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 12
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = 200
+	}
+	if opts.MaxRepeat <= 0 {
+		opts.MaxRepeat = 3
+	}
+	return &Generator{GenerateOptions: opts, rnd: rand.New(rand.NewSource(opts.Seed))}
+}
+
+// Generate returns a random text of the rule, such as "unit_statement", "anonymous_block" or "expression".
+func (g *Generator) Generate(rule string) (string, error) {
+	gr := loadGenGrammar()
+	r := gr.rules[rule]
+	if r == nil {
+		return "", fmt.Errorf("%q: %w", rule, ErrUnknownRule)
+	}
+	if gr.minLen[rule] == genInf {
+		return "", fmt.Errorf("%q cannot be generated", rule)
+	}
+	g.depth, g.size, g.toks = 0, 0, g.toks[:0]
+	g.gen(gr, r)
+	return joinTokens(g.toks), nil
+}
+
+// ErrUnknownRule is returned for a rule not in the grammar.
+var ErrUnknownRule = errors.New("unknown rule")
+
+// exhausted reports whether the depth or the size limit is reached.
+func (g *Generator) exhausted() bool {
+	return g.depth >= g.MaxDepth || g.size >= g.MaxSize
+}
+
+func (g *Generator) gen(gr *genGrammar, n *g4.Node) {
+	switch n.Kind {
+	case g4.Token:
+		g.toks = append(g.toks, g.tokenText(gr, n.Name))
+		g.size++
+	case g4.Literal:
+		g.toks = append(g.toks, n.Name)
+		g.size++
+	case g4.Rule:
+		g.depth++
+		g.gen(gr, gr.rules[n.Name])
+		g.depth--
+	case g4.Sequence:
+		for _, it := range n.Items {
+			g.gen(gr, it)
+		}
+	case g4.Choice:
+		g.gen(gr, g.choose(gr, n.Items))
+	case g4.Optional:
+		if !g.exhausted() && g.rnd.Intn(2) == 0 {
+			g.gen(gr, n.Items[0])
+		}
+	case g4.Star, g4.Plus:
+		k := 0
+		if n.Kind == g4.Plus {
+			k = 1
+		}
+		if !g.exhausted() {
+			k += g.rnd.Intn(g.MaxRepeat + 1 - k)
+		}
+		for i := 0; i < k; i++ {
+			g.gen(gr, n.Items[0])
+		}
+	}
+}
+
+// choose an alternative by the weights, or one of the shortest if exhausted.
+func (g *Generator) choose(gr *genGrammar, alts []*g4.Node) *g4.Node {
+	weights := make([]float64, len(alts))
+	var sum float64
+	if !g.exhausted() {
+		for i, alt := range alts {
+			if gr.length(alt) == genInf {
+				continue
+			}
+			weights[i] = g.weight(alt)
+			sum += weights[i]
+		}
+	}
+	if sum == 0 {
+		shortest := genInf
+		for _, alt := range alts {
+			shortest = min(shortest, gr.length(alt))
+		}
+		for i, alt := range alts {
+			if gr.length(alt) == shortest {
+				weights[i] = 1
+				sum++
+			}
+		}
+	}
+	x := g.rnd.Float64() * sum
+	for i, w := range weights {
+		if x -= w; x < 0 && w > 0 {
+			return alts[i]
+		}
+	}
+	for i := len(alts) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return alts[i]
+		}
+	}
+	return alts[0]
+}
+
+// weight of the alternative: the product of the Weights of the rules and tokens it references directly.
+func (g *Generator) weight(n *g4.Node) float64 {
+	if len(g.Weights) == 0 {
+		return 1
+	}
+	switch n.Kind {
+	case g4.Token, g4.Rule:
+		if w, ok := g.Weights[n.Name]; ok {
+			return w
+		}
+		return 1
+	}
+	w := 1.0
+	for _, it := range n.Items {
+		w *= g.weight(it)
+	}
+	return w
+}
+
+func (g *Generator) tokenText(gr *genGrammar, name string) string {
+	switch name {
+	case "REGULAR_ID":
+		return gr.identifiers[g.rnd.Intn(len(gr.identifiers))]
+	case "UNSIGNED_INTEGER":
+		return strconv.Itoa(g.rnd.Intn(1000))
+	case "APPROXIMATE_NUM_LIT":
+		return strconv.Itoa(g.rnd.Intn(100)) + "." + strconv.Itoa(g.rnd.Intn(100))
+	case "BINDVAR":
+		return ":B" + strconv.Itoa(1+g.rnd.Intn(9))
+	case "CHAR_STRING":
+		return []string{"'a'", "'It''s'", "''", "'x y'"}[g.rnd.Intn(4)]
+	}
+	return gr.tokens[name]
+}
+
+// joinTokens joins the tokens with spaces, except around the periods of qualified names.
+func joinTokens(toks []string) string {
+	var buf strings.Builder
+	for i, tok := range toks {
+		if i != 0 && !(tok == "." && !endsWithDigit(toks[i-1]) || toks[i-1] == "." && !startsWithDigit(tok)) {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(tok)
+	}
+	return buf.String()
+}
+func startsWithDigit(s string) bool { return s != "" && '0' <= s[0] && s[0] <= '9' }
+func endsWithDigit(s string) bool   { return s != "" && '0' <= s[len(s)-1] && s[len(s)-1] <= '9' }
+
+// genInf is the length of the unavailable elements.
+const genInf = math.MaxInt32
+
+type genGrammar struct {
+	rules map[string]*g4.Node
+	// tokens are the texts of the tokens, by name.
+	tokens map[string]string
+	// minLen is the minimal number of tokens of a rule.
+	minLen map[string]int
+	// identifiers are the texts of REGULAR_ID: the ones not lexed as keywords.
+	identifiers []string
+}
+
+var (
+	genGrammarOnce sync.Once
+	genGrammarVal  *genGrammar
+)
+
+// loadGenGrammar parses the embedded grammar, once.
+func loadGenGrammar() *genGrammar {
+	genGrammarOnce.Do(func() {
+		gr := genGrammar{rules: make(map[string]*g4.Node), tokens: make(map[string]string)}
+		for _, r := range g4.ReadRules(parserGrammar) {
+			gr.rules[r.Name] = r.Body
+		}
+		lexer := NewPlSqlStringLexer("")
+		for i, name := range lexer.SymbolicNames {
+			if i < len(lexer.LiteralNames) && len(lexer.LiteralNames[i]) > 2 {
+				lit := lexer.LiteralNames[i]
+				gr.tokens[name] = strings.ReplaceAll(lit[1:len(lit)-1], `\'`, "'")
+			}
+		}
+		for name, text := range map[string]string{
+			"NOT_EQUAL_OP": "<>", "DELIMITED_ID": `"Quoted"`, "NATIONAL_CHAR_STRING_LIT": "N'n'",
+			"PERCENT_FOUND": "%FOUND", "PERCENT_ISOPEN": "%ISOPEN", "PERCENT_NOTFOUND": "%NOTFOUND",
+			"PERCENT_ROWCOUNT": "%ROWCOUNT", "PERCENT_ROWTYPE": "%ROWTYPE", "PERCENT_TYPE": "%TYPE",
+			// generated by tokenText
+			"REGULAR_ID": "X", "UNSIGNED_INTEGER": "1", "APPROXIMATE_NUM_LIT": "1.5", "BINDVAR": ":B1", "CHAR_STRING": "'a'",
+		} {
+			gr.tokens[name] = text
+		}
+		for _, id := range []string{"X", "Y", "T1", "EMP", "DEPTNO", "V_NAME", "P_ID", "PKG1", "L_CNT", "TBL"} {
+			if _, isKeyword := gr.tokens[id]; !isKeyword {
+				gr.identifiers = append(gr.identifiers, id)
+			}
+		}
+		gr.computeMinLen()
+		genGrammarVal = &gr
+	})
+	return genGrammarVal
+}
+
+// computeMinLen computes the minimal lengths of the rules, until a fixpoint.
+func (gr *genGrammar) computeMinLen() {
+	gr.minLen = make(map[string]int, len(gr.rules))
+	for name := range gr.rules {
+		gr.minLen[name] = genInf
+	}
+	for changed := true; changed; {
+		changed = false
+		for name, r := range gr.rules {
+			if n := gr.length(r); n < gr.minLen[name] {
+				gr.minLen[name], changed = n, true
+			}
+		}
+	}
+}
+
+// length returns the minimal number of tokens of the node.
+func (gr *genGrammar) length(n *g4.Node) int {
+	switch n.Kind {
+	case g4.Token:
+		if _, ok := gr.tokens[n.Name]; ok {
+			return 1
+		}
+		return genInf
+	case g4.Literal:
+		return 1
+	case g4.Rule:
+		if l, ok := gr.minLen[n.Name]; ok {
+			return l
+		}
+		return genInf
+	case g4.Optional, g4.Star:
+		return 0
+	case g4.Predicate:
+		// the parser does not set the version, so isVersion10 and isVersion12 are false
+		if strings.Contains(n.Name, "isVersion") {
+			return genInf
+		}
+		return 0
+	case g4.Plus:
+		return gr.length(n.Items[0])
+	case g4.Sequence:
+		var sum int
+		for _, it := range n.Items {
+			if sum += gr.length(it); sum >= genInf {
+				return genInf
+			}
+		}
+		return sum
+	case g4.Choice:
+		shortest := genInf
+		for _, it := range n.Items {
+			shortest = min(shortest, gr.length(it))
+		}
+		return shortest
+	}
+	return genInf
+}
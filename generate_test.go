@@ -0,0 +1,89 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+func TestGenerator(t *testing.T) {
+	generate := func(opts plsqlparser.GenerateOptions, rule string, n int) []string {
+		g := plsqlparser.NewGenerator(opts)
+		texts := make([]string, n)
+		for i := range texts {
+			var err error
+			if texts[i], err = g.Generate(rule); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return texts
+	}
+
+	opts := plsqlparser.GenerateOptions{Seed: 42, MaxSize: 50}
+	texts := generate(opts, "unit_statement", 10)
+	again := generate(opts, "unit_statement", 10)
+	for i, text := range texts {
+		if text != again[i] {
+			t.Errorf("%d. not reproducible:\n%s\n%s", i, text, again[i])
+		}
+		for _, msg := range parseErrors(text, func(p *plsql.PlSqlParser) { p.Unit_statement() }) {
+			t.Errorf("%q: %s", text, msg)
+		}
+	}
+	opts.Seed++
+	if other := generate(opts, "unit_statement", 10); other[0] == texts[0] && other[1] == texts[1] {
+		t.Errorf("another seed generated the same texts: %q", other[:2])
+	}
+
+	for _, text := range generate(plsqlparser.GenerateOptions{Weights: map[string]float64{"NOT_EQUAL_OP": 0}}, "relational_operator", 100) {
+		if text == "<>" {
+			t.Error("NOT_EQUAL_OP is generated with 0 weight")
+		}
+	}
+
+	if _, err := plsqlparser.NewGenerator(plsqlparser.GenerateOptions{}).Generate("no_such_rule"); !errors.Is(err, plsqlparser.ErrUnknownRule) {
+		t.Errorf("got %v, wanted ErrUnknownRule", err)
+	}
+}
+
+// parseErrors returns the syntax errors of lexing and parsing the text with the start rule,
+// and an error if the rule does not consume the text.
+func parseErrors(text string, rule func(*plsql.PlSqlParser)) []string {
+	el := &syntaxErrorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	parser := plsqlparser.NewPlSqlLexerParser(text)
+	parser.GetTokenStream().GetTokenSource().(*plsql.PlSqlLexer).RemoveErrorListeners()
+	parser.GetTokenStream().GetTokenSource().(*plsql.PlSqlLexer).AddErrorListener(el)
+	parser.RemoveErrorListeners()
+	parser.AddErrorListener(el)
+	rule(parser)
+	if t := parser.GetCurrentToken(); len(el.msgs) == 0 && t.GetTokenType() != antlr.TokenEOF {
+		el.msgs = append(el.msgs, fmt.Sprintf("%d:%d: unexpected %q", t.GetLine(), t.GetColumn()+1, t.GetText()))
+	}
+	return el.msgs
+}
+
+type syntaxErrorListener struct {
+	*antlr.DefaultErrorListener
+	msgs []string
+}
+
+func (el *syntaxErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	el.msgs = append(el.msgs, fmt.Sprintf("%d:%d: %s", line, column+1, msg))
+}
+
+func BenchmarkGenerator(b *testing.B) {
+	g := plsqlparser.NewGenerator(plsqlparser.GenerateOptions{Seed: 1})
+	for i := 0; i < b.N; i++ {
+		if _, err := g.Generate("unit_statement"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/UNO-SOFT/plsql-parser/internal/g4"
+)
+
+// Rule is a parser rule of an ANTLR4 grammar.
+type Rule struct {
+	Name string
+	// Keywords are the token names referenced by the rule, with the trailing _ removed (NULL_ is NULL).
+	Keywords []string
+	// Refs are the names of the rules referenced by the rule.
+	Refs []string
+	// Body is the EBNF AST of the rule.
+	Body *Node
+}
+
+// Grammar is the parser rules of an ANTLR4 grammar, by name.
+type Grammar map[string]*Rule
+
+// ReadGrammar reads the parser rules of an ANTLR4 grammar (such as PlSqlParser.g4).
+//
+// Comments, actions, predicates and labels are skipped.
+func ReadGrammar(text string) Grammar {
+	g := make(Grammar)
+	for _, r := range g4.ReadRules(text) {
+		rule := &Rule{Name: r.Name, Body: fromG4(r.Body)}
+		if rule.Body == nil {
+			rule.Body = &Node{Kind: Sequence}
+		}
+		r.Body.Walk(func(n *g4.Node) bool {
+			switch n.Kind {
+			case g4.Token:
+				rule.Keywords = appendNew(rule.Keywords, strings.TrimSuffix(n.Name, "_"))
+			case g4.Rule:
+				rule.Refs = appendNew(rule.Refs, n.Name)
+			}
+			return true
+		})
+		g[r.Name] = rule
+	}
+	return g
+}
+
+func appendNew(ss []string, s string) []string {
+	for _, t := range ss {
+		if t == s {
+			return ss
+		}
+	}
+	return append(ss, s)
+}
+
+// Match returns the rule matching the name of an Oracle syntax diagram:
+// the same name, or the name with or without a _clause or _statement suffix.
+func (g Grammar) Match(diagram string) *Rule {
+	name := strings.ToLower(diagram)
+	candidates := []string{name}
+	for _, suffix := range []string{"_clause", "_statement"} {
+		if base := strings.TrimSuffix(name, suffix); base != name {
+			candidates = append(candidates, base)
+		} else {
+			candidates = append(candidates, name+suffix)
+		}
+	}
+	for _, c := range candidates {
+		if r := g[c]; r != nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// Keywords returns the keywords of the rule and the rules it references, up to depth levels deep.
+func (g Grammar) Keywords(rule *Rule, depth int) map[string]bool {
+	kws := make(map[string]bool)
+	seen := make(map[string]bool)
+	var walk func(*Rule, int)
+	walk = func(r *Rule, depth int) {
+		if r == nil || seen[r.Name] {
+			return
+		}
+		seen[r.Name] = true
+		for _, k := range r.Keywords {
+			kws[k] = true
+		}
+		if depth == 0 {
+			return
+		}
+		for _, ref := range r.Refs {
+			walk(g[ref], depth-1)
+		}
+	}
+	walk(rule, depth)
+	return kws
+}
+
+// Description is an Oracle syntax diagram description, as written by the crawler.
+type Description struct {
+	Path, Description string
+}
+
+// Name returns the name of the syntax diagram: the base name of its path, without the extension.
+func (d Description) Name() string {
+	name := path.Base(d.Path)
+	for ext := path.Ext(name); ext != ""; ext = path.Ext(name) {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+// Coverage is the coverage of an Oracle syntax diagram by the grammar.
+type Coverage struct {
+	Diagram, Path string
+	// Rule is the matching grammar rule, empty if there is none.
+	Rule string
+	// MissingKeywords are the keywords of the diagram not found in the rule
+	// (nor in the rules it references).
+	MissingKeywords []string `json:",omitempty"`
+	// MissingOptional are the optional parts of the diagram whose keywords are all missing.
+	MissingOptional []string `json:",omitempty"`
+	// Error is the error of parsing the description.
+	Error string `json:",omitempty"`
+}
+
+// Covered reports whether the diagram has a rule and nothing is missing.
+func (c Coverage) Covered() bool {
+	return c.Rule != "" && len(c.MissingKeywords) == 0 && c.Error == ""
+}
+
+// CoverageReport compares the descriptions to the grammar, looking for the keywords of the
+// diagrams in the matching rules and the rules they reference up to depth levels deep.
+//
+// The descriptions are deduplicated by diagram name, and the report is sorted by it.
+func CoverageReport(g Grammar, descs []Description, depth int) []Coverage {
+	report := make([]Coverage, 0, len(descs))
+	seen := make(map[string]bool, len(descs))
+	for _, d := range descs {
+		c := Coverage{Diagram: d.Name(), Path: d.Path}
+		if seen[c.Diagram] {
+			continue
+		}
+		seen[c.Diagram] = true
+		n, err := ParseDescription(d.Description)
+		if err != nil {
+			c.Error = err.Error()
+		}
+		rule := g.Match(c.Diagram)
+		if rule == nil {
+			report = append(report, c)
+			continue
+		}
+		c.Rule = rule.Name
+		if n == nil {
+			report = append(report, c)
+			continue
+		}
+		have := g.Keywords(rule, depth)
+		for _, k := range n.Keywords() {
+			if !have[k] {
+				c.MissingKeywords = append(c.MissingKeywords, k)
+			}
+		}
+		n.Walk(func(n *Node) bool {
+			if n.Kind != Optional {
+				return true
+			}
+			kws := n.Keywords()
+			for _, k := range kws {
+				if have[k] {
+					return true
+				}
+			}
+			if len(kws) != 0 {
+				c.MissingOptional = append(c.MissingOptional, n.Items[0].String())
+			}
+			return false
+		})
+		report = append(report, c)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Diagram < report[j].Diagram })
+	return report
+}
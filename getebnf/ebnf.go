@@ -0,0 +1,315 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/UNO-SOFT/plsql-parser/internal/g4"
+)
+
+// NodeKind is the kind of an EBNF Node.
+type NodeKind uint8
+
+const (
+	// Sequence of the Items.
+	Sequence = NodeKind(iota)
+	// Choice is one of the Items: { A | B } or A | B.
+	Choice
+	// Optional is the optional Items[0]: [ A ].
+	Optional
+	// Repeat is one or more of Items[0]: A...
+	Repeat
+	// Keyword is an uppercase word of the syntax, such as SELECT.
+	Keyword
+	// Nonterminal is a lowercase name of another diagram, such as expr.
+	Nonterminal
+	// Punct is a literal punctuation, such as ( or ,
+	Punct
+)
+
+// Node is a node of the EBNF AST of an Oracle syntax description.
+type Node struct {
+	Kind NodeKind
+	// Name is the text of a Keyword, Nonterminal or Punct.
+	Name  string
+	Items []*Node
+}
+
+// String returns the W3C-style EBNF of the node: keywords bare, nonterminals in <>,
+// punctuation quoted, (A | B), A?, A+ and A* for [ A ]...
+func (n *Node) String() string {
+	var buf strings.Builder
+	n.write(&buf, false)
+	return buf.String()
+}
+
+func (n *Node) write(buf *strings.Builder, nested bool) {
+	switch n.Kind {
+	case Keyword:
+		buf.WriteString(n.Name)
+	case Nonterminal:
+		buf.WriteString("<" + n.Name + ">")
+	case Punct:
+		buf.WriteString(`"` + n.Name + `"`)
+	case Optional, Repeat:
+		item, suffix := n.Items[0], byte('?')
+		if n.Kind == Repeat {
+			if suffix = '+'; item.Kind == Optional {
+				item, suffix = item.Items[0], '*'
+			}
+		}
+		if item.Kind == Sequence && len(item.Items) > 1 || item.Kind == Choice {
+			buf.WriteByte('(')
+			item.write(buf, false)
+			buf.WriteByte(')')
+		} else {
+			item.write(buf, true)
+		}
+		buf.WriteByte(suffix)
+	case Sequence, Choice:
+		sep := " "
+		if n.Kind == Choice {
+			sep = " | "
+		}
+		if nested && len(n.Items) > 1 {
+			buf.WriteByte('(')
+		}
+		for i, it := range n.Items {
+			if i != 0 {
+				buf.WriteString(sep)
+			}
+			it.write(buf, n.Kind == Sequence)
+		}
+		if nested && len(n.Items) > 1 {
+			buf.WriteByte(')')
+		}
+	}
+}
+
+// Walk calls f for the node and its descendants, depth-first, while f returns true.
+func (n *Node) Walk(f func(*Node) bool) {
+	if !f(n) {
+		return
+	}
+	for _, it := range n.Items {
+		it.Walk(f)
+	}
+}
+
+// Keywords returns the distinct keywords of the node, in order of appearance.
+func (n *Node) Keywords() []string {
+	var kws []string
+	seen := make(map[string]bool)
+	n.Walk(func(n *Node) bool {
+		if n.Kind == Keyword && !seen[n.Name] {
+			seen[n.Name] = true
+			kws = append(kws, n.Name)
+		}
+		return true
+	})
+	return kws
+}
+
+// ParseDescription parses the text of an Oracle syntax diagram description,
+// such as "ACCESSIBLE BY ( accessor [, accessor ]... )", into an EBNF AST.
+//
+// [ ] is optional, { } is a required group, | separates the alternatives,
+// ... repeats the preceding item; ( ) and the other punctuation are literal.
+func ParseDescription(text string) (*Node, error) {
+	p := descParser{tokens: descTokens(text)}
+	n := p.alternatives()
+	if p.pos < len(p.tokens) {
+		return n, fmt.Errorf("unexpected %q at token %d of %q", p.tokens[p.pos], p.pos, text)
+	}
+	if p.err != nil {
+		return n, fmt.Errorf("%q: %w", text, p.err)
+	}
+	return n, nil
+}
+
+// descTokens splits the description into words, punctuation, '...' quoted literals and ellipses.
+func descTokens(text string) []string {
+	var tokens []string
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isWordRune(r):
+			j := i
+			for j < len(rs) && (isWordRune(rs[j]) || rs[j] == '-' && j+1 < len(rs) && isWordRune(rs[j+1]) && j > i) {
+				j++
+			}
+			tokens = append(tokens, string(rs[i:j]))
+			i = j
+		case r == '.' && i+2 < len(rs) && rs[i+1] == '.' && rs[i+2] == '.':
+			tokens = append(tokens, "...")
+			i += 3
+		case r == '\'':
+			j := i + 1
+			for j < len(rs) && rs[j] != '\'' {
+				j++
+			}
+			if j < len(rs) {
+				j++
+			}
+			tokens = append(tokens, string(rs[i:j]))
+			i = j
+		case r == ':' && i+1 < len(rs) && rs[i+1] == '=',
+			r == '=' && i+1 < len(rs) && rs[i+1] == '>',
+			r == '|' && i+1 < len(rs) && rs[i+1] == '|':
+			tokens = append(tokens, string(rs[i:i+2]))
+			i += 2
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$' || r == '#'
+}
+
+type descParser struct {
+	tokens []string
+	pos    int
+	err    error
+}
+
+func (p *descParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// alternatives: sequence ('|' sequence)*
+func (p *descParser) alternatives() *Node {
+	n := p.sequence()
+	if p.peek() != "|" {
+		return n
+	}
+	choice := &Node{Kind: Choice, Items: []*Node{n}}
+	for p.peek() == "|" {
+		p.pos++
+		choice.Items = append(choice.Items, p.sequence())
+	}
+	return choice
+}
+
+// sequence: (primary '...'?)*
+func (p *descParser) sequence() *Node {
+	seq := &Node{Kind: Sequence}
+	for {
+		switch p.peek() {
+		case "", "|", "]", "}":
+			if len(seq.Items) == 1 {
+				return seq.Items[0]
+			}
+			return seq
+		case "...":
+			p.pos++
+			if len(seq.Items) == 0 {
+				p.err = fmt.Errorf("... at token %d repeats nothing", p.pos-1)
+				continue
+			}
+			last := len(seq.Items) - 1
+			seq.Items[last] = &Node{Kind: Repeat, Items: []*Node{seq.Items[last]}}
+			continue
+		}
+		seq.Items = append(seq.Items, p.primary())
+	}
+}
+
+// primary: '[' alternatives ']' | '{' alternatives '}' | word | punct
+func (p *descParser) primary() *Node {
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch tok {
+	case "[", "{":
+		closing := "]"
+		if tok == "{" {
+			closing = "}"
+		}
+		n := p.alternatives()
+		if p.peek() != closing {
+			p.err = fmt.Errorf("missing %s for %s", closing, tok)
+		} else {
+			p.pos++
+		}
+		if tok == "[" {
+			return &Node{Kind: Optional, Items: []*Node{n}}
+		}
+		return n
+	}
+	r := []rune(tok)[0]
+	switch {
+	case r == '\'':
+		return &Node{Kind: Punct, Name: strings.Trim(tok, "'")}
+	case !isWordRune(r):
+		return &Node{Kind: Punct, Name: tok}
+	case strings.ToUpper(tok) == tok && !unicode.IsDigit(r):
+		return &Node{Kind: Keyword, Name: tok}
+	case unicode.IsDigit(r):
+		return &Node{Kind: Punct, Name: tok}
+	}
+	return &Node{Kind: Nonterminal, Name: tok}
+}
+
+// fromG4 converts the AST of a grammar rule body into an EBNF AST.
+//
+// x? is Optional, x+ is Repeat, x* is Repeat of Optional (as [ x ]... of the descriptions),
+// token names are Keywords (with the trailing _ removed), rule names are Nonterminals,
+// literals, ~x and . are Punct. The predicates are dropped (nil).
+func fromG4(n *g4.Node) *Node {
+	switch n.Kind {
+	case g4.Sequence, g4.Choice:
+		kind := Sequence
+		if n.Kind == g4.Choice {
+			kind = Choice
+		}
+		items := make([]*Node, 0, len(n.Items))
+		for _, it := range n.Items {
+			if it := fromG4(it); it != nil {
+				items = append(items, it)
+			}
+		}
+		if len(items) == 1 && kind == Sequence {
+			return items[0]
+		}
+		return &Node{Kind: kind, Items: items}
+	case g4.Optional, g4.Star, g4.Plus:
+		item := fromG4(n.Items[0])
+		if item == nil {
+			return nil
+		}
+		switch n.Kind {
+		case g4.Optional:
+			return &Node{Kind: Optional, Items: []*Node{item}}
+		case g4.Star:
+			item = &Node{Kind: Optional, Items: []*Node{item}}
+		}
+		return &Node{Kind: Repeat, Items: []*Node{item}}
+	case g4.Token:
+		return &Node{Kind: Keyword, Name: strings.TrimSuffix(n.Name, "_")}
+	case g4.Rule:
+		return &Node{Kind: Nonterminal, Name: n.Name}
+	case g4.Literal:
+		return &Node{Kind: Punct, Name: n.Name}
+	case g4.Not:
+		if item := fromG4(n.Items[0]); item != nil {
+			return &Node{Kind: Punct, Name: "~" + item.String()}
+		}
+	case g4.Wildcard:
+		return &Node{Kind: Punct, Name: "."}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDescription(t *testing.T) {
+	for _, tc := range []struct {
+		In, Want string
+	}{
+		{"ACCESSIBLE BY ( accessor [, accessor ]... )", `ACCESSIBLE BY "(" <accessor> ("," <accessor>)* ")"`},
+		{"{ ENABLE | DISABLE } ROW MOVEMENT", "(ENABLE | DISABLE) ROW MOVEMENT"},
+		{"DROP TABLE [ schema. ] table [ CASCADE CONSTRAINTS ] [ PURGE ] ;", `DROP TABLE (<schema> ".")? <table> (CASCADE CONSTRAINTS)? PURGE? ";"`},
+		{"x := expr", `<x> ":=" <expr>`},
+	} {
+		n, err := ParseDescription(tc.In)
+		if err != nil {
+			t.Errorf("%q: %+v", tc.In, err)
+			continue
+		}
+		if got := n.String(); got != tc.Want {
+			t.Errorf("%q:\ngot  %s\nwant %s", tc.In, got, tc.Want)
+		}
+	}
+	for _, in := range []string{"[ a", "a ]", "{ a | b"} {
+		if _, err := ParseDescription(in); err == nil {
+			t.Errorf("%q: wanted error", in)
+		}
+	}
+}
+
+func TestCoverageReport(t *testing.T) {
+	const grammar = `parser grammar X;
+options { tokenVocab=XLexer; }
+@parser::postinclude {
+#include <X.h>
+}
+
+drop_table // comment
+    : DROP TABLE tableview_name (PURGE)? ';'
+    ;
+
+tableview_name
+    : id=identifier ('.' id_expression)? #label
+    | {self.isVersion12()}? NULL_
+    ;
+`
+	g := ReadGrammar(grammar)
+	if got := g["tableview_name"]; got == nil || !reflect.DeepEqual(got.Keywords, []string{"NULL"}) || !reflect.DeepEqual(got.Refs, []string{"identifier", "id_expression"}) {
+		t.Fatalf("got %+v", got)
+	}
+	report := CoverageReport(g, []Description{
+		{Path: "/lnpls/img_text/drop_table.html", Description: "DROP TABLE [ schema. ] table [ CASCADE CONSTRAINTS ] [ PURGE ] ;"},
+		{Path: "/sqlrf/img_text/drop_table.html", Description: "DROP TABLE table"},
+		{Path: "/sqlrf/img_text/accessible_by_clause.html", Description: "ACCESSIBLE BY ( accessor [, accessor ]... )"},
+	}, 2)
+	want := []Coverage{
+		{Diagram: "accessible_by_clause", Path: "/sqlrf/img_text/accessible_by_clause.html"},
+		{Diagram: "drop_table", Path: "/lnpls/img_text/drop_table.html", Rule: "drop_table",
+			MissingKeywords: []string{"CASCADE", "CONSTRAINTS"}, MissingOptional: []string{"CASCADE CONSTRAINTS"}},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("got\n%+v\nwant\n%+v", report, want)
+	}
+}
+
+func TestDecodeDescriptions(t *testing.T) {
+	descs, err := decodeDescriptions(strings.NewReader(`{"Path":"/a/b.html","Description":"A"}
+{"Path":"/a/c.eps.html","Description":"B"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(descs) != 2 || descs[0].Name() != "b" || descs[1].Name() != "c" {
+		t.Errorf("got %+v", descs)
+	}
+}
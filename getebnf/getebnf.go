@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -14,6 +15,7 @@ import (
 	"github.com/PuerkitoBio/fetchbot"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
 )
 
 var (
@@ -34,10 +36,32 @@ func main() {
 }
 
 func Main() error {
-	flag.Parse()
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `Usage of %[1]s:
+	%[1]s [-seed URLs] > descriptions.json
+		crawl the Oracle documentation for the syntax diagram descriptions.
+	%[1]s offline DIR|FILE.zip... > descriptions.json
+		read the descriptions from a saved mirror of the documentation, sorted by path.
+	%[1]s ebnf [descriptions.json...]
+		print the descriptions as EBNF.
+	%[1]s coverage [-grammar PlSqlParser.g4] [-depth 2] [-format text|json] [descriptions.json...]
+		report the diagrams not covered by the grammar.
+	%[1]s railroad [-grammar PlSqlParser.g4] [-o DIR] [descriptions.json...]
+		write the railroad diagrams of the grammar rules and the descriptions as HTML pages.
 
-	type description struct {
-		Path, Description string
+`, os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	switch flag.Arg(0) {
+	case "offline":
+		return offlineMain(flag.Args()[1:])
+	case "ebnf":
+		return ebnfMain(flag.Args()[1:])
+	case "coverage":
+		return coverageMain(flag.Args()[1:])
+	case "railroad":
+		return railroadMain(flag.Args()[1:])
 	}
 
 	// Create the muxer
@@ -63,7 +87,7 @@ func Main() error {
 		hosts = append(hosts, u.Host)
 	}
 
-	enc := json.NewEncoder(os.Stdout)
+	enc := jsontext.NewEncoder(os.Stdout)
 
 	for _, u := range us {
 		// Handle GET requests for html responses, to parse the body and enqueue all links as HEAD
@@ -81,29 +105,12 @@ func Main() error {
 					log.Printf("[ERR] %s %s - %s\n", ctx.Cmd.Method(), ctx.Cmd.URL(), err)
 					return
 				}
-				/*
-					<body>
-					      <article>
-						           <header>
-								               <h1>Description of the illustration accessible_by_clause.eps</h1>
-											            </header>
-														         <div><pre
-				*/
-				doc.Find("body>article").Each(func(i int, s *goquery.Selection) {
-					if strings.HasPrefix(s.Find("header>h1").Text(), "Description ") {
-						desc := description{
-							Path:        ctx.Cmd.URL().Path,
-							Description: s.Find("div>pre").Text(),
-						}
-						if desc.Description == "" {
-							return
-						}
-						if err := enc.Encode(desc); err != nil {
-							log.Println("ERROR:", err)
-							_ = q.Cancel()
-						}
+				for _, desc := range findDescriptions(doc, ctx.Cmd.URL().Path) {
+					if err := json.MarshalEncode(enc, desc); err != nil {
+						log.Println("ERROR:", err)
+						_ = q.Cancel()
 					}
-				})
+				}
 				// Enqueue all links as HEAD requests
 				log.Println("enqueue", ctx.Cmd.URL())
 				enqueueLinks(ctx, hosts, doc)
@@ -132,6 +139,103 @@ func Main() error {
 	return q.Close()
 }
 
+func ebnfMain(args []string) error {
+	descs, err := readDescriptions(args)
+	if err != nil {
+		return err
+	}
+	for _, d := range descs {
+		n, err := ParseDescription(d.Description)
+		if err != nil {
+			log.Printf("%s: %v", d.Path, err)
+			continue
+		}
+		fmt.Printf("%s ::= %s\n", d.Name(), n)
+	}
+	return nil
+}
+
+func coverageMain(args []string) error {
+	fs := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	flagGrammar := fs.String("grammar", "PlSqlParser.g4", "ANTLR4 parser grammar")
+	flagDepth := fs.Int("depth", 2, "depth of the referenced rules to look for the keywords in")
+	flagFormat := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	b, err := os.ReadFile(*flagGrammar)
+	if err != nil {
+		return err
+	}
+	descs, err := readDescriptions(fs.Args())
+	if err != nil {
+		return err
+	}
+	report := CoverageReport(ReadGrammar(string(b)), descs, *flagDepth)
+	if *flagFormat == "json" {
+		return json.MarshalWrite(os.Stdout, report, jsontext.WithIndent("  "))
+	}
+	var covered int
+	for _, c := range report {
+		switch {
+		case c.Covered():
+			covered++
+		case c.Rule == "":
+			fmt.Printf("%s: no rule\n", c.Diagram)
+		default:
+			fmt.Printf("%s: rule %s", c.Diagram, c.Rule)
+			if len(c.MissingKeywords) != 0 {
+				fmt.Printf(" missing keywords %s", strings.Join(c.MissingKeywords, " "))
+			}
+			if c.Error != "" {
+				fmt.Printf(" error %s", c.Error)
+			}
+			fmt.Println()
+			for _, o := range c.MissingOptional {
+				fmt.Printf("\tmissing [ %s ]\n", o)
+			}
+		}
+	}
+	fmt.Printf("%d of %d diagrams covered\n", covered, len(report))
+	return nil
+}
+
+// readDescriptions reads the JSON stream of Descriptions from the files, or from stdin if there are none.
+func readDescriptions(files []string) ([]Description, error) {
+	if len(files) == 0 {
+		return decodeDescriptions(os.Stdin)
+	}
+	var descs []Description
+	for _, fn := range files {
+		fh, err := os.Open(fn)
+		if err != nil {
+			return descs, err
+		}
+		ds, err := decodeDescriptions(fh)
+		fh.Close()
+		if err != nil {
+			return descs, fmt.Errorf("%s: %w", fn, err)
+		}
+		descs = append(descs, ds...)
+	}
+	return descs, nil
+}
+
+func decodeDescriptions(r io.Reader) ([]Description, error) {
+	var descs []Description
+	dec := jsontext.NewDecoder(r)
+	for {
+		var d Description
+		if err := json.UnmarshalDecode(dec, &d); err != nil {
+			if err == io.EOF {
+				return descs, nil
+			}
+			return descs, err
+		}
+		descs = append(descs, d)
+	}
+}
+
 func enqueueLinks(ctx *fetchbot.Context, matchHosts []string, doc *goquery.Document) {
 	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
 		val, _ := s.Attr("href")
@@ -0,0 +1,120 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// findDescriptions returns the syntax diagram descriptions of the page at path:
+//
+//	<body>
+//	  <article>
+//	    <header>
+//	      <h1>Description of the illustration accessible_by_clause.eps</h1>
+//	    </header>
+//	    <div><pre>ACCESSIBLE BY ( accessor [, accessor ]... )</pre></div>
+func findDescriptions(doc *goquery.Document, path string) []Description {
+	var descs []Description
+	doc.Find("body>article").Each(func(i int, s *goquery.Selection) {
+		if !strings.HasPrefix(s.Find("header>h1").Text(), "Description ") {
+			return
+		}
+		if desc := (Description{Path: path, Description: s.Find("div>pre").Text()}); desc.Description != "" {
+			descs = append(descs, desc)
+		}
+	})
+	return descs
+}
+
+// ReadMirror reads the descriptions from the .html and .htm pages of a saved mirror
+// of the documentation (a directory or a zip file, as an fs.FS).
+//
+// The Path of a description is the slash-separated path of its page in the mirror, with a leading /.
+// The descriptions are sorted by Path, then Description.
+func ReadMirror(fsys fs.FS) ([]Description, error) {
+	var descs []Description
+	err := fs.WalkDir(fsys, ".", func(fn string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if ext := strings.ToLower(path.Ext(fn)); ext != ".html" && ext != ".htm" {
+			return nil
+		}
+		fh, err := fsys.Open(fn)
+		if err != nil {
+			return err
+		}
+		doc, err := goquery.NewDocumentFromReader(fh)
+		fh.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", fn, err)
+		}
+		descs = append(descs, findDescriptions(doc, "/"+fn)...)
+		return nil
+	})
+	sortDescriptions(descs)
+	return descs, err
+}
+
+// sortDescriptions sorts the descriptions by Path, then Description.
+func sortDescriptions(descs []Description) {
+	sort.Slice(descs, func(i, j int) bool {
+		if descs[i].Path == descs[j].Path {
+			return descs[i].Description < descs[j].Description
+		}
+		return descs[i].Path < descs[j].Path
+	})
+}
+
+// offlineMain writes the descriptions of the mirrors (directories or zip files), sorted together, to stdout,
+// in the same JSON stream format as the crawler.
+func offlineMain(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("offline needs a directory or a zip file")
+	}
+	var descs []Description
+	for _, fn := range args {
+		ds, err := readMirrorFile(fn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fn, err)
+		}
+		descs = append(descs, ds...)
+	}
+	sortDescriptions(descs)
+	enc := jsontext.NewEncoder(os.Stdout)
+	for _, desc := range descs {
+		if err := json.MarshalEncode(enc, desc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readMirrorFile(fn string) ([]Description, error) {
+	fi, err := os.Stat(fn)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return ReadMirror(os.DirFS(fn))
+	}
+	zr, err := zip.OpenReader(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return ReadMirror(zr)
+}
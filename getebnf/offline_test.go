@@ -0,0 +1,52 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadMirror(t *testing.T) {
+	b, err := os.ReadFile("testdata/mirror.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := decodeDescriptions(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := os.DirFS("testdata/mirror")
+	got, err := ReadMirror(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dir: got\n%+v\nwant\n%+v", got, want)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := zw.AddFS(fsys); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err = ReadMirror(zr); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("zip: got\n%+v\nwant\n%+v", got, want)
+	}
+}
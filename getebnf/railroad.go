@@ -0,0 +1,360 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Sizes of the railroad diagram, in pixels.
+const (
+	rrCharWidth = 8  // of a character of the 13px monospace font
+	rrBoxPad    = 10 // horizontal padding of the text in a box
+	rrBoxHalf   = 11 // half height of a box
+	rrGap       = 10 // between the items of a sequence, and vertically between the branches
+	rrArc       = 10 // radius of the turns
+	rrEdge      = 20 // width of the start and end of the diagram
+)
+
+// rrLayout is the size of a Node in a railroad diagram: its width,
+// and its height above (up) and below (down) the baseline it enters and exits on.
+type rrLayout struct {
+	*Node
+	w, up, down int
+	items       []*rrLayout
+}
+
+func layout(n *Node) *rrLayout {
+	l := &rrLayout{Node: n}
+	switch n.Kind {
+	case Keyword, Nonterminal, Punct:
+		l.w, l.up, l.down = len([]rune(n.label()))*rrCharWidth+2*rrBoxPad, rrBoxHalf, rrBoxHalf
+	case Sequence:
+		for i, it := range n.Items {
+			il := layout(it)
+			l.items = append(l.items, il)
+			if i != 0 {
+				l.w += rrGap
+			}
+			l.w += il.w
+			l.up, l.down = max(l.up, il.up), max(l.down, il.down)
+		}
+	case Choice, Optional:
+		items := n.Items
+		if n.Kind == Optional {
+			// the bypass on the baseline, the item below it
+			items = []*Node{{Kind: Sequence}, n.Items[0]}
+		}
+		for i, it := range items {
+			il := layout(it)
+			l.items = append(l.items, il)
+			l.w = max(l.w, il.w)
+			if i == 0 {
+				l.up, l.down = il.up, il.down
+			} else {
+				l.down += max(rrGap+il.up, 2*rrArc) + il.down
+			}
+		}
+		l.w += 4 * rrArc
+	case Repeat:
+		il := layout(n.Items[0])
+		l.items = []*rrLayout{il}
+		l.w, l.up, l.down = il.w+4*rrArc, il.up, max(il.down+rrGap, 2*rrArc)
+	}
+	return l
+}
+
+// label is the text of a Keyword, Nonterminal or Punct box.
+func (n *Node) label() string {
+	if n.Kind == Punct {
+		return "'" + n.Name + "'"
+	}
+	return n.Name
+}
+
+// rrWriter writes the SVG elements of a railroad diagram.
+type rrWriter struct {
+	strings.Builder
+	// href returns the link of a Nonterminal, or the empty string.
+	href func(name string) string
+}
+
+func (w *rrWriter) line(x1, y1, x2, y2 int) {
+	if x1 != x2 || y1 != y2 {
+		fmt.Fprintf(w, `<path d="M%d %dL%d %d"/>`, x1, y1, x2, y2)
+	}
+}
+
+// turn draws a quarter circle from (x1, y1) to (x2, y2), horizontal at the end, or at the start if !horizontalEnd.
+func (w *rrWriter) turn(x1, y1, x2, y2 int, horizontalEnd bool) {
+	cx, cy := x1, y2
+	if !horizontalEnd {
+		cx, cy = x2, y1
+	}
+	fmt.Fprintf(w, `<path d="M%d %dQ%d %d %d %d"/>`, x1, y1, cx, cy, x2, y2)
+}
+
+// draw the node from (x, y) to (x+l.w, y).
+func (w *rrWriter) draw(l *rrLayout, x, y int) {
+	switch l.Kind {
+	case Keyword, Nonterminal, Punct:
+		class, text := "terminal", html.EscapeString(l.label())
+		rx := rrBoxHalf
+		if l.Kind == Nonterminal {
+			class, rx = "nonterminal", 0
+		}
+		fmt.Fprintf(w, `<g class="%s">`, class)
+		href := ""
+		if l.Kind == Nonterminal && w.href != nil {
+			href = w.href(l.Name)
+		}
+		if href != "" {
+			fmt.Fprintf(w, `<a href="%s">`, html.EscapeString(href))
+		}
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" rx="%d"/><text x="%d" y="%d">%s</text>`,
+			x, y-rrBoxHalf, l.w, 2*rrBoxHalf, rx, x+l.w/2, y+4, text)
+		if href != "" {
+			w.WriteString(`</a>`)
+		}
+		w.WriteString(`</g>`)
+	case Sequence:
+		for i, il := range l.items {
+			if i != 0 {
+				w.line(x, y, x+rrGap, y)
+				x += rrGap
+			}
+			w.draw(il, x, y)
+			x += il.w
+		}
+	case Choice, Optional:
+		left, right := x+2*rrArc, x+l.w-2*rrArc
+		by := y
+		for i, il := range l.items {
+			if i != 0 {
+				by += max(rrGap+il.up, 2*rrArc)
+				w.turn(x, y, x+rrArc, y+rrArc, false)
+				w.line(x+rrArc, y+rrArc, x+rrArc, by-rrArc)
+				w.turn(x+rrArc, by-rrArc, left, by, true)
+				w.turn(right, by, x+l.w-rrArc, by-rrArc, false)
+				w.line(x+l.w-rrArc, by-rrArc, x+l.w-rrArc, y+rrArc)
+				w.turn(x+l.w-rrArc, y+rrArc, x+l.w, y, true)
+			} else {
+				w.line(x, y, left, y)
+				w.line(right, y, x+l.w, y)
+			}
+			w.draw(il, left, by)
+			w.line(left+il.w, by, right, by)
+			by += il.down
+		}
+	case Repeat:
+		il := l.items[0]
+		left, right, by := x+2*rrArc, x+l.w-2*rrArc, y+l.down
+		w.line(x, y, left, y)
+		w.draw(il, left, y)
+		w.line(right, y, x+l.w, y)
+		// the loop back below the item
+		w.turn(right, y, right+rrArc, y+rrArc, false)
+		w.line(right+rrArc, y+rrArc, right+rrArc, by-rrArc)
+		w.turn(right+rrArc, by-rrArc, right, by, true)
+		w.line(right, by, left, by)
+		w.turn(left, by, left-rrArc, by-rrArc, false)
+		w.line(left-rrArc, by-rrArc, left-rrArc, y+rrArc)
+		w.turn(left-rrArc, y+rrArc, left, y, true)
+	}
+}
+
+// RailroadSVG returns the railroad diagram of the node as an SVG element.
+// href returns the link of a Nonterminal (empty for no link), and may be nil.
+func RailroadSVG(n *Node, href func(name string) string) string {
+	l := layout(n)
+	width, height := l.w+2*rrEdge, l.up+l.down+2*rrGap
+	y := l.up + rrGap
+	w := rrWriter{href: href}
+	fmt.Fprintf(&w, `<svg class="railroad" xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		width, height, width, height)
+	// the start and the end marks
+	fmt.Fprintf(&w, `<path d="M%d %dv%d M%d %dv%d"/>`, 1, y-rrArc/2, rrArc, width-1, y-rrArc/2, rrArc)
+	w.line(1, y, rrEdge, y)
+	w.draw(l, rrEdge, y)
+	w.line(rrEdge+l.w, y, width-1, y)
+	w.WriteString(`</svg>`)
+	return w.String()
+}
+
+// WriteRailroadSite writes a static HTML site of the railroad diagrams of the grammar rules
+// and of the descriptions into dir: an index.html, a rule-NAME.html for each rule
+// (with the matching Oracle diagrams beside the rule's), and a doc-NAME.html for each diagram.
+//
+// The nonterminals link to the rule pages from the rule diagrams,
+// and to the diagram pages (or to the matching rule) from the Oracle diagrams.
+func WriteRailroadSite(dir string, g Grammar, descs []Description) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	type diagram struct {
+		Name, Path, Text, Rule string
+		Node                   *Node
+		Err                    error
+	}
+	diagrams := make(map[string]*diagram, len(descs))
+	docsOf := make(map[string][]*diagram)
+	for _, d := range descs {
+		name := d.Name()
+		if diagrams[name] != nil {
+			continue
+		}
+		dg := &diagram{Name: name, Path: d.Path, Text: d.Description}
+		dg.Node, dg.Err = ParseDescription(d.Description)
+		if r := g.Match(name); r != nil {
+			dg.Rule = r.Name
+			docsOf[r.Name] = append(docsOf[r.Name], dg)
+		}
+		diagrams[name] = dg
+	}
+
+	ruleHref := func(name string) string {
+		if g[name] == nil {
+			return ""
+		}
+		return "rule-" + name + ".html"
+	}
+	docHref := func(name string) string {
+		if diagrams[name] != nil {
+			return "doc-" + name + ".html"
+		}
+		if r := g.Match(name); r != nil {
+			return ruleHref(r.Name)
+		}
+		return ""
+	}
+	type section struct {
+		Title, Href, EBNF string
+		SVG               template.HTML
+		Err               error
+	}
+	type page struct {
+		Title    string
+		Sections []section
+		Links    []section
+	}
+	docSection := func(dg *diagram) section {
+		s := section{Title: "Oracle: " + dg.Name, Href: docHref(dg.Name), EBNF: dg.Text, Err: dg.Err}
+		if dg.Node != nil {
+			s.SVG = template.HTML(RailroadSVG(dg.Node, docHref))
+		}
+		return s
+	}
+	write := func(fn string, p page) error {
+		fh, err := os.Create(filepath.Join(dir, fn))
+		if err != nil {
+			return err
+		}
+		err = railroadTemplate.Execute(fh, p)
+		if closeErr := fh.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		return err
+	}
+
+	ruleNames := make([]string, 0, len(g))
+	for name := range g {
+		ruleNames = append(ruleNames, name)
+	}
+	sort.Strings(ruleNames)
+	var index page
+	index.Title = "PL/SQL grammar"
+	for _, name := range ruleNames {
+		r := g[name]
+		p := page{Title: name}
+		if r.Body != nil {
+			p.Sections = append(p.Sections, section{Title: "Grammar: " + name, EBNF: r.Body.String(),
+				SVG: template.HTML(RailroadSVG(r.Body, ruleHref))})
+		}
+		for _, dg := range docsOf[name] {
+			p.Sections = append(p.Sections, docSection(dg))
+		}
+		if err := write(ruleHref(name), p); err != nil {
+			return err
+		}
+		index.Links = append(index.Links, section{Title: name, Href: ruleHref(name)})
+	}
+
+	docNames := make([]string, 0, len(diagrams))
+	for name := range diagrams {
+		docNames = append(docNames, name)
+	}
+	sort.Strings(docNames)
+	for _, name := range docNames {
+		dg := diagrams[name]
+		p := page{Title: name, Sections: []section{docSection(dg)}}
+		p.Sections[0].Href = ""
+		if dg.Rule != "" {
+			p.Links = append(p.Links, section{Title: "Grammar: " + dg.Rule, Href: ruleHref(dg.Rule)})
+		}
+		if err := write(docHref(name), p); err != nil {
+			return err
+		}
+		title := "Oracle: " + name
+		if dg.Rule == "" {
+			title += " (no rule)"
+		}
+		index.Links = append(index.Links, section{Title: title, Href: docHref(name)})
+	}
+	return write("index.html", index)
+}
+
+var railroadTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en"><head><meta charset="UTF-8"><title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+.sections { display: flex; flex-wrap: wrap; gap: 2em; align-items: flex-start; }
+.railroad path { fill: none; stroke: #333; stroke-width: 1.5; }
+.railroad rect { fill: #ffc; stroke: #333; stroke-width: 1.5; }
+.railroad .nonterminal rect { fill: #cef; }
+.railroad text { font: 13px monospace; text-anchor: middle; }
+.railroad a text { text-decoration: underline; }
+pre { white-space: pre-wrap; max-width: 60em; }
+</style></head>
+<body><h1>{{.Title}}</h1>
+<p><a href="index.html">index</a></p>
+<div class="sections">{{range .Sections}}
+<section><h2>{{if .Href}}<a href="{{.Href}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</h2>
+{{.SVG}}
+<pre>{{.EBNF}}</pre>{{with .Err}}
+<p class="error">{{.}}</p>{{end}}
+</section>{{end}}
+</div>{{if .Links}}
+<ul>{{range .Links}}
+<li><a href="{{.Href}}">{{.Title}}</a></li>{{end}}
+</ul>{{end}}
+</body></html>
+`))
+
+// railroadMain writes the railroad diagrams of the grammar and the descriptions
+// (read from the files, or from stdin if there are none) into a static HTML site.
+func railroadMain(args []string) error {
+	fs := flag.NewFlagSet("railroad", flag.ContinueOnError)
+	flagGrammar := fs.String("grammar", "PlSqlParser.g4", "ANTLR4 parser grammar")
+	flagOut := fs.String("o", "railroad", "output directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	b, err := os.ReadFile(*flagGrammar)
+	if err != nil {
+		return err
+	}
+	descs, err := readDescriptions(fs.Args())
+	if err != nil {
+		return err
+	}
+	return WriteRailroadSite(*flagOut, ReadGrammar(string(b)), descs)
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRailroadSVG(t *testing.T) {
+	n, err := ParseDescription("{ ENABLE | DISABLE } [ NOVALIDATE ] constraint [, constraint ]... <x>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svg := RailroadSVG(n, func(name string) string { return name + ".html" })
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := dec.Token(); err != nil {
+			if err != io.EOF {
+				t.Fatalf("%v: %s", err, svg)
+			}
+			break
+		}
+	}
+	for _, want := range []string{">ENABLE<", ">DISABLE<", `<a href="constraint.html">`, ">&#39;&lt;&#39;<"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("no %q in %s", want, svg)
+		}
+	}
+}
+
+func TestWriteRailroadSite(t *testing.T) {
+	g := ReadGrammar(`parser grammar X;
+drop_table : DROP TABLE tableview_name PURGE? ';' ;
+tableview_name : (schema_name '.')? id_expression ;
+`)
+	dir := t.TempDir()
+	if err := WriteRailroadSite(dir, g, []Description{
+		{Path: "/sqlrf/img_text/drop_table.html", Description: "DROP TABLE [ schema. ] table [ PURGE ] ;"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for fn, wants := range map[string][]string{
+		"index.html":               {`href="rule-drop_table.html"`, `href="doc-drop_table.html"`},
+		"rule-drop_table.html":     {"Grammar: drop_table", `<a href="rule-tableview_name.html">`, `<a href="doc-drop_table.html">Oracle: drop_table</a>`},
+		"rule-tableview_name.html": {"Grammar: tableview_name"},
+		"doc-drop_table.html":      {`href="rule-drop_table.html"`},
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, fn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(b), want) {
+				t.Errorf("%s: no %q", fn, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,623 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsqlchroma "github.com/UNO-SOFT/plsql-parser/chroma"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/alecthomas/chroma"
+	chromahtml "github.com/alecthomas/chroma/formatters/html"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// RenderHTML renders the PL/SQL sources, analysed together, into static HTML pages:
+//
+//   - a page per source file (the path with / replaced by _, a -2, -3... suffix if taken by another page, plus .html),
+//     highlighted by the chroma.Lexer, with the identifiers linked to their declarations (see NewSymbolTable), and the table names
+//     (in DML, %TYPE and %ROWTYPE) to their CREATE TABLE in the sources,
+//   - a "called by" panel for each procedure and function, below the page of its declaration,
+//     listing the calls grouped by the calling subprogram; the declared name links to it,
+//   - an index page per schema (schema-NAME.html, schema-default.html for the objects without owner),
+//     listing its packages with their subprograms, the standalone subprograms, triggers, tables, views and sequences,
+//   - index.html, listing the schemas and the files,
+//   - style.css, the chroma "github" style.
+//
+// The returned error is only for syntax errors.
+func RenderHTML(files []File, opts ...Options) ([]File, error) {
+	trees, err := parseFiles(files, options(opts))
+	if err != nil {
+		return nil, err
+	}
+	site := htmlSite{files: files, st: NewSymbolTable(trees...),
+		decls:   make(map[*Symbol]Reference),
+		tables:  make(map[string]htmlTarget),
+		callers: make(map[*Symbol][]Reference),
+	}
+	for range files {
+		site.anchors = append(site.anchors, make(map[int]bool))
+		site.links = append(site.links, make(map[int]string))
+	}
+	site.collect(trees)
+
+	var out []File
+	for i := range files {
+		page, err := site.sourcePage(i)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page)
+	}
+	indexes, err := site.indexPages()
+	if err != nil {
+		return nil, err
+	}
+	out = append(out, indexes...)
+
+	var css bytes.Buffer
+	if err := chromahtml.New(chromahtml.WithClasses(true)).WriteCSS(&css, styles.Get("github")); err != nil {
+		return nil, err
+	}
+	css.WriteString(htmlCSS)
+	return append(out, File{Name: "style.css", Text: css.String()}), nil
+}
+
+// htmlSite holds the cross references of the pages.
+type htmlSite struct {
+	files []File
+	st    *SymbolTable
+	// pages are the names of the pages of the files.
+	pages []string
+	// decls are the canonical declarations of the symbols.
+	decls map[*Symbol]Reference
+	// tables are the CREATE TABLE statements, by the (owner-qualified, and the plain) table name.
+	tables  map[string]htmlTarget
+	objects []htmlObject
+	// callers are the calls of the procedures and functions.
+	callers map[*Symbol][]Reference
+	// anchors and links are the anchored and linked names of each file, by their start.
+	anchors []map[int]bool
+	links   []map[int]string
+}
+
+// htmlTarget is a position of a file.
+type htmlTarget struct{ Tree, Start int }
+
+// htmlObject is a schema object created by the sources.
+type htmlObject struct {
+	Schema, Name, Kind string
+	htmlTarget
+	// Symbol is the package, procedure or function declared.
+	Symbol *Symbol
+}
+
+// htmlPageNames returns the names of the pages of the source files, made unique
+// (and distinct from index.html and the reserved names) by a numeric suffix: a/b.sql and a_b.sql are a_b.sql.html and a_b.sql-2.html.
+func htmlPageNames(files []File, reserved []string) []string {
+	used := map[string]bool{"index.html": true, "style.css": true}
+	for _, page := range reserved {
+		used[page] = true
+	}
+	pages := make([]string, len(files))
+	for i, f := range files {
+		base := strings.TrimSuffix(htmlPageName(f.Name), ".html")
+		page := base + ".html"
+		for n := 2; used[page]; n++ {
+			page = fmt.Sprintf("%s-%d.html", base, n)
+		}
+		used[page] = true
+		pages[i] = page
+	}
+	return pages
+}
+
+// htmlPageName returns the name of the page of the source file.
+func htmlPageName(name string) string {
+	name = strings.TrimLeft(filepath.ToSlash(name), "./")
+	return strings.NewReplacer("/", "_", ":", "_", " ", "_").Replace(name) + ".html"
+}
+
+// schemaPage returns the name of the index page of the schema.
+func schemaPage(schema string) string {
+	if schema == "" {
+		return "schema-default.html"
+	}
+	return "schema-" + htmlPageName(schema)
+}
+
+// anchor returns the id of the position.
+func anchor(start int) string { return fmt.Sprintf("s%d", start) }
+
+func (site *htmlSite) href(t htmlTarget) string {
+	return site.pages[t.Tree] + "#" + anchor(t.Start)
+}
+
+func (site *htmlSite) collect(trees []antlr.Tree) {
+	st := site.st
+	for _, ref := range st.References {
+		if sym := ref.Symbol; ref.Decl && sym != nil && ref.Scope == sym.Scope && ref.Start == sym.Decl.Start {
+			if _, ok := site.decls[sym]; !ok {
+				site.decls[sym] = ref
+			}
+		}
+	}
+	for i, tree := range trees {
+		ol := &htmlObjectListener{BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}, site: site, tree: i}
+		antlr.ParseTreeWalkerDefault.Walk(ol, tree)
+	}
+	// the schema indexes keep their names, the pages of the sources get suffixed
+	var schemaPages []string
+	for _, o := range site.objects {
+		schemaPages = append(schemaPages, schemaPage(o.Schema))
+	}
+	site.pages = htmlPageNames(site.files, schemaPages)
+	for i, tree := range trees {
+		tl := &htmlTableListener{BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}, site: site, tree: i}
+		antlr.ParseTreeWalkerDefault.Walk(tl, tree)
+	}
+
+	for _, ref := range st.References {
+		sym := ref.Symbol
+		if sym == nil {
+			continue
+		}
+		decl, ok := site.decls[sym]
+		target := htmlTarget{Tree: decl.Tree, Start: decl.Start}
+		switch {
+		case ref.Decl:
+			site.anchors[ref.Tree][ref.Start] = true
+			if !ok {
+				continue
+			}
+			if ref.Tree != decl.Tree || ref.Start != decl.Start {
+				site.links[ref.Tree][ref.Start] = site.href(target)
+			} else if sym.Kind == ProcedureSym || sym.Kind == FunctionSym {
+				site.links[ref.Tree][ref.Start] = "#callers-" + anchor(ref.Start)
+			}
+		case ok:
+			site.links[ref.Tree][ref.Start] = site.href(target)
+		}
+		if _, ok := ref.Node.(*plsql.Label_nameContext); ok || ref.Decl {
+			continue
+		}
+		if sym.Kind == ProcedureSym || sym.Kind == FunctionSym {
+			site.callers[sym] = append(site.callers[sym], ref)
+		}
+	}
+}
+
+// htmlObjectListener collects the objects created by a tree.
+type htmlObjectListener struct {
+	BaseWalkListener
+	site *htmlSite
+	tree int
+}
+
+// add the object of the possibly schema-qualified name.
+func (ol *htmlObjectListener) add(kind string, schema, name antlr.ParserRuleContext) *htmlObject {
+	if name == nil {
+		return nil
+	}
+	o := htmlObject{Kind: kind, Name: catalog.Normalize(name.GetText()),
+		htmlTarget: htmlTarget{Tree: ol.tree, Start: name.GetStop().GetStart()}}
+	if schema != nil {
+		o.Schema = catalog.Normalize(schema.GetText())
+	}
+	ol.site.anchors[ol.tree][o.Start] = true
+	ol.site.objects = append(ol.site.objects, o)
+	return &ol.site.objects[len(ol.site.objects)-1]
+}
+
+// symbol sets the symbol of the object declared by name.
+func (ol *htmlObjectListener) symbol(o *htmlObject, name antlr.Tree) {
+	if o == nil {
+		return
+	}
+	if sym := ol.site.st.decls[name]; sym != nil {
+		o.Symbol = ol.site.st.Canonical(sym)
+	}
+}
+
+func (ol *htmlObjectListener) EnterCreate_package(ctx *plsql.Create_packageContext) {
+	o := ol.add("package", ctx.Schema_object_name(), ctx.Package_name(0))
+	ol.symbol(o, ctx.Package_name(0))
+}
+func (ol *htmlObjectListener) EnterCreate_package_body(ctx *plsql.Create_package_bodyContext) {
+	o := ol.add("package body", ctx.Schema_object_name(), ctx.Package_name(0))
+	ol.symbol(o, ctx.Package_name(0))
+}
+func (ol *htmlObjectListener) EnterCreate_procedure_body(ctx *plsql.Create_procedure_bodyContext) {
+	pn := ctx.Procedure_name().(*plsql.Procedure_nameContext)
+	ol.addQualified("procedure", pn.Identifier(), pn.Id_expression())
+}
+func (ol *htmlObjectListener) EnterCreate_function_body(ctx *plsql.Create_function_bodyContext) {
+	fn := ctx.Function_name().(*plsql.Function_nameContext)
+	ol.addQualified("function", fn.Identifier(), fn.Id_expression())
+}
+func (ol *htmlObjectListener) EnterCreate_trigger(ctx *plsql.Create_triggerContext) {
+	tn := ctx.Trigger_name().(*plsql.Trigger_nameContext)
+	ol.addQualified("trigger", tn.Identifier(), tn.Id_expression())
+}
+func (ol *htmlObjectListener) EnterCreate_table(ctx *plsql.Create_tableContext) {
+	o := ol.addTableview("table", ctx.Tableview_name())
+	if o == nil {
+		return
+	}
+	t := o.htmlTarget
+	if o.Schema != "" {
+		ol.site.tables[o.Schema+"."+o.Name] = t
+	}
+	if _, ok := ol.site.tables[o.Name]; !ok {
+		ol.site.tables[o.Name] = t
+	}
+}
+func (ol *htmlObjectListener) EnterCreate_view(ctx *plsql.Create_viewContext) {
+	ol.addTableview("view", ctx.Tableview_name())
+}
+func (ol *htmlObjectListener) EnterCreate_sequence(ctx *plsql.Create_sequenceContext) {
+	ids := ctx.Sequence_name().(*plsql.Sequence_nameContext).AllId_expression()
+	var schema antlr.ParserRuleContext
+	if len(ids) > 1 {
+		schema = ids[len(ids)-2]
+	}
+	ol.add("sequence", schema, ids[len(ids)-1])
+}
+
+// addQualified adds the object named first, or first.second.
+func (ol *htmlObjectListener) addQualified(kind string, first plsql.IIdentifierContext, second plsql.IId_expressionContext) {
+	var schema antlr.ParserRuleContext
+	if second != nil {
+		schema = first
+	}
+	name := objectName(first, second)
+	o := ol.add(kind, schema, name)
+	ol.symbol(o, name)
+}
+
+func (ol *htmlObjectListener) addTableview(kind string, tvn plsql.ITableview_nameContext) *htmlObject {
+	tv, ok := tvn.(*plsql.Tableview_nameContext)
+	if !ok || tv.Identifier() == nil {
+		return nil
+	}
+	if id := tv.Id_expression(); id != nil {
+		return ol.add(kind, tv.Identifier(), id)
+	}
+	return ol.add(kind, nil, tv.Identifier())
+}
+
+// htmlTableListener links the table names to their CREATE TABLE.
+type htmlTableListener struct {
+	BaseWalkListener
+	site *htmlSite
+	tree int
+}
+
+// link the name token to the table.
+func (tl *htmlTableListener) link(tok antlr.Token, table string) {
+	t, ok := tl.site.tables[table]
+	if !ok {
+		return
+	}
+	start := tok.GetStart()
+	if _, ok := tl.site.links[tl.tree][start]; ok || tl.site.anchors[tl.tree][start] {
+		return
+	}
+	tl.site.links[tl.tree][start] = tl.site.href(t)
+}
+
+func (tl *htmlTableListener) EnterTableview_name(ctx *plsql.Tableview_nameContext) {
+	if name := tableviewName(ctx); name != "" {
+		tl.link(ctx.GetStop(), name)
+	}
+}
+
+// EnterType_spec links the table of TABLE%ROWTYPE and TABLE.COLUMN%TYPE.
+func (tl *htmlTableListener) EnterType_spec(ctx *plsql.Type_specContext) {
+	tn, ok := ctx.Type_name().(*plsql.Type_nameContext)
+	if !ok {
+		return
+	}
+	ids := tn.AllId_expression()
+	switch {
+	case ctx.PERCENT_ROWTYPE() != nil:
+	case ctx.PERCENT_TYPE() != nil && len(ids) > 1:
+		ids = ids[:len(ids)-1] // the column
+	default:
+		return
+	}
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = catalog.Normalize(id.GetText())
+	}
+	tl.link(ids[len(ids)-1].GetStop(), strings.Join(names, "."))
+}
+
+// htmlCaller is a subprogram (or trigger, package initialization, script) calling another.
+type htmlCaller struct {
+	Name, Href string
+	Calls      []htmlLink
+}
+
+type htmlLink struct{ Name, Href string }
+
+// htmlSubprogram is the "called by" panel of a subprogram.
+type htmlSubprogram struct {
+	ID, Name, Kind, Href string
+	Callers              []*htmlCaller
+	start                int
+}
+
+// calledBy returns the panels of the subprograms declared in the file.
+func (site *htmlSite) calledBy(tree int) []htmlSubprogram {
+	st := site.st
+	var subs []htmlSubprogram
+	for sym, decl := range site.decls {
+		if decl.Tree != tree || (sym.Kind != ProcedureSym && sym.Kind != FunctionSym) {
+			continue
+		}
+		sub := htmlSubprogram{ID: "callers-" + anchor(decl.Start), Name: st.qualifiedName(sym), Kind: sym.Kind.String(),
+			Href: "#" + anchor(decl.Start), start: decl.Start}
+		byName := make(map[string]*htmlCaller)
+		for _, ref := range site.callers[sym] {
+			name, href := site.callerOf(ref)
+			c := byName[name]
+			if c == nil {
+				c = &htmlCaller{Name: name, Href: href}
+				byName[name] = c
+				sub.Callers = append(sub.Callers, c)
+			}
+			line := ref.Node.GetStop().GetLine()
+			c.Calls = append(c.Calls, htmlLink{
+				Name: fmt.Sprintf("%s:%d", site.files[ref.Tree].Name, line),
+				Href: site.pages[ref.Tree] + "#" + anchor(ref.Start),
+			})
+		}
+		sort.Slice(sub.Callers, func(i, j int) bool { return sub.Callers[i].Name < sub.Callers[j].Name })
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].start < subs[j].start })
+	return subs
+}
+
+// callerOf returns the name and the link of the subprogram making the call,
+// or the name of the enclosing trigger or package, or of the file.
+func (site *htmlSite) callerOf(ref Reference) (string, string) {
+	st := site.st
+	for s := ref.Scope; s != nil && s != st.Root; s = s.Parent {
+		if o := s.owner; o != nil && (o.Kind == ProcedureSym || o.Kind == FunctionSym) {
+			sym := st.Canonical(o)
+			var href string
+			if decl, ok := site.decls[sym]; ok {
+				href = site.href(htmlTarget{Tree: decl.Tree, Start: decl.Start})
+			}
+			return st.qualifiedName(sym), href
+		}
+	}
+	for s := ref.Scope; s != nil && s != st.Root; s = s.Parent {
+		if s.Name != "" {
+			return s.Name, ""
+		}
+	}
+	return site.files[ref.Tree].Name, site.pages[ref.Tree]
+}
+
+// highlight returns the highlighted text of the file, with the anchors and links.
+func (site *htmlSite) highlight(tree int) (template.HTML, error) {
+	it, err := plsqlchroma.Lexer.Tokenise(nil, site.files[tree].Text)
+	if err != nil {
+		return "", err
+	}
+	anchors, links := site.anchors[tree], site.links[tree]
+	var buf strings.Builder
+	line := 1
+	lineNo := func() { fmt.Fprintf(&buf, `<span class="ln" id="L%d">%4d </span>`, line, line) }
+	lineNo()
+	// text writes the escaped text, numbering the lines
+	text := func(s string) {
+		for {
+			i := strings.IndexByte(s, '\n')
+			if i < 0 {
+				buf.WriteString(html.EscapeString(s))
+				return
+			}
+			buf.WriteString(html.EscapeString(s[:i+1]))
+			line++
+			lineNo()
+			s = s[i+1:]
+		}
+	}
+	var pos int
+	for tok := it(); tok != chroma.EOF; tok = it() {
+		start := pos
+		pos += len([]rune(tok.Value))
+		cls := htmlClass(tok.Type)
+		href, linked := links[start]
+		switch {
+		case anchors[start] || linked:
+			buf.WriteString(`<a`)
+			if cls != "" {
+				fmt.Fprintf(&buf, ` class="%s"`, cls)
+			}
+			if anchors[start] {
+				fmt.Fprintf(&buf, ` id="%s"`, anchor(start))
+			}
+			if linked {
+				fmt.Fprintf(&buf, ` href="%s"`, html.EscapeString(href))
+			}
+			buf.WriteString(">")
+			text(tok.Value)
+			buf.WriteString("</a>")
+		case cls != "" && tok.Type != chroma.TextWhitespace:
+			fmt.Fprintf(&buf, `<span class="%s">`, cls)
+			text(tok.Value)
+			buf.WriteString("</span>")
+		default:
+			text(tok.Value)
+		}
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// htmlClass returns the CSS class of the token type, as the chroma HTML formatter does.
+func htmlClass(t chroma.TokenType) string {
+	for ; t != 0; t = t.Parent() {
+		if cls, ok := chroma.StandardTypes[t]; ok {
+			return cls
+		}
+	}
+	return chroma.StandardTypes[t]
+}
+
+func (site *htmlSite) sourcePage(tree int) (File, error) {
+	src, err := site.highlight(tree)
+	if err != nil {
+		return File{}, err
+	}
+	var buf strings.Builder
+	if err := htmlTemplates.ExecuteTemplate(&buf, "source", struct {
+		Title       string
+		Source      template.HTML
+		Subprograms []htmlSubprogram
+	}{Title: site.files[tree].Name, Source: src, Subprograms: site.calledBy(tree)}); err != nil {
+		return File{}, err
+	}
+	return File{Name: site.pages[tree], Text: buf.String()}, nil
+}
+
+// htmlEntry is an object in the index of its schema.
+type htmlEntry struct {
+	Name, Kind, Href string
+	Members          []htmlLink
+}
+
+// indexPages returns the schema indexes and index.html.
+func (site *htmlSite) indexPages() ([]File, error) {
+	bySchema := make(map[string][]htmlEntry)
+	for _, o := range site.objects {
+		e := htmlEntry{Name: o.Name, Kind: o.Kind, Href: site.href(o.htmlTarget)}
+		if sym := o.Symbol; sym != nil && sym.Kind == PackageSym && sym.Own != nil && o.Kind == "package" {
+			for _, m := range sym.Own.Symbols {
+				if decl, ok := site.decls[site.st.Canonical(m)]; ok && (m.Kind == ProcedureSym || m.Kind == FunctionSym) {
+					e.Members = append(e.Members, htmlLink{Name: m.Kind.String() + " " + m.Name,
+						Href: site.href(htmlTarget{Tree: decl.Tree, Start: decl.Start})})
+				}
+			}
+		}
+		bySchema[o.Schema] = append(bySchema[o.Schema], e)
+	}
+
+	type schemaLink struct{ Name, Href string }
+	var schemas []schemaLink
+	var out []File
+	for schema, entries := range bySchema {
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].Name != entries[j].Name {
+				return entries[i].Name < entries[j].Name
+			}
+			return entries[i].Kind < entries[j].Kind
+		})
+		name, page := schema, schemaPage(schema)
+		if schema == "" {
+			name = "(default)"
+		}
+		var buf strings.Builder
+		if err := htmlTemplates.ExecuteTemplate(&buf, "schema", struct {
+			Title   string
+			Entries []htmlEntry
+		}{Title: name, Entries: entries}); err != nil {
+			return nil, err
+		}
+		out = append(out, File{Name: page, Text: buf.String()})
+		schemas = append(schemas, schemaLink{Name: name, Href: page})
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Name < schemas[j].Name })
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	sources := make([]htmlLink, len(site.files))
+	for i, f := range site.files {
+		sources[i] = htmlLink{Name: f.Name, Href: site.pages[i]}
+	}
+	var buf strings.Builder
+	if err := htmlTemplates.ExecuteTemplate(&buf, "index", struct {
+		Title   string
+		Schemas []schemaLink
+		Files   []htmlLink
+	}{Title: "PL/SQL sources", Schemas: schemas, Files: sources}); err != nil {
+		return nil, err
+	}
+	return append(out, File{Name: "index.html", Text: buf.String()}), nil
+}
+
+const htmlCSS = `
+body { font-family: sans-serif; margin: 1em 2em; }
+pre.chroma { padding: 0.5em; line-height: 1.3; }
+pre.chroma a { color: inherit; text-decoration: none; }
+pre.chroma a[href]:hover { text-decoration: underline; }
+pre.chroma a:target, .callers:target { background-color: #ffffcc; }
+.ln { color: #999; user-select: none; }
+.callers { border: 1px solid #ddd; border-radius: 4px; margin: 0.5em 0; padding: 0.2em 1em; }
+.callers h3 { font-size: 1em; margin: 0.5em 0; }
+.kind { color: #777; font-size: smaller; }
+`
+
+var htmlTemplates = template.Must(template.New("").Parse(`
+{{- define "header"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="style.css">
+</head>
+<body>
+<p><a href="index.html">index</a></p>
+<h1>{{.Title}}</h1>
+{{end}}
+
+{{- define "source"}}{{template "header" .}}<pre class="chroma">{{.Source}}</pre>
+{{if .Subprograms}}<h2>Called by</h2>
+{{range .Subprograms}}<div class="callers" id="{{.ID}}">
+<h3><span class="kind">{{.Kind}}</span> <a href="{{.Href}}">{{.Name}}</a></h3>
+{{if .Callers}}<ul>
+{{range .Callers}}<li>{{if .Href}}<a href="{{.Href}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}:
+{{range $i, $c := .Calls}}{{if $i}}, {{end}}<a href="{{$c.Href}}">{{$c.Name}}</a>{{end}}</li>
+{{end}}</ul>
+{{else}}<p>not called from the sources</p>
+{{end}}</div>
+{{end}}{{end}}</body>
+</html>
+{{end}}
+
+{{- define "schema"}}{{template "header" .}}<ul>
+{{range .Entries}}<li><span class="kind">{{.Kind}}</span> <a href="{{.Href}}">{{.Name}}</a>
+{{- if .Members}}
+<ul>
+{{range .Members}}<li><a href="{{.Href}}">{{.Name}}</a></li>
+{{end}}</ul>
+{{end}}</li>
+{{end}}</ul>
+</body>
+</html>
+{{end}}
+
+{{- define "index"}}{{template "header" .}}<h2>Schemas</h2>
+<ul>
+{{range .Schemas}}<li><a href="{{.Href}}">{{.Name}}</a></li>
+{{end}}</ul>
+<h2>Files</h2>
+<ul>
+{{range .Files}}<li><a href="{{.Href}}">{{.Name}}</a></li>
+{{end}}</ul>
+</body>
+</html>
+{{end}}`))
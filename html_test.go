@@ -0,0 +1,118 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestRenderHTML(t *testing.T) {
+	const (
+		tables = `CREATE TABLE hr.emp (id NUMBER, name VARCHAR2(100));
+`
+		spec = `CREATE OR REPLACE PACKAGE hr.emp_api IS
+  PROCEDURE do_it(p_id IN NUMBER);
+END emp_api;
+/
+`
+		body = `CREATE OR REPLACE PACKAGE BODY hr.emp_api IS
+  PROCEDURE do_it(p_id IN NUMBER) IS
+    v_row emp%ROWTYPE;
+  BEGIN
+    SELECT * INTO v_row FROM emp WHERE id = p_id;
+  END do_it;
+
+  PROCEDURE run IS
+  BEGIN
+    do_it(1);
+  END run;
+END emp_api;
+/
+`
+	)
+	files := []plsqlparser.File{
+		{Name: "ddl/tables.sql", Text: tables},
+		{Name: "emp_api.pks", Text: spec},
+		{Name: "emp_api.pkb", Text: body},
+	}
+	pages, err := plsqlparser.RenderHTML(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]string, len(pages))
+	for _, p := range pages {
+		byName[p.Name] = p.Text
+	}
+	for _, name := range []string{"ddl_tables.sql.html", "emp_api.pks.html", "emp_api.pkb.html", "schema-HR.html", "index.html", "style.css"} {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("no %s in %v", name, pages)
+		}
+	}
+
+	// after returns the anchor of the first occurrence of s in text, after the prefix.
+	after := func(text, prefix, s string) string {
+		i := strings.Index(text, prefix)
+		return fmt.Sprintf("s%d", i+len(prefix)+strings.Index(text[i+len(prefix):], s))
+	}
+	spDoIt := after(spec, "PROCEDURE ", "do_it")
+	bdDoIt := after(body, "PROCEDURE ", "do_it")
+	for name, wants := range map[string][]string{
+		"emp_api.pks.html": {
+			`id="` + spDoIt + `" href="#callers-` + spDoIt + `"`,
+			`<div class="callers" id="callers-` + spDoIt + `">`,
+			`<a href="emp_api.pkb.html#` + after(body, "PROCEDURE ", "run") + `">EMP_API.RUN</a>`,
+			`<a href="emp_api.pkb.html#` + after(body, "run IS\n  BEGIN\n    ", "do_it") + `">emp_api.pkb:10</a>`,
+		},
+		"emp_api.pkb.html": {
+			`id="` + bdDoIt + `" href="emp_api.pks.html#` + spDoIt + `"`,
+			`href="emp_api.pks.html#` + spDoIt + `">do_it</a><span class="p">(</span>`,
+			`href="ddl_tables.sql.html#` + after(tables, "hr.", "emp") + `">emp</a><span class="k">%ROWTYPE`,
+			`FROM</span> <a class="n" href="ddl_tables.sql.html#`,
+			`href="emp_api.pks.html#` + after(spec, "do_it(", "p_id") + `">p_id</a><span class="p">;`,
+		},
+		"schema-HR.html": {
+			`<span class="kind">package</span> <a href="emp_api.pks.html#`,
+			`<a href="emp_api.pks.html#` + spDoIt + `">procedure DO_IT</a>`,
+			`<span class="kind">table</span> <a href="ddl_tables.sql.html#`,
+		},
+		"index.html": {
+			`<a href="schema-HR.html">HR</a>`,
+			`<a href="emp_api.pkb.html">emp_api.pkb</a>`,
+		},
+	} {
+		for _, want := range wants {
+			if !strings.Contains(byName[name], want) {
+				t.Errorf("%s: %q not found in\n%s", name, want, byName[name])
+			}
+		}
+	}
+}
+
+func TestRenderHTMLPageNames(t *testing.T) {
+	var files []plsqlparser.File
+	for _, name := range []string{"a/b.sql", "a_b.sql", "x.sql", "../x.sql", "index", "schema-default", "schema-HR"} {
+		files = append(files, plsqlparser.File{Name: name, Text: "CREATE TABLE t (id NUMBER);\n"})
+	}
+	files[len(files)-1].Text = "CREATE TABLE hr.t (id NUMBER);\n"
+	pages, err := plsqlparser.RenderHTML(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]int, len(pages))
+	for _, p := range pages {
+		byName[p.Name]++
+	}
+	for _, name := range []string{"a_b.sql.html", "a_b.sql-2.html", "x.sql.html", "x.sql-2.html", "index-2.html", "index.html",
+		"schema-default.html", "schema-default-2.html", "schema-HR.html", "schema-HR-2.html",
+	} {
+		if byName[name] != 1 {
+			t.Errorf("%s: %d pages in %v", name, byName[name], byName)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package casefold provides the input stream of the (case sensitive) PlSqlLexer.
+package casefold
+
+import (
+	"unicode"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// Stream is an input stream which gives the characters uppercased to the (case sensitive) lexer,
+// while the text of the tokens is the original, so the strings and quoted identifiers keep their case.
+type Stream struct {
+	*antlr.InputStream
+}
+
+// NewStream returns a new Stream of the text.
+func NewStream(text string) *Stream {
+	return &Stream{InputStream: antlr.NewInputStream(text)}
+}
+
+func (s *Stream) LA(offset int) int {
+	c := s.InputStream.LA(offset)
+	if c <= 0 {
+		return c
+	}
+	return int(unicode.ToUpper(rune(c)))
+}
+
+func (s *Stream) LT(offset int) int { return s.LA(offset) }
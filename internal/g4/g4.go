@@ -0,0 +1,267 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package g4 reads the parser rules of ANTLR4 grammars (such as PlSqlParser.g4).
+package g4
+
+import (
+	"strings"
+)
+
+// Kind is the kind of a Node.
+type Kind uint8
+
+const (
+	// Sequence of the Items.
+	Sequence = Kind(iota)
+	// Choice is one of the Items: A | B.
+	Choice
+	// Optional is the optional Items[0]: A?
+	Optional
+	// Star is zero or more of Items[0]: A*
+	Star
+	// Plus is one or more of Items[0]: A+
+	Plus
+	// Token is a token name, such as SELECT.
+	Token
+	// Rule is a rule name, such as expression.
+	Rule
+	// Literal is the (unquoted) text of a 'literal'.
+	Literal
+	// Not is any token but Items[0]: ~A
+	Not
+	// Wildcard is any token: .
+	Wildcard
+	// Predicate is a semantic predicate, Name is its code: {self.isVersion12()}?
+	Predicate
+)
+
+// Node is a node of the AST of a grammar rule.
+type Node struct {
+	Kind Kind
+	// Name is the name of a Token or Rule, the text of a Literal or the code of a Predicate.
+	Name  string
+	Items []*Node
+}
+
+// Walk calls f for the node and its descendants, depth-first, while f returns true.
+func (n *Node) Walk(f func(*Node) bool) {
+	if !f(n) {
+		return
+	}
+	for _, it := range n.Items {
+		it.Walk(f)
+	}
+}
+
+// ParserRule is a parser rule of a grammar.
+type ParserRule struct {
+	Name string
+	Body *Node
+}
+
+// ReadRules reads the parser rules of the grammar, in order.
+//
+// Comments, actions, labels and element options are skipped, as is EOF.
+// A Sequence of one element is that element.
+func ReadRules(text string) []ParserRule {
+	var rules []ParserRule
+	tokens := Tokens(text)
+	for i := 1; i < len(tokens); i++ {
+		if tokens[i] != ":" || !isRuleName(tokens[i-1]) {
+			continue
+		}
+		name, start := tokens[i-1], i+1
+		for i = start; i < len(tokens) && tokens[i] != ";"; i++ {
+		}
+		p := parser{tokens: tokens[start:i]}
+		rules = append(rules, ParserRule{Name: name, Body: p.alternatives()})
+	}
+	return rules
+}
+
+func isRuleName(s string) bool { return s != "" && 'a' <= s[0] && s[0] <= 'z' }
+
+// IsIdent reports whether the grammar token is an identifier: a token or rule name.
+func IsIdent(s string) bool {
+	return s != "" && (s[0] == '_' || 'a' <= s[0] && s[0] <= 'z' || 'A' <= s[0] && s[0] <= 'Z')
+}
+
+// Tokens splits the grammar into identifiers, 'literals', {predicates}? and punctuation,
+// skipping the comments and the {actions}.
+func Tokens(text string) []string {
+	var tokens []string
+	isIdent := func(c byte) bool {
+		return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+	}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case strings.HasPrefix(text[i:], "//"):
+			if j := strings.IndexByte(text[i:], '\n'); j >= 0 {
+				i += j + 1
+			} else {
+				i = len(text)
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			if j := strings.Index(text[i+2:], "*/"); j >= 0 {
+				i += 2 + j + 2
+			} else {
+				i = len(text)
+			}
+		case c == '{':
+			start := i
+			for depth := 0; i < len(text); {
+				if text[i] == '{' {
+					depth++
+				} else if text[i] == '}' {
+					depth--
+				}
+				if i++; depth == 0 {
+					break
+				}
+			}
+			if i < len(text) && text[i] == '?' { // semantic predicate
+				i++
+				tokens = append(tokens, text[start:i])
+			}
+		case c == '\'':
+			j := i + 1
+			for j < len(text) && text[j] != '\'' {
+				if text[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(text))
+			tokens = append(tokens, text[i:j])
+			i = j
+		case isIdent(c):
+			j := i + 1
+			for j < len(text) && isIdent(text[j]) {
+				j++
+			}
+			tokens = append(tokens, text[i:j])
+			i = j
+		case strings.HasPrefix(text[i:], "::"), strings.HasPrefix(text[i:], "+="):
+			tokens = append(tokens, text[i:i+2])
+			i += 2
+		default:
+			tokens = append(tokens, text[i:i+1])
+			i++
+		}
+	}
+	return tokens
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// alternatives: sequence ('|' sequence)*
+func (p *parser) alternatives() *Node {
+	n := p.sequence()
+	if p.peek() != "|" {
+		return n
+	}
+	choice := &Node{Kind: Choice, Items: []*Node{n}}
+	for p.peek() == "|" {
+		p.pos++
+		choice.Items = append(choice.Items, p.sequence())
+	}
+	return choice
+}
+
+// sequence: (element ('?' | '*' | '+')?)*
+func (p *parser) sequence() *Node {
+	seq := &Node{Kind: Sequence}
+	for {
+		switch p.peek() {
+		case "", "|", ")":
+			if len(seq.Items) == 1 {
+				return seq.Items[0]
+			}
+			return seq
+		case "#": // alternative label
+			p.pos += 2
+			continue
+		case "<": // element options
+			for p.pos < len(p.tokens) && p.tokens[p.pos] != ">" {
+				p.pos++
+			}
+			p.pos++
+			continue
+		}
+		n := p.element()
+		if n == nil {
+			continue
+		}
+		var kind Kind
+		switch p.peek() {
+		case "?":
+			kind = Optional
+		case "*":
+			kind = Star
+		case "+":
+			kind = Plus
+		default:
+			seq.Items = append(seq.Items, n)
+			continue
+		}
+		n = &Node{Kind: kind, Items: []*Node{n}}
+		if p.pos++; p.peek() == "?" { // non-greedy
+			p.pos++
+		}
+		seq.Items = append(seq.Items, n)
+	}
+}
+
+// element: label ('=' | '+=') element | '(' alternatives ')' | '~' element | '.' | {predicate}? | TOKEN | rule | 'literal'
+func (p *parser) element() *Node {
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch next := p.peek(); {
+	case tok == "(":
+		n := p.alternatives()
+		if p.peek() == ")" {
+			p.pos++
+		}
+		return n
+	case tok == "~":
+		if p.pos < len(p.tokens) {
+			if n := p.element(); n != nil {
+				return &Node{Kind: Not, Items: []*Node{n}}
+			}
+		}
+		return nil
+	case tok == ".":
+		return &Node{Kind: Wildcard}
+	case tok[0] == '{':
+		return &Node{Kind: Predicate, Name: tok[1 : len(tok)-2]}
+	case tok[0] == '\'' && len(tok) > 1:
+		return &Node{Kind: Literal, Name: strings.ReplaceAll(tok[1:len(tok)-1], `\'`, "'")}
+	case !IsIdent(tok):
+		return nil
+	case next == "=" || next == "+=":
+		if p.pos++; p.pos < len(p.tokens) {
+			return p.element()
+		}
+		return nil
+	case tok == "EOF":
+		return nil
+	case 'A' <= tok[0] && tok[0] <= 'Z':
+		return &Node{Kind: Token, Name: tok}
+	}
+	return &Node{Kind: Rule, Name: tok}
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package g4_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/UNO-SOFT/plsql-parser/internal/g4"
+)
+
+func TestReadRules(t *testing.T) {
+	rules := g4.ReadRules(`parser grammar X;
+options { tokenVocab=XLexer; }
+
+// comment
+drop_table : DROP TABLE name=tableview_name (PURGE)? ';' EOF ;
+tableview_name
+    : id_expression ('.' id_expression)* #label
+    | {self.isVersion12()}? NULL_
+    | ~';'+?
+    ;
+`)
+	want := []g4.ParserRule{
+		{Name: "drop_table", Body: &g4.Node{Kind: g4.Sequence, Items: []*g4.Node{
+			{Kind: g4.Token, Name: "DROP"}, {Kind: g4.Token, Name: "TABLE"}, {Kind: g4.Rule, Name: "tableview_name"},
+			{Kind: g4.Optional, Items: []*g4.Node{{Kind: g4.Token, Name: "PURGE"}}}, {Kind: g4.Literal, Name: ";"},
+		}}},
+		{Name: "tableview_name", Body: &g4.Node{Kind: g4.Choice, Items: []*g4.Node{
+			{Kind: g4.Sequence, Items: []*g4.Node{
+				{Kind: g4.Rule, Name: "id_expression"},
+				{Kind: g4.Star, Items: []*g4.Node{{Kind: g4.Sequence, Items: []*g4.Node{{Kind: g4.Literal, Name: "."}, {Kind: g4.Rule, Name: "id_expression"}}}}},
+			}},
+			{Kind: g4.Sequence, Items: []*g4.Node{{Kind: g4.Predicate, Name: "self.isVersion12()"}, {Kind: g4.Token, Name: "NULL_"}}},
+			{Kind: g4.Plus, Items: []*g4.Node{{Kind: g4.Not, Items: []*g4.Node{{Kind: g4.Literal, Name: ";"}}}}},
+		}}},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("got %+v, wanted %+v", rules, want)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// Metric holds the code metrics of a procedure, function or trigger.
+type Metric struct {
+	// Name is qualified with the package name for packaged subprograms.
+	Name string
+	// Kind is PROCEDURE, FUNCTION or TRIGGER.
+	Kind string
+	// Line is where the subprogram starts.
+	Line int
+	// LOC is the number of lines with code, not counting the blank and comment-only lines.
+	LOC int
+	// Complexity is the cyclomatic complexity: 1 + the number of IFs, ELSIFs, CASE WHENs,
+	// loops, EXIT/CONTINUE WHENs and exception handlers.
+	Complexity int
+	// Nesting is the maximal depth of nested IFs, CASEs, loops and blocks.
+	Nesting int
+	// Params is the number of parameters.
+	Params int
+	// SQL is the number of SQL statements.
+	SQL int
+	Halstead
+}
+
+// Halstead measures, counting the identifiers and literals as operands,
+// and the keywords and symbols as operators.
+type Halstead struct {
+	DistinctOperators, DistinctOperands int
+	Operators, Operands                 int
+	Vocabulary, Length                  int
+	Volume, Difficulty, Effort          float64
+}
+
+// Exceeds returns the descriptions of the metrics greater than the non-zero limits.
+func (m Metric) Exceeds(limit Metric) []string {
+	var over []string
+	for _, x := range []struct {
+		Name     string
+		Got, Max int
+	}{
+		{"lines of code", m.LOC, limit.LOC},
+		{"complexity", m.Complexity, limit.Complexity},
+		{"nesting", m.Nesting, limit.Nesting},
+		{"parameters", m.Params, limit.Params},
+		{"SQL statements", m.SQL, limit.SQL},
+	} {
+		if x.Max != 0 && x.Got > x.Max {
+			over = append(over, fmt.Sprintf("%s %d > %d", x.Name, x.Got, x.Max))
+		}
+	}
+	if limit.Volume != 0 && m.Volume > limit.Volume {
+		over = append(over, fmt.Sprintf("Halstead volume %.0f > %.0f", m.Volume, limit.Volume))
+	}
+	if limit.Effort != 0 && m.Effort > limit.Effort {
+		over = append(over, fmt.Sprintf("Halstead effort %.0f > %.0f", m.Effort, limit.Effort))
+	}
+	return over
+}
+
+// Metrics returns the metrics of each procedure, function and trigger of the unit,
+// in the order of their ends: nested subprograms precede the enclosing one,
+// and their code is not counted in the enclosing one, except for the lines of code.
+func Metrics(unit string, opts ...Options) ([]Metric, error) {
+	parser := options(opts).newParser(unit)
+	el := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	parser.AddErrorListener(el)
+	tree := parser.Sql_script()
+	if len(el.slice) != 0 {
+		return nil, &el.Errors
+	}
+	ml := &metricsListener{
+		BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()},
+		tokens:           parser.GetTokenStream(),
+	}
+	antlr.ParseTreeWalkerDefault.Walk(ml, tree)
+	return ml.Metrics, nil
+}
+
+type metricsListener struct {
+	BaseWalkListener
+	Metrics  []Metric
+	tokens   antlr.TokenStream
+	packages []string
+	stack    []*unitMetrics
+}
+
+type unitMetrics struct {
+	Metric
+	node                antlr.ParserRuleContext
+	depth               int
+	operators, operands map[string]struct{}
+}
+
+func (ml *metricsListener) top() *unitMetrics {
+	if len(ml.stack) == 0 {
+		return nil
+	}
+	return ml.stack[len(ml.stack)-1]
+}
+
+func (ml *metricsListener) push(ctx antlr.ParserRuleContext, kind string, name antlr.Tree) {
+	m := &unitMetrics{
+		Metric: Metric{Kind: kind, Name: catalog.Normalize(name.(interface{ GetText() string }).GetText()),
+			Line: ctx.GetStart().GetLine(), Complexity: 1},
+		node:      ctx,
+		operators: make(map[string]struct{}),
+		operands:  make(map[string]struct{}),
+	}
+	if n := len(ml.packages); n != 0 {
+		m.Name = ml.packages[n-1] + "." + m.Name
+	}
+	ml.stack = append(ml.stack, m)
+}
+
+func (ml *metricsListener) pop(ctx antlr.ParserRuleContext) {
+	m := ml.top()
+	ml.stack = ml.stack[:len(ml.stack)-1]
+
+	lines := make(map[int]struct{})
+	for i := ctx.GetStart().GetTokenIndex(); i <= ctx.GetStop().GetTokenIndex(); i++ {
+		t := ml.tokens.Get(i)
+		if t.GetChannel() != antlr.TokenDefaultChannel {
+			continue
+		}
+		for j := 0; j <= strings.Count(t.GetText(), "\n"); j++ {
+			lines[t.GetLine()+j] = struct{}{}
+		}
+	}
+	m.LOC = len(lines)
+
+	h := &m.Halstead
+	h.DistinctOperators, h.DistinctOperands = len(m.operators), len(m.operands)
+	h.Vocabulary, h.Length = h.DistinctOperators+h.DistinctOperands, h.Operators+h.Operands
+	if h.Vocabulary != 0 {
+		h.Volume = float64(h.Length) * math.Log2(float64(h.Vocabulary))
+	}
+	if h.DistinctOperands != 0 {
+		h.Difficulty = float64(h.DistinctOperators) / 2 * float64(h.Operands) / float64(h.DistinctOperands)
+	}
+	h.Effort = h.Difficulty * h.Volume
+
+	ml.Metrics = append(ml.Metrics, m.Metric)
+}
+
+// decision adds a decision point to the current subprogram.
+func (ml *metricsListener) decision() {
+	if m := ml.top(); m != nil {
+		m.Complexity++
+	}
+}
+
+func (ml *metricsListener) enterNested() {
+	if m := ml.top(); m != nil {
+		if m.depth++; m.depth > m.Nesting {
+			m.Nesting = m.depth
+		}
+	}
+}
+func (ml *metricsListener) exitNested() {
+	if m := ml.top(); m != nil {
+		m.depth--
+	}
+}
+
+func (ml *metricsListener) EnterCreate_package_body(ctx *plsql.Create_package_bodyContext) {
+	ml.packages = append(ml.packages, catalog.Normalize(ctx.Package_name(0).GetText()))
+}
+func (ml *metricsListener) ExitCreate_package_body(ctx *plsql.Create_package_bodyContext) {
+	ml.packages = ml.packages[:len(ml.packages)-1]
+}
+
+func (ml *metricsListener) EnterCreate_procedure_body(ctx *plsql.Create_procedure_bodyContext) {
+	ml.push(ctx, "PROCEDURE", ctx.Procedure_name())
+}
+func (ml *metricsListener) ExitCreate_procedure_body(ctx *plsql.Create_procedure_bodyContext) {
+	ml.pop(ctx)
+}
+func (ml *metricsListener) EnterCreate_function_body(ctx *plsql.Create_function_bodyContext) {
+	ml.push(ctx, "FUNCTION", ctx.Function_name())
+}
+func (ml *metricsListener) ExitCreate_function_body(ctx *plsql.Create_function_bodyContext) {
+	ml.pop(ctx)
+}
+func (ml *metricsListener) EnterProcedure_body(ctx *plsql.Procedure_bodyContext) {
+	ml.push(ctx, "PROCEDURE", ctx.Identifier())
+}
+func (ml *metricsListener) ExitProcedure_body(ctx *plsql.Procedure_bodyContext) { ml.pop(ctx) }
+func (ml *metricsListener) EnterFunction_body(ctx *plsql.Function_bodyContext) {
+	ml.push(ctx, "FUNCTION", ctx.Identifier())
+}
+func (ml *metricsListener) ExitFunction_body(ctx *plsql.Function_bodyContext) { ml.pop(ctx) }
+func (ml *metricsListener) EnterCreate_trigger(ctx *plsql.Create_triggerContext) {
+	ml.push(ctx, "TRIGGER", ctx.Trigger_name())
+}
+func (ml *metricsListener) ExitCreate_trigger(ctx *plsql.Create_triggerContext) { ml.pop(ctx) }
+
+func (ml *metricsListener) ExitParameter(ctx *plsql.ParameterContext) {
+	if m := ml.top(); m != nil && ctx.GetParent() == m.node {
+		m.Params++
+	}
+}
+
+func (ml *metricsListener) EnterSql_statement(ctx *plsql.Sql_statementContext) {
+	if m := ml.top(); m != nil {
+		m.SQL++
+	}
+}
+
+func (ml *metricsListener) EnterIf_statement(ctx *plsql.If_statementContext) {
+	ml.decision()
+	ml.enterNested()
+}
+func (ml *metricsListener) ExitIf_statement(ctx *plsql.If_statementContext) { ml.exitNested() }
+func (ml *metricsListener) EnterElsif_part(ctx *plsql.Elsif_partContext)    { ml.decision() }
+func (ml *metricsListener) EnterCase_statement(ctx *plsql.Case_statementContext) {
+	ml.enterNested()
+}
+func (ml *metricsListener) ExitCase_statement(ctx *plsql.Case_statementContext) { ml.exitNested() }
+func (ml *metricsListener) EnterSimple_case_when_part(ctx *plsql.Simple_case_when_partContext) {
+	ml.decision()
+}
+func (ml *metricsListener) EnterSearched_case_when_part(ctx *plsql.Searched_case_when_partContext) {
+	ml.decision()
+}
+func (ml *metricsListener) EnterLoop_statement(ctx *plsql.Loop_statementContext) {
+	ml.decision()
+	ml.enterNested()
+}
+func (ml *metricsListener) ExitLoop_statement(ctx *plsql.Loop_statementContext) { ml.exitNested() }
+func (ml *metricsListener) EnterExit_statement(ctx *plsql.Exit_statementContext) {
+	if ctx.WHEN() != nil {
+		ml.decision()
+	}
+}
+func (ml *metricsListener) EnterContinue_statement(ctx *plsql.Continue_statementContext) {
+	if ctx.WHEN() != nil {
+		ml.decision()
+	}
+}
+func (ml *metricsListener) EnterException_handler(ctx *plsql.Exception_handlerContext) {
+	ml.decision()
+}
+
+// EnterBlock counts the nested DECLARE ... BEGIN ... END blocks.
+func (ml *metricsListener) EnterBlock(ctx *plsql.BlockContext) { ml.enterNested() }
+func (ml *metricsListener) ExitBlock(ctx *plsql.BlockContext)  { ml.exitNested() }
+
+// EnterBody counts the nested BEGIN ... END blocks, but not the body of the subprogram.
+func (ml *metricsListener) EnterBody(ctx *plsql.BodyContext) {
+	if _, ok := ctx.GetParent().(*plsql.StatementContext); ok {
+		ml.enterNested()
+	}
+}
+func (ml *metricsListener) ExitBody(ctx *plsql.BodyContext) {
+	if _, ok := ctx.GetParent().(*plsql.StatementContext); ok {
+		ml.exitNested()
+	}
+}
+
+// VisitTerminal counts the operands (identifiers, literals and bind variables) and the operators (everything else).
+func (ml *metricsListener) VisitTerminal(node antlr.TerminalNode) {
+	m := ml.top()
+	if m == nil || node.GetSymbol().GetTokenType() == antlr.TokenEOF {
+		return
+	}
+	text := node.GetText()
+	switch node.GetParent().(type) {
+	case *plsql.Id_expressionContext, *plsql.Regular_idContext,
+		*plsql.Non_reserved_keywords_pre12cContext, *plsql.Non_reserved_keywords_in_12cContext:
+		m.Operands++
+		m.operands[catalog.Normalize(text)] = struct{}{}
+	case *plsql.NumericContext, *plsql.Quoted_stringContext, *plsql.ConstantContext, *plsql.Bind_variableContext:
+		m.Operands++
+		m.operands[text] = struct{}{}
+	default:
+		m.Operators++
+		m.operators[strings.ToUpper(text)] = struct{}{}
+	}
+}
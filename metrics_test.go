@@ -0,0 +1,73 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestMetrics(t *testing.T) {
+	const unit = `CREATE OR REPLACE PACKAGE BODY emp_pkg IS
+  PROCEDURE raise_sal(p_empno IN NUMBER, p_pct IN NUMBER) IS
+    v_sal NUMBER;
+  BEGIN
+    -- read the salary
+    SELECT sal INTO v_sal FROM emp WHERE empno = p_empno;
+
+    IF v_sal IS NULL THEN
+      RETURN;
+    ELSIF v_sal > 1000 THEN
+      FOR i IN 1..10 LOOP
+        EXIT WHEN i > p_pct;
+        v_sal := v_sal + 1;
+      END LOOP;
+    END IF;
+    UPDATE emp SET sal = v_sal WHERE empno = p_empno;
+  EXCEPTION
+    WHEN NO_DATA_FOUND THEN NULL;
+  END raise_sal;
+
+  FUNCTION f RETURN NUMBER IS BEGIN RETURN 1; END f;
+END emp_pkg;
+`
+	ms, err := plsqlparser.Metrics(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 2 {
+		t.Fatalf("got %d metrics, wanted 2: %+v", len(ms), ms)
+	}
+	m := ms[0]
+	t.Logf("%+v", m)
+	for _, x := range []struct {
+		Name      string
+		Got, Want int
+	}{
+		{"line", m.Line, 2},
+		{"LOC", m.LOC, 16},
+		{"complexity", m.Complexity, 6},
+		{"nesting", m.Nesting, 2},
+		{"params", m.Params, 2},
+		{"SQL", m.SQL, 2},
+	} {
+		if x.Got != x.Want {
+			t.Errorf("%s: got %d, wanted %d", x.Name, x.Got, x.Want)
+		}
+	}
+	if m.Name != "EMP_PKG.RAISE_SAL" || m.Kind != "PROCEDURE" {
+		t.Errorf("got %s %s", m.Kind, m.Name)
+	}
+	if m.Operands == 0 || m.Operators == 0 || m.Volume <= 0 || m.Effort <= 0 {
+		t.Errorf("Halstead: %+v", m.Halstead)
+	}
+	if over := m.Exceeds(plsqlparser.Metric{Complexity: 5, Nesting: 2}); len(over) != 1 {
+		t.Errorf("Exceeds: got %q", over)
+	}
+	if f := ms[1]; f.Name != "EMP_PKG.F" || f.Complexity != 1 || f.LOC != 1 {
+		t.Errorf("F: %+v", f)
+	}
+}
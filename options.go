@@ -0,0 +1,205 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// LevelTrace is the slog level of the rule enter and exit events, below slog.LevelDebug.
+const LevelTrace = slog.LevelDebug - 4
+
+// Options of the parsing entry points (ParseToConvertMap, Check, Metrics, Rename...).
+// The zero value parses silently.
+type Options struct {
+	// Logger receives the syntax errors, ambiguity reports and parse times at slog.LevelDebug,
+	// and the rule enter and exit events at LevelTrace.
+	Logger *slog.Logger
+	// Trace is called for each parse event, if not nil.
+	Trace func(TraceEvent)
+}
+
+// TraceKind is the kind of a TraceEvent.
+type TraceKind uint8
+
+const (
+	// TraceEnter is the start of a rule.
+	TraceEnter = TraceKind(iota + 1)
+	// TraceExit is the end of a rule.
+	TraceExit
+	// TraceAmbiguity is an ambiguity of the grammar, resolved by choosing the first alternative.
+	TraceAmbiguity
+	// TraceSyntaxError is a syntax error.
+	TraceSyntaxError
+	// TraceDone is the end of the parse, with the time it took.
+	TraceDone
+)
+
+func (k TraceKind) String() string {
+	switch k {
+	case TraceEnter:
+		return "enter"
+	case TraceExit:
+		return "exit"
+	case TraceAmbiguity:
+		return "ambiguity"
+	case TraceSyntaxError:
+		return "syntax error"
+	case TraceDone:
+		return "done"
+	}
+	return "unknown"
+}
+
+// TraceEvent is an event of the parse.
+type TraceEvent struct {
+	Kind TraceKind
+	// Rule is the name of the rule entered, exited or parsed.
+	Rule string
+	// Depth is the depth of the rule, 0 for the top one.
+	Depth int
+	// Start and Stop are the positions of the rule's text (Stop is known only at the exit),
+	// or of the ambiguous text or the offending token.
+	Start, Stop int
+	// Line and Column (1-based) are of the Start.
+	Line, Column int
+	// Message is the message of the syntax error.
+	Message string
+	// Duration is the time the parse took, for TraceDone.
+	Duration time.Duration
+}
+
+// options returns the first of the optional opts.
+func options(opts []Options) Options {
+	if len(opts) == 0 {
+		return Options{}
+	}
+	return opts[0]
+}
+
+func (o Options) enabled(level slog.Level) bool {
+	return o.Logger != nil && o.Logger.Enabled(context.Background(), level)
+}
+
+// newParser returns a parser of the text, tracing to o.
+//
+// Unlike NewPlSqlLexerParser, its lexer and parser do not print the syntax errors to the console.
+func (o Options) newParser(text string) *plsql.PlSqlParser {
+	parser := NewPlSqlLexerParser(text)
+	parser.RemoveErrorListeners()
+	lexer := parser.GetTokenStream().GetTokenSource().(*plsql.PlSqlLexer)
+	lexer.RemoveErrorListeners()
+	if t := o.trace(parser); t != nil {
+		lexer.AddErrorListener(t)
+	}
+	return parser
+}
+
+// trace adds a tracer to the parser, if there is anything to trace to, and returns it.
+func (o Options) trace(parser *plsql.PlSqlParser) *tracer {
+	if o.Trace == nil && !o.enabled(slog.LevelDebug) {
+		return nil
+	}
+	t := &tracer{DefaultErrorListener: antlr.NewDefaultErrorListener(), Options: o, parser: parser,
+		rules: o.Trace != nil || o.enabled(LevelTrace)}
+	parser.AddErrorListener(t)
+	parser.AddParseListener(t)
+	return t
+}
+
+// tracer sends the parse events to the Options.
+type tracer struct {
+	*antlr.DefaultErrorListener
+	Options
+	parser *plsql.PlSqlParser
+	rules  bool
+	depth  int
+	start  time.Time
+}
+
+func (t *tracer) emit(ev TraceEvent) {
+	if t.Trace != nil {
+		t.Trace(ev)
+	}
+	level := slog.LevelDebug
+	if ev.Kind == TraceEnter || ev.Kind == TraceExit {
+		level = LevelTrace
+	}
+	if !t.enabled(level) {
+		return
+	}
+	attrs := []slog.Attr{slog.String("rule", ev.Rule), slog.Int("line", ev.Line), slog.Int("column", ev.Column)}
+	switch ev.Kind {
+	case TraceEnter, TraceExit:
+		attrs = append(attrs, slog.Int("depth", ev.Depth))
+	case TraceAmbiguity:
+		attrs = append(attrs, slog.Int("start", ev.Start), slog.Int("stop", ev.Stop))
+	case TraceSyntaxError:
+		attrs = append(attrs, slog.String("msg", ev.Message))
+	case TraceDone:
+		attrs = append(attrs, slog.Duration("dur", ev.Duration))
+	}
+	t.Logger.LogAttrs(context.Background(), level, "parse "+ev.Kind.String(), attrs...)
+}
+
+func (t *tracer) rule(kind TraceKind, ctx antlr.ParserRuleContext) TraceEvent {
+	ev := TraceEvent{Kind: kind, Rule: t.parser.GetRuleNames()[ctx.GetRuleIndex()], Depth: t.depth, Start: -1, Stop: -1}
+	if tok := ctx.GetStart(); tok != nil {
+		ev.Start, ev.Line, ev.Column = tok.GetStart(), tok.GetLine(), tok.GetColumn()+1
+	}
+	if tok := ctx.GetStop(); kind != TraceEnter && tok != nil {
+		ev.Stop = tok.GetStop()
+	}
+	return ev
+}
+
+func (t *tracer) VisitTerminal(node antlr.TerminalNode) {}
+func (t *tracer) VisitErrorNode(node antlr.ErrorNode)   {}
+func (t *tracer) EnterEveryRule(ctx antlr.ParserRuleContext) {
+	if t.depth == 0 {
+		t.start = time.Now()
+	}
+	if t.rules {
+		t.emit(t.rule(TraceEnter, ctx))
+	}
+	t.depth++
+}
+func (t *tracer) ExitEveryRule(ctx antlr.ParserRuleContext) {
+	t.depth--
+	if t.rules {
+		t.emit(t.rule(TraceExit, ctx))
+	}
+	if t.depth == 0 {
+		ev := t.rule(TraceDone, ctx)
+		ev.Duration = time.Since(t.start)
+		t.emit(ev)
+	}
+}
+
+func (t *tracer) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	ev := TraceEvent{Kind: TraceSyntaxError, Start: -1, Stop: -1, Line: line, Column: column + 1, Message: msg}
+	if tok, ok := offendingSymbol.(antlr.Token); ok {
+		ev.Start, ev.Stop = tok.GetStart(), tok.GetStop()
+	}
+	if ctx := t.parser.GetParserRuleContext(); ctx != nil {
+		ev.Rule = t.parser.GetRuleNames()[ctx.GetRuleIndex()]
+	}
+	t.emit(ev)
+}
+
+func (t *tracer) ReportAmbiguity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, exact bool, ambigAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
+	stream := t.parser.GetTokenStream()
+	start, stop := stream.Get(startIndex), stream.Get(stopIndex)
+	ev := TraceEvent{Kind: TraceAmbiguity, Start: start.GetStart(), Stop: stop.GetStop(), Line: start.GetLine(), Column: start.GetColumn() + 1}
+	if ctx := t.parser.GetParserRuleContext(); ctx != nil {
+		ev.Rule = t.parser.GetRuleNames()[ctx.GetRuleIndex()]
+	}
+	t.emit(ev)
+}
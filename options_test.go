@@ -0,0 +1,76 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestOptionsTrace(t *testing.T) {
+	var events []plsqlparser.TraceEvent
+	opts := plsqlparser.Options{Trace: func(ev plsqlparser.TraceEvent) { events = append(events, ev) }}
+	if _, err := plsqlparser.ParseExpression("a + f(1)", opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) < 3 {
+		t.Fatalf("got %d events", len(events))
+	}
+	if ev := events[0]; ev.Kind != plsqlparser.TraceEnter || ev.Rule != "expression" || ev.Depth != 0 {
+		t.Errorf("first event: %+v", ev)
+	}
+	if ev := events[len(events)-1]; ev.Kind != plsqlparser.TraceDone || ev.Rule != "expression" || ev.Stop != len("a + f(1)")-1 {
+		t.Errorf("last event: %+v", ev)
+	}
+	var depth int
+	for _, ev := range events {
+		switch ev.Kind {
+		case plsqlparser.TraceEnter:
+			if ev.Depth != depth {
+				t.Errorf("%+v: wanted depth %d", ev, depth)
+			}
+			depth++
+		case plsqlparser.TraceExit:
+			depth--
+			if ev.Depth != depth {
+				t.Errorf("%+v: wanted depth %d", ev, depth)
+			}
+		}
+	}
+	if depth != 0 {
+		t.Errorf("unbalanced enter/exit: %d", depth)
+	}
+
+	events = events[:0]
+	if _, err := plsqlparser.ParseExpression("a + ", opts); err == nil {
+		t.Error("wanted syntax error")
+	}
+	var found bool
+	for _, ev := range events {
+		found = found || ev.Kind == plsqlparser.TraceSyntaxError
+	}
+	if !found {
+		t.Error("no syntax error traced")
+	}
+}
+
+func TestOptionsLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if _, err := plsqlparser.Metrics("CREATE OR REPLACE PROCEDURE p IS BEGIN NULL; END;", plsqlparser.Options{Logger: logger}); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	if !strings.Contains(s, `msg="parse done" rule=sql_script`) {
+		t.Errorf("no parse time logged: %s", s)
+	}
+	if strings.Contains(s, "parse enter") {
+		t.Errorf("rules logged at debug level: %s", s)
+	}
+}
@@ -5,27 +5,29 @@
 package plsqlparser
 
 import (
-	"fmt"
 	"io"
-	"log"
-	"strings"
-	"unicode/utf8"
 
 	"github.com/pkg/errors"
 )
 
 var Warning = errors.New("WARNING")
 
+// InsertInto is an INSERT statement.
 type InsertInto struct {
 	Table    string
 	IsSelect bool
 	Fields   []string
-	Values   []Expression
+	// Values are the expressions of the VALUES clause, or the Fields of the Select.
+	Values []Expression
+	// Select is the query of an INSERT ... SELECT (filled by Parse only).
+	Select *SelectStatement
 }
 
-// Parse the text, naively.
-func (ii *InsertInto) Parse(text string) error {
-	fmt.Println(text)
+// ParseNaive parses the text with a token state machine, without the grammar.
+//
+// It understands only the simplest INSERT statements, the values are
+// the flat list of tokens after VALUES. See Parse.
+func (ii *InsertInto) ParseNaive(text string) error {
 	var mode uint8
 
 	gettok2 := func() Token {
@@ -88,7 +90,6 @@ func (ii *InsertInto) Parse(text string) error {
 			ii.Values = append(ii.Values, Expression{Value: tok.Value})
 
 		case 7:
-			log.Printf("%d%q rem:%d", tok.Type, tok.Value, len(text))
 			toks, err := slurpTokens(nil, gettok2, nil)
 			if err != nil {
 				return err
@@ -103,10 +104,17 @@ func (ii *InsertInto) Parse(text string) error {
 	return nil
 }
 
+// SelectStatement is a query.
 type SelectStatement struct {
 	Fields []Expression
+	// Aliases are the aliases of the Fields, "" where there is none (filled by ParseSelectStatement only).
+	Aliases []string
+	From    []TableWithAlias
+	Where   *Expression
 }
 
+// ParseSelect parses the tokens naively: each field is the flat list of its tokens,
+// with the parenthesized parts grouped. See ParseSelectStatement.
 func ParseSelect(tokens []Token) (SelectStatement, error) {
 	ss := SelectStatement{Fields: []Expression{{}}}
 	var n int
@@ -124,21 +132,56 @@ func ParseSelect(tokens []Token) (SelectStatement, error) {
 				return ss, err
 			}
 			tokens = rest
-			ss.Fields[0] = Expression{Expr: sub}
+			last := &ss.Fields[len(ss.Fields)-1]
+			last.Expr = append(last.Expr, Expression{Expr: sub})
 
 		default:
-			ss.Fields[0].Expr = append(ss.Fields[0].Expr, Expression{Value: tok.Value})
+			last := &ss.Fields[len(ss.Fields)-1]
+			last.Expr = append(last.Expr, Expression{Value: tok.Value})
 		}
 	}
 	return ss, nil
 }
 
+// Expression is a node of an expression tree.
 type Expression struct {
+	Kind ExprKind
+	// Value is the name of the column or function, the operator, or the text of the literal.
 	Value string
-	Expr  Expressions
+	// Expr are the operands of the operator, the arguments of the function, or the elements of the list.
+	Expr Expressions
+	// Chunk is the text of the expression (filled from the parse tree only).
+	Chunk
 }
 type Expressions []Expression
 
+// ExprKind is the kind of an Expression.
+type ExprKind uint8
+
+const (
+	// TokenExpr is a token, or a parenthesized group of tokens of the naive parsers.
+	TokenExpr = ExprKind(iota)
+	// LiteralExpr is a string, number, date, NULL, TRUE...
+	LiteralExpr
+	// ColumnExpr is a (dotted) name of a column or variable.
+	ColumnExpr
+	// BindExpr is a bind variable, such as :1 or :NAME.
+	BindExpr
+	// FunctionExpr is a function call.
+	FunctionExpr
+	// OperatorExpr is an operator: AND, OR, NOT, IS NULL, =, IN, BETWEEN, LIKE, ||, +, PRIOR...
+	// The named arguments of functions are => operators.
+	OperatorExpr
+	// ListExpr is a parenthesized list of expressions, such as (1, 2) of IN.
+	ListExpr
+	// SubqueryExpr is a subquery, its Value is its text.
+	SubqueryExpr
+	// StarExpr is * or TABLE.*
+	StarExpr
+	// OtherExpr is not analyzed further (CASE, ...), its Value is its text.
+	OtherExpr
+)
+
 func ParseTillCloseParen(tokens []Token) ([]Token, Expressions, error) {
 	var expr Expressions
 	for len(tokens) > 0 {
@@ -177,11 +220,24 @@ func slurpTokens(tokens []Token, gettok func() Token, till func(Token) bool) ([]
 	return tokens, nil
 }
 
+// Token is a token of the text, see Tokenizer.
 type Token struct {
-	Type  TokenType
+	Type TokenType
+	// Value is the unquoted string, the name of the identifier, bind variable or label,
+	// the text of the comment, number or operator.
 	Value string
 	Err   error
+	// Chunk is the text of the token, with its rune offsets.
+	Chunk
+	// Line is 1-based, Column is 0-based.
+	Line, Column int
 }
+
+// Hidden reports whether the token is a space or a comment.
+func (t Token) Hidden() bool {
+	return t.Type == SpaceTok || t.Type == LineCommentTok || t.Type == BlockCommentTok
+}
+
 type TokenType uint8
 
 const (
@@ -195,66 +251,44 @@ const (
 	LineCommentTok
 	BlockCommentTok
 	EndTok
+	SpaceTok
+	BindTok
+	LabelTok
+	// SQLPlusTok is a PROMPT or @script line.
+	SQLPlusTok
 )
 
+// gettok returns the first non-space token of the text, and the rest of the text.
+//
+// Dotted names (SCHEMA.TABLE) are returned as one AtomTok.
 func gettok(text string) (Token, string) {
-	text = strings.TrimSpace(text)
-	if len(text) == 0 {
+	tz := NewTokenizer(text)
+	start := tz.pos
+	tok := tz.Next()
+	for tok.Type == SpaceTok {
+		start = tz.pos
+		tok = tz.Next()
+	}
+	if tok.Err == io.EOF {
 		return Token{Type: EndTok}, ""
 	}
-	if len(text) > 1 {
-		switch text[:2] {
-		case "--":
-			if i := strings.Index(text, "\n"); i >= 2 {
-				return Token{Type: LineCommentTok, Value: text[2:i]}, text[i+1:]
+	if tok.Type == AtomTok {
+		for {
+			pos := tz.pos
+			if dot := tz.Next(); dot.Type != OpTok || dot.Value != "." {
+				tz.pos = pos
+				break
 			}
-		case "/*":
-			if i := strings.Index(text, "*/"); i >= 2 {
-				return Token{Type: BlockCommentTok, Value: text[2:i]}, text[i+2:]
+			name := tz.Next()
+			if name.Type != AtomTok {
+				tz.pos = pos
+				break
 			}
-		case "||", ":=":
-			return Token{Type: OpTok, Value: text[:2]}, text[2:]
-		}
-	}
-	switch text[0] {
-	case '(':
-		return Token{Type: OpenParenTok}, text[1:]
-	case ')':
-		return Token{Type: CloseParenTok}, text[1:]
-	case ',':
-		return Token{Type: CommaTok}, text[1:]
-	case '\'':
-		if i := strings.IndexByte(text[1:], '\''); i >= 0 {
-			return Token{Type: StringTok, Value: text[1 : 1+i]}, text[1+i+1:]
-		}
-	case ';':
-		return Token{Type: EndTok}, text[1:]
-	case '-', '+', '=', '*', '<', '>', '/':
-		return Token{Type: OpTok, Value: text[:1]}, text[1:]
-	}
-	r, size := utf8.DecodeRuneInString(text)
-	if isDigit(r) {
-		var i int
-		if i = strings.IndexFunc(text[size:], notDigitDot); i < 0 {
-			i = len(text) - size
+			tok.Stop, tok.Text = name.Stop, text[start:tz.pos]
+			tok.Value = tok.Text
 		}
-		return Token{Type: NumberTok, Value: text[:size+i]}, text[size+i:]
 	}
-	if isBeginName(r) {
-		var i int
-		if i = strings.IndexFunc(text[size:], notInName); i < 0 {
-			i = len(text) - size
-		}
-		return Token{Type: AtomTok, Value: text[:size+i]}, text[size+i:]
-	}
-
-	return Token{Type: AtomTok, Err: errors.Errorf("unknown : %q", text)}, ""
+	return tok, text[tz.pos:]
 }
 
-func isBeginName(r rune) bool {
-	return r == '.' || r == '"' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || r == '_'
-}
-func isInName(r rune) bool    { return r == '.' || r == '"' || '0' <= r && r <= '9' || isBeginName(r) }
-func notInName(r rune) bool   { return !isInName(r) }
-func isDigit(r rune) bool     { return '0' <= r && r <= '9' }
-func notDigitDot(r rune) bool { return !(r == '.' || isDigit(r)) }
+func isDigit(r rune) bool { return '0' <= r && r <= '9' }
@@ -5,6 +5,7 @@
 package plsqlparser_test
 
 import (
+	"fmt"
 	"testing"
 
 	plsqlparser "github.com/UNO-SOFT/plsql-parser"
@@ -17,3 +18,16 @@ func TestParseExampleTest(t *testing.T) {
 	}
 	t.Log(p)
 }
+
+func TestParseToConvertMapAliases(t *testing.T) {
+	cm, err := plsqlparser.ParseToConvertMap(`INSERT INTO t SELECT a x, b AS y, c FROM u`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Select == nil || fmt.Sprintf("%v", cm.Select.Aliases) != "[X Y C]" {
+		t.Errorf("got %+v", cm.Select)
+	}
+	if _, err := plsqlparser.ParseToConvertMap(`INSERT INTO (a) SELECT FROM`); err == nil {
+		t.Error("wanted error")
+	}
+}
@@ -15820,15 +15820,15 @@ type PlSqlLexer struct {
 // however, if used within a Golang sync.Pool, the construction cost amortizes well and the
 // objects can be used in a thread-safe manner.
 func NewPlSqlLexer(input antlr.CharStream) *PlSqlLexer {
-	l := new(PlSqlLexer)
+	l := &PlSqlLexer{PlSqlBaseLexer: new(PlSqlBaseLexer)}
 	lexerDeserializer := antlr.NewATNDeserializer(nil)
 	lexerAtn := lexerDeserializer.DeserializeFromUInt16(serializedLexerAtn)
 	lexerDecisionToDFA := make([]*antlr.DFA, len(lexerAtn.DecisionToState))
 	for index, ds := range lexerAtn.DecisionToState {
 		lexerDecisionToDFA[index] = antlr.NewDFA(ds, index)
 	}
-	fmt.Printf("l=%#v input=%#v\n", l, input)
 	l.BaseLexer = antlr.NewBaseLexer(input)
+	l.Virt = l // GetAllTokens calls NextToken of PlSqlBaseLexer, which the predicates depend on
 	l.Interpreter = antlr.NewLexerATNSimulator(l, lexerAtn, lexerDecisionToDFA, antlr.NewPredictionContextCache())
 
 	l.channelNames = lexerChannelNames
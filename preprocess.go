@@ -0,0 +1,879 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CCFlags are the settings of the conditional compilation.
+type CCFlags struct {
+	// Version and Release of the target database, for DBMS_DB_VERSION (e.g. 19 and 0).
+	Version, Release int
+	// Flags are the values of the $$ inquiry directives: the PLSQL_CCFLAGS (see ParseCCFlags),
+	// and the compilation parameters (e.g. PLSQL_OPTIMIZE_LEVEL), as bool, int, string or nil.
+	// Names are case insensitive, undefined flags are NULL.
+	Flags map[string]interface{}
+	// Unit is the value of $$PLSQL_UNIT.
+	Unit string
+}
+
+// ParseCCFlags parses the PLSQL_CCFLAGS setting ("debug:TRUE, level:2") into flags.
+func ParseCCFlags(s string) (map[string]interface{}, error) {
+	flags := make(map[string]interface{})
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, ":")
+		if !ok {
+			return flags, fmt.Errorf("%q: no value", part)
+		}
+		name, value = strings.ToUpper(strings.TrimSpace(name)), strings.TrimSpace(value)
+		switch strings.ToUpper(value) {
+		case "TRUE":
+			flags[name] = true
+		case "FALSE":
+			flags[name] = false
+		case "NULL":
+			flags[name] = nil
+		default:
+			i, err := strconv.Atoi(value)
+			if err != nil {
+				return flags, fmt.Errorf("%q: value must be a boolean or integer: %w", part, err)
+			}
+			flags[name] = i
+		}
+	}
+	return flags, nil
+}
+
+// Preprocessed is the effective source after the conditional compilation.
+//
+// The directives and the text of the unselected branches are replaced with spaces,
+// keeping the newlines, so the positions in the Text are the same as in the original
+// text, except after the replaced inquiry directives ($$FLAG).
+type Preprocessed struct {
+	Text string
+	// Errors are the $ERROR directives of the selected branches.
+	Errors []Diagnostic
+
+	// positions are the positions in the original text of the runes of Text.
+	positions []int
+}
+
+// Position returns the position in the original text of the rune offset of the Text.
+func (pp Preprocessed) Position(offset int) int {
+	if offset < 0 || len(pp.positions) == 0 {
+		return 0
+	}
+	if offset >= len(pp.positions) {
+		return pp.positions[len(pp.positions)-1] + 1 + offset - len(pp.positions)
+	}
+	return pp.positions[offset]
+}
+
+// Preprocess evaluates the $IF, $ELSIF, $ELSE, $END and $ERROR directives of the text,
+// and replaces the $$ inquiry directives with their values, as the compiler would with the flags.
+//
+// The conditions can use the DBMS_DB_VERSION constants, the inquiry directives,
+// literals, comparisons, IS [NOT] NULL, NOT, AND and OR.
+func Preprocess(text string, flags CCFlags) (Preprocessed, error) {
+	rs := []rune(text)
+	nodes, err := parseCC(rs)
+	if err != nil {
+		return Preprocessed{}, err
+	}
+	e := &ccEmitter{rs: rs, flags: flags}
+	e.choose = func(ifn *ccIf) (int, error) {
+		for i, b := range ifn.branches {
+			if b.cond == nil {
+				return i, nil
+			}
+			v, err := e.eval(b.cond)
+			if err != nil {
+				return -1, err
+			}
+			if ok, _ := v.(bool); ok {
+				return i, nil
+			}
+		}
+		return -1, nil
+	}
+	if err := e.emit(nodes); err != nil {
+		return Preprocessed{}, err
+	}
+	return e.result(), nil
+}
+
+// PreprocessBranches returns variants of the text which together contain all the branches
+// of the conditional compilation, for linting: the k-th variant selects the k-th branch of each $IF
+// (or its last branch, if it has fewer).
+//
+// The conditions are not evaluated, and the inquiry directives are replaced by NULL,
+// except $$PLSQL_LINE and $$PLSQL_UNIT.
+func PreprocessBranches(text string) ([]Preprocessed, error) {
+	rs := []rune(text)
+	nodes, err := parseCC(rs)
+	if err != nil {
+		return nil, err
+	}
+	n := 1
+	var count func([]ccNode)
+	count = func(nodes []ccNode) {
+		for _, node := range nodes {
+			if ifn, ok := node.(*ccIf); ok {
+				if k := ifn.options(); k > n {
+					n = k
+				}
+				for _, b := range ifn.branches {
+					count(b.body)
+				}
+			}
+		}
+	}
+	count(nodes)
+
+	variants := make([]Preprocessed, 0, n)
+	for k := 0; k < n; k++ {
+		e := &ccEmitter{rs: rs, lenient: true}
+		e.choose = func(ifn *ccIf) (int, error) {
+			i := k
+			if o := ifn.options(); i >= o {
+				i = o - 1
+			}
+			if i >= len(ifn.branches) {
+				return -1, nil
+			}
+			return i, nil
+		}
+		if err := e.emit(nodes); err != nil {
+			return variants, err
+		}
+		variants = append(variants, e.result())
+	}
+	return variants, nil
+}
+
+// ccNode is a ccText, ccInquiry, ccIf or ccError.
+type ccNode interface{}
+
+// ccText is a range of the text without directives.
+type ccText struct{ start, end int }
+
+// ccInquiry is a $$NAME inquiry directive.
+type ccInquiry struct {
+	start, end int
+	name       string
+}
+
+// ccIf is a $IF ... $END, from the $ of $IF till after $END.
+type ccIf struct {
+	start, end int
+	branches   []ccBranch
+}
+
+// options is the number of choices: the branches, and an empty one if there is no $ELSE.
+func (ifn *ccIf) options() int {
+	if ifn.branches[len(ifn.branches)-1].cond == nil {
+		return len(ifn.branches)
+	}
+	return len(ifn.branches) + 1
+}
+
+type ccBranch struct {
+	// cond is nil for $ELSE.
+	cond               []ccToken
+	bodyStart, bodyEnd int
+	body               []ccNode
+}
+
+// ccError is an $ERROR ... $END.
+type ccError struct {
+	start, end int
+	expr       []ccToken
+}
+
+type ccToken struct {
+	text string
+	pos  int
+}
+
+// parseCC parses the directives of the text.
+func parseCC(rs []rune) ([]ccNode, error) {
+	p := &ccParser{rs: rs}
+	nodes, stop, err := p.body()
+	if err == nil && stop != "" {
+		err = p.errorf(p.i, "unexpected $%s", stop)
+	}
+	return nodes, err
+}
+
+type ccParser struct {
+	rs []rune
+	i  int
+}
+
+func (p *ccParser) errorf(pos int, format string, args ...interface{}) error {
+	line, col := lineColumn(p.rs, pos)
+	return fmt.Errorf("%d:%d: %s", line, col, fmt.Sprintf(format, args...))
+}
+
+// body parses till the next $ELSIF, $ELSE, $END or $THEN, returning its name (without the $), or "" at the end of the text.
+// The position is left at the start of the returned directive.
+func (p *ccParser) body() ([]ccNode, string, error) {
+	var nodes []ccNode
+	textStart := p.i
+	flush := func() {
+		if textStart < p.i {
+			nodes = append(nodes, &ccText{start: textStart, end: p.i})
+		}
+	}
+	for {
+		p.skipToDollar()
+		if p.i >= len(p.rs) {
+			flush()
+			return nodes, "", nil
+		}
+		start := p.i
+		if p.i+1 < len(p.rs) && p.rs[p.i+1] == '$' {
+			name := p.word(p.i + 2)
+			if name == "" {
+				p.i += 2
+				continue
+			}
+			flush()
+			p.i += 2 + len([]rune(name))
+			nodes = append(nodes, &ccInquiry{start: start, end: p.i, name: strings.ToUpper(name)})
+			textStart = p.i
+			continue
+		}
+		name := strings.ToUpper(p.word(p.i + 1))
+		switch name {
+		case "IF":
+			flush()
+			p.i += 3
+			ifn, err := p.ifDirective(start)
+			if err != nil {
+				return nodes, "", err
+			}
+			nodes = append(nodes, ifn)
+			textStart = p.i
+		case "ERROR":
+			flush()
+			p.i += 6
+			expr, err := p.tokens("END")
+			if err != nil {
+				return nodes, "", err
+			}
+			p.i += 4
+			nodes = append(nodes, &ccError{start: start, end: p.i, expr: expr})
+			textStart = p.i
+		case "ELSIF", "ELSE", "END", "THEN":
+			flush()
+			return nodes, name, nil
+		default:
+			p.i++
+		}
+	}
+}
+
+// ifDirective parses the rest of the $IF, from after "$IF" till after "$END".
+func (p *ccParser) ifDirective(start int) (*ccIf, error) {
+	ifn := &ccIf{start: start}
+	for directive := "IF"; ; {
+		var b ccBranch
+		if directive != "ELSE" {
+			cond, err := p.tokens("THEN")
+			if err != nil {
+				return nil, err
+			}
+			if len(cond) == 0 {
+				return nil, p.errorf(p.i, "missing condition of $%s", directive)
+			}
+			b.cond = cond
+			p.i += 5
+		}
+		b.bodyStart = p.i
+		body, stop, err := p.body()
+		if err != nil {
+			return nil, err
+		}
+		b.body, b.bodyEnd = body, p.i
+		ifn.branches = append(ifn.branches, b)
+		switch {
+		case stop == "":
+			return nil, p.errorf(start, "missing $END of $IF")
+		case stop == "THEN" || directive == "ELSE" && stop != "END":
+			return nil, p.errorf(p.i, "unexpected $%s", stop)
+		}
+		p.i += 1 + len(stop)
+		if stop == "END" {
+			ifn.end = p.i
+			return ifn, nil
+		}
+		directive = stop
+	}
+}
+
+// tokens reads the tokens of an expression till the $ directive, leaving the position at its $.
+func (p *ccParser) tokens(till string) ([]ccToken, error) {
+	var tokens []ccToken
+	start := p.i
+	for {
+		p.skipSpace()
+		if p.i >= len(p.rs) {
+			return tokens, p.errorf(start, "missing $%s", till)
+		}
+		pos, r := p.i, p.rs[p.i]
+		switch {
+		case r == '$' && p.i+1 < len(p.rs) && p.rs[p.i+1] == '$':
+			p.i += 2 + len([]rune(p.word(p.i+2)))
+		case r == '$':
+			name := strings.ToUpper(p.word(p.i + 1))
+			if name == till {
+				return tokens, nil
+			}
+			return tokens, p.errorf(pos, "unexpected $%s, wanted $%s", name, till)
+		case r == '\'':
+			p.skipString()
+		case isIdentRune(r) || r == '"':
+			for p.i < len(p.rs) && (isIdentRune(p.rs[p.i]) || p.rs[p.i] == '.' || p.rs[p.i] == '"') {
+				p.i++
+			}
+		default:
+			p.i++
+			if p.i < len(p.rs) {
+				switch string(p.rs[p.i-1 : p.i+1]) {
+				case "<>", "!=", "~=", "^=", "<=", ">=", "||":
+					p.i++
+				}
+			}
+		}
+		tokens = append(tokens, ccToken{text: string(p.rs[pos:p.i]), pos: pos})
+	}
+}
+
+// word returns the identifier starting at i.
+func (p *ccParser) word(i int) string {
+	j := i
+	for j < len(p.rs) && isIdentRune(p.rs[j]) {
+		j++
+	}
+	if j == i || !unicode.IsLetter(p.rs[i]) {
+		return ""
+	}
+	return string(p.rs[i:j])
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$' || r == '#'
+}
+
+// skipToDollar advances to the next $ which is not in a comment, literal or identifier (V$SESSION).
+func (p *ccParser) skipToDollar() {
+	for p.i < len(p.rs) {
+		r := p.rs[p.i]
+		switch {
+		case r == '$':
+			if p.i == 0 || !isIdentRune(p.rs[p.i-1]) {
+				return
+			}
+			p.i++
+		case r == '-' || r == '/':
+			if !p.skipComment() {
+				p.i++
+			}
+		case r == '\'':
+			p.skipString()
+		case r == '"':
+			for p.i++; p.i < len(p.rs) && p.rs[p.i] != '"'; p.i++ {
+			}
+			p.i++
+		case isIdentRune(r):
+			j := p.i
+			if (r == 'n' || r == 'N') && j+1 < len(p.rs) {
+				j++
+			}
+			if (p.rs[j] == 'q' || p.rs[j] == 'Q') && j+1 < len(p.rs) && p.rs[j+1] == '\'' {
+				p.i = j + 1
+				p.skipQString()
+				continue
+			}
+			for p.i < len(p.rs) && isIdentRune(p.rs[p.i]) {
+				p.i++
+			}
+		default:
+			p.i++
+		}
+	}
+}
+
+// skipComment skips the -- or /* */ comment at the position, reporting whether there was one.
+func (p *ccParser) skipComment() bool {
+	if p.i+1 >= len(p.rs) {
+		return false
+	}
+	switch string(p.rs[p.i : p.i+2]) {
+	case "--":
+		for p.i < len(p.rs) && p.rs[p.i] != '\n' {
+			p.i++
+		}
+		return true
+	case "/*":
+		p.i += 2
+		for p.i < len(p.rs) && !(p.rs[p.i] == '*' && p.i+1 < len(p.rs) && p.rs[p.i+1] == '/') {
+			p.i++
+		}
+		p.i += 2
+		if p.i > len(p.rs) {
+			p.i = len(p.rs)
+		}
+		return true
+	}
+	return false
+}
+
+func (p *ccParser) skipSpace() {
+	for p.i < len(p.rs) {
+		if unicode.IsSpace(p.rs[p.i]) {
+			p.i++
+		} else if !p.skipComment() {
+			return
+		}
+	}
+}
+
+// skipString skips the 'string' at the position.
+func (p *ccParser) skipString() {
+	for p.i++; p.i < len(p.rs); p.i++ {
+		if p.rs[p.i] == '\'' {
+			if p.i+1 < len(p.rs) && p.rs[p.i+1] == '\'' {
+				p.i++
+				continue
+			}
+			p.i++
+			return
+		}
+	}
+}
+
+// skipQString skips the '[string]' part of a q'[string]' at the position.
+func (p *ccParser) skipQString() {
+	if p.i+1 >= len(p.rs) {
+		p.i = len(p.rs)
+		return
+	}
+	closing := p.rs[p.i+1]
+	switch closing {
+	case '[':
+		closing = ']'
+	case '{':
+		closing = '}'
+	case '(':
+		closing = ')'
+	case '<':
+		closing = '>'
+	}
+	for p.i += 2; p.i < len(p.rs); p.i++ {
+		if p.rs[p.i] == closing && p.i+1 < len(p.rs) && p.rs[p.i+1] == '\'' {
+			p.i += 2
+			return
+		}
+	}
+}
+
+// lineColumn returns the 1-based line and column of the position.
+func lineColumn(rs []rune, pos int) (line, column int) {
+	line, column = 1, 1
+	for i := 0; i < pos && i < len(rs); i++ {
+		if rs[i] == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+type ccEmitter struct {
+	rs        []rune
+	flags     CCFlags
+	lenient   bool
+	choose    func(*ccIf) (int, error)
+	out       []rune
+	positions []int
+	errors    []Diagnostic
+}
+
+func (e *ccEmitter) result() Preprocessed {
+	return Preprocessed{Text: string(e.out), positions: e.positions, Errors: e.errors}
+}
+
+func (e *ccEmitter) text(from, to int) {
+	for i := from; i < to; i++ {
+		e.out = append(e.out, e.rs[i])
+		e.positions = append(e.positions, i)
+	}
+}
+
+// blank the text, keeping the newlines.
+func (e *ccEmitter) blank(from, to int) {
+	for i := from; i < to; i++ {
+		r := e.rs[i]
+		if r != '\n' && r != '\r' {
+			r = ' '
+		}
+		e.out = append(e.out, r)
+		e.positions = append(e.positions, i)
+	}
+}
+
+func (e *ccEmitter) emit(nodes []ccNode) error {
+	for _, node := range nodes {
+		switch x := node.(type) {
+		case *ccText:
+			e.text(x.start, x.end)
+		case *ccInquiry:
+			v, err := e.inquiry(x.name, x.start)
+			if err != nil {
+				return err
+			}
+			for _, r := range ccLiteral(v) {
+				e.out = append(e.out, r)
+				e.positions = append(e.positions, x.start)
+			}
+			if n := x.end - x.start - len([]rune(ccLiteral(v))); n > 0 {
+				// keep the columns after it
+				e.blank(x.end-n, x.end)
+			}
+		case *ccIf:
+			i, err := e.choose(x)
+			if err != nil {
+				return err
+			}
+			if i < 0 {
+				e.blank(x.start, x.end)
+				continue
+			}
+			b := x.branches[i]
+			e.blank(x.start, b.bodyStart)
+			if err := e.emit(b.body); err != nil {
+				return err
+			}
+			e.blank(b.bodyEnd, x.end)
+		case *ccError:
+			e.blank(x.start, x.end)
+			v, err := e.eval(x.expr)
+			if err != nil {
+				return err
+			}
+			msg, ok := v.(string)
+			if !ok {
+				msg = ccLiteral(v)
+			}
+			line, col := lineColumn(e.rs, x.start)
+			e.errors = append(e.errors, Diagnostic{
+				Chunk: Chunk{Text: string(e.rs[x.start:x.end]), Start: x.start, Stop: x.end - 1},
+				Line:  line, Column: col, Message: msg,
+			})
+		}
+	}
+	return nil
+}
+
+// ccLiteral returns the PL/SQL literal of the value.
+func ccLiteral(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if x {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int:
+		return strconv.Itoa(x)
+	case string:
+		return "'" + strings.ReplaceAll(x, "'", "''") + "'"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func (e *ccEmitter) inquiry(name string, pos int) (interface{}, error) {
+	switch name {
+	case "PLSQL_LINE":
+		line, _ := lineColumn(e.rs, pos)
+		return line, nil
+	case "PLSQL_UNIT":
+		if e.flags.Unit == "" {
+			return nil, nil
+		}
+		return e.flags.Unit, nil
+	}
+	for k, v := range e.flags.Flags {
+		if strings.EqualFold(k, name) {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+// eval evaluates the static expression. Errors are ignored when lenient, returning the text of the expression.
+func (e *ccEmitter) eval(tokens []ccToken) (interface{}, error) {
+	ev := &ccEval{e: e, tokens: tokens}
+	v, err := ev.or()
+	if err == nil && ev.i < len(tokens) {
+		err = ev.errorf("unexpected %q", tokens[ev.i].text)
+	}
+	if err != nil && e.lenient {
+		texts := make([]string, len(tokens))
+		for i, t := range tokens {
+			texts[i] = t.text
+		}
+		return strings.Join(texts, " "), nil
+	}
+	return v, err
+}
+
+type ccEval struct {
+	e      *ccEmitter
+	tokens []ccToken
+	i      int
+}
+
+func (ev *ccEval) errorf(format string, args ...interface{}) error {
+	pos := len(ev.e.rs)
+	if ev.i < len(ev.tokens) {
+		pos = ev.tokens[ev.i].pos
+	} else if len(ev.tokens) != 0 {
+		pos = ev.tokens[len(ev.tokens)-1].pos
+	}
+	line, col := lineColumn(ev.e.rs, pos)
+	return fmt.Errorf("%d:%d: %s", line, col, fmt.Sprintf(format, args...))
+}
+
+func (ev *ccEval) peek() string {
+	if ev.i < len(ev.tokens) {
+		return strings.ToUpper(ev.tokens[ev.i].text)
+	}
+	return ""
+}
+
+func (ev *ccEval) or() (interface{}, error) {
+	v, err := ev.and()
+	for err == nil && ev.peek() == "OR" {
+		ev.i++
+		var w interface{}
+		if w, err = ev.and(); err == nil {
+			// three-valued logic
+			a, aok := v.(bool)
+			b, bok := w.(bool)
+			switch {
+			case aok && a || bok && b:
+				v = true
+			case aok && bok:
+				v = false
+			default:
+				v = nil
+			}
+		}
+	}
+	return v, err
+}
+
+func (ev *ccEval) and() (interface{}, error) {
+	v, err := ev.not()
+	for err == nil && ev.peek() == "AND" {
+		ev.i++
+		var w interface{}
+		if w, err = ev.not(); err == nil {
+			a, aok := v.(bool)
+			b, bok := w.(bool)
+			switch {
+			case aok && !a || bok && !b:
+				v = false
+			case aok && bok:
+				v = true
+			default:
+				v = nil
+			}
+		}
+	}
+	return v, err
+}
+
+func (ev *ccEval) not() (interface{}, error) {
+	if ev.peek() != "NOT" {
+		return ev.comparison()
+	}
+	ev.i++
+	v, err := ev.not()
+	if b, ok := v.(bool); ok {
+		return !b, err
+	}
+	return nil, err
+}
+
+func (ev *ccEval) comparison() (interface{}, error) {
+	v, err := ev.concatenation()
+	if err != nil {
+		return v, err
+	}
+	op := ev.peek()
+	switch op {
+	case "IS":
+		ev.i++
+		not := ev.peek() == "NOT"
+		if not {
+			ev.i++
+		}
+		if ev.peek() != "NULL" {
+			return nil, ev.errorf("wanted NULL")
+		}
+		ev.i++
+		return (v == nil) != not, nil
+	case "=", "<>", "!=", "~=", "^=", "<", ">", "<=", ">=":
+	default:
+		return v, nil
+	}
+	ev.i++
+	w, err := ev.concatenation()
+	if err != nil || v == nil || w == nil {
+		return nil, err
+	}
+	var c int
+	switch a := v.(type) {
+	case int:
+		b, ok := w.(int)
+		if !ok {
+			return nil, ev.errorf("cannot compare %v to %v", v, w)
+		}
+		c = a - b
+	case string:
+		b, ok := w.(string)
+		if !ok {
+			return nil, ev.errorf("cannot compare %v to %v", v, w)
+		}
+		c = strings.Compare(a, b)
+	case bool:
+		b, ok := w.(bool)
+		if !ok || op != "=" && op != "<>" && op != "!=" && op != "~=" && op != "^=" {
+			return nil, ev.errorf("cannot compare %v to %v", v, w)
+		}
+		if a != b {
+			c = 1
+		}
+	}
+	switch op {
+	case "=":
+		return c == 0, nil
+	case "<":
+		return c < 0, nil
+	case ">":
+		return c > 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">=":
+		return c >= 0, nil
+	}
+	return c != 0, nil
+}
+
+func (ev *ccEval) concatenation() (interface{}, error) {
+	v, err := ev.primary()
+	if err != nil || ev.peek() != "||" {
+		return v, err
+	}
+	s := ccString(v)
+	for err == nil && ev.peek() == "||" {
+		ev.i++
+		if v, err = ev.primary(); err == nil {
+			s += ccString(v)
+		}
+	}
+	return s, err
+}
+
+// ccString is the value as concatenated to a string.
+func ccString(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	}
+	return ccLiteral(v)
+}
+
+func (ev *ccEval) primary() (interface{}, error) {
+	if ev.i >= len(ev.tokens) {
+		return nil, ev.errorf("missing operand")
+	}
+	t := ev.tokens[ev.i]
+	text := strings.ToUpper(t.text)
+	ev.i++
+	switch {
+	case text == "(":
+		v, err := ev.or()
+		if err == nil && ev.peek() != ")" {
+			err = ev.errorf("missing )")
+		}
+		ev.i++
+		return v, err
+	case text == "TRUE":
+		return true, nil
+	case text == "FALSE":
+		return false, nil
+	case text == "NULL":
+		return nil, nil
+	case strings.HasPrefix(text, "$$"):
+		return ev.e.inquiry(text[2:], t.pos)
+	case strings.HasPrefix(text, "'"):
+		return strings.ReplaceAll(t.text[1:len(t.text)-1], "''", "'"), nil
+	case text[0] >= '0' && text[0] <= '9':
+		i, err := strconv.Atoi(text)
+		if err != nil {
+			ev.i--
+			return nil, ev.errorf("%q: %v", t.text, err)
+		}
+		return i, nil
+	case strings.HasPrefix(text, "DBMS_DB_VERSION.") || strings.HasPrefix(text, "SYS.DBMS_DB_VERSION."):
+		if v, ok := ev.e.dbVersion(text[strings.LastIndexByte(text, '.')+1:]); ok {
+			return v, nil
+		}
+	}
+	ev.i--
+	return nil, ev.errorf("unknown %q in static expression", t.text)
+}
+
+// dbVersion returns the value of the DBMS_DB_VERSION constant: VERSION, RELEASE or VER_LE_v[_r].
+func (e *ccEmitter) dbVersion(name string) (interface{}, bool) {
+	switch name {
+	case "VERSION":
+		return e.flags.Version, true
+	case "RELEASE":
+		return e.flags.Release, true
+	}
+	if !strings.HasPrefix(name, "VER_LE_") {
+		return nil, false
+	}
+	v, r, hasRelease := strings.Cut(name[len("VER_LE_"):], "_")
+	version, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, false
+	}
+	if !hasRelease {
+		return e.flags.Version <= version, true
+	}
+	release, err := strconv.Atoi(r)
+	if err != nil {
+		return nil, false
+	}
+	return e.flags.Version < version || e.flags.Version == version && e.flags.Release <= release, true
+}
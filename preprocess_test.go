@@ -0,0 +1,100 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+const ccSource = `CREATE OR REPLACE PACKAGE BODY pkg IS
+  PROCEDURE p IS
+  BEGIN
+    $IF DBMS_DB_VERSION.VER_LE_12 $THEN
+      old_way; -- $END in a comment
+    $ELSIF $$debug AND $$level >= 2 $THEN
+      DBMS_OUTPUT.PUT_LINE('debug $IF ' || $$PLSQL_UNIT || ':' || $$PLSQL_LINE);
+    $ELSE
+      SELECT COUNT(*) INTO n FROM v$session;
+    $END
+    $IF $$level IS NULL $THEN
+      $ERROR 'level is not set in ' || $$PLSQL_UNIT $END
+    $END
+  END p;
+END pkg;
+`
+
+func TestPreprocess(t *testing.T) {
+	flags, err := plsqlparser.ParseCCFlags("debug:TRUE, level:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		Name  string
+		Flags plsqlparser.CCFlags
+		Want  []string
+		Error string
+	}{
+		{Name: "old", Flags: plsqlparser.CCFlags{Version: 12, Release: 2, Flags: flags},
+			Want: []string{"old_way; -- $END in a comment"}},
+		{Name: "debug", Flags: plsqlparser.CCFlags{Version: 19, Flags: flags, Unit: "PKG"},
+			Want: []string{"DBMS_OUTPUT.PUT_LINE('debug $IF ' || 'PKG' || ':' || 7 );"}},
+		{Name: "else", Flags: plsqlparser.CCFlags{Version: 19, Unit: "PKG"},
+			Want: []string{"SELECT COUNT(*) INTO n FROM v$session;"}, Error: "level is not set in PKG"},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			pp, err := plsqlparser.Preprocess(ccSource, tc.Flags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var lines []string
+			for _, line := range strings.Split(pp.Text, "\n") {
+				if line = strings.Join(strings.Fields(line), " "); line != "" {
+					lines = append(lines, line)
+				}
+			}
+			want := append(append([]string{"CREATE OR REPLACE PACKAGE BODY pkg IS", "PROCEDURE p IS", "BEGIN"},
+				tc.Want...), "END p;", "END pkg;")
+			if got := strings.Join(lines, "\n"); got != strings.Join(want, "\n") {
+				t.Errorf("got\n%s\nwanted\n%s", got, strings.Join(want, "\n"))
+			}
+			if strings.Count(pp.Text, "\n") != strings.Count(ccSource, "\n") {
+				t.Error("lines are not kept")
+			}
+			if i := strings.Index(pp.Text, "END p;"); pp.Position(i) != strings.Index(ccSource, "END p;") {
+				t.Errorf("position of END p: got %d, wanted %d", pp.Position(i), strings.Index(ccSource, "END p;"))
+			}
+			var errs []string
+			for _, d := range pp.Errors {
+				errs = append(errs, d.Message)
+			}
+			if got := strings.Join(errs, "\n"); got != tc.Error {
+				t.Errorf("got errors %q, wanted %q", got, tc.Error)
+			}
+		})
+	}
+
+	if _, err := plsqlparser.Preprocess("BEGIN $IF x $THEN NULL; END;", plsqlparser.CCFlags{}); err == nil {
+		t.Error("wanted error for missing $END")
+	}
+}
+
+func TestPreprocessBranches(t *testing.T) {
+	variants, err := plsqlparser.PreprocessBranches(ccSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(variants) != 3 {
+		t.Fatalf("got %d variants, wanted 3", len(variants))
+	}
+	for i, want := range []string{"old_way;", "DBMS_OUTPUT.PUT_LINE", "SELECT COUNT(*)"} {
+		if !strings.Contains(variants[i].Text, want) {
+			t.Errorf("%d. %q does not contain %q", i, variants[i].Text, want)
+		}
+	}
+}
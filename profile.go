@@ -0,0 +1,278 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/UNO-SOFT/plsql-parser/internal/casefold"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// Profile is the profile of parsing files, see ProfileParse.
+type Profile struct {
+	// Rules are the invoked rules, the slowest first.
+	// The Time of a rule includes the time of the rules it invokes.
+	Rules []RuleProfile
+	// Decisions are the invoked decisions (adaptive predictions), the slowest first.
+	Decisions []DecisionProfile
+	// Ambiguities are the ambiguous inputs, in the order of the files.
+	Ambiguities []Ambiguity
+	// Time is the total time of the parses.
+	Time time.Duration
+}
+
+// RuleProfile is the profile of a grammar rule.
+type RuleProfile struct {
+	Rule        string
+	Invocations int
+	Time        time.Duration
+}
+
+// DecisionProfile is the profile of a decision of the grammar:
+// a point of a rule where the parser predicts which alternative to take.
+type DecisionProfile struct {
+	Decision    int
+	Rule        string
+	Invocations int
+	Time        time.Duration
+	// TotalLookahead and MaxLookahead are the numbers of tokens looked at to predict.
+	TotalLookahead, MaxLookahead int
+	// LLFallbacks is the number of predictions SLL could not decide, and retried with full context (LL).
+	LLFallbacks int
+	// ContextSensitivities is the number of LL predictions differing from the SLL conflict.
+	ContextSensitivities int
+	// Ambiguities is the number of ambiguous predictions.
+	Ambiguities int
+}
+
+// Ambiguity is an input matched by several alternatives of a decision.
+// The parser chooses the first (minimal) one.
+type Ambiguity struct {
+	File         string
+	Decision     int
+	Rule         string
+	Alternatives []int
+	// Exact is true if the ambiguity is certain, not just detected as a conflict.
+	Exact bool
+	// Chunk is the ambiguous text.
+	Chunk
+	Line, Column int
+}
+
+func (a Ambiguity) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s (decision %d) alternatives %v: %q", a.File, a.Line, a.Column, a.Rule, a.Decision, a.Alternatives, a.Text)
+}
+
+// ProfileParse parses the files as SQL scripts, and returns the time spent in each rule and decision,
+// the SLL to LL fallbacks and the ambiguities.
+//
+// The Go ANTLR runtime has no ProfilingATNSimulator, so the predictions are timed by
+// observing the parser's token stream, and the fallbacks and ambiguities are collected
+// from the error listener reports. Profiling slows down the parse.
+//
+// Syntax errors are returned with the profile.
+func ProfileParse(files []File, opts ...Options) (*Profile, error) {
+	o := options(opts)
+	var pr *profiler
+	var errs Errors
+	start := time.Now()
+	for _, f := range files {
+		lexer := plsql.NewPlSqlLexer(casefold.NewStream(f.Text))
+		lexer.RemoveErrorListeners()
+		next := &profiler{
+			DefaultErrorListener: antlr.NewDefaultErrorListener(),
+			CommonTokenStream:    antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel),
+			file:                 f.Name, text: []rune(f.Text),
+		}
+		parser := plsql.NewPlSqlParser(next)
+		parser.BuildParseTrees = true
+		if pr == nil {
+			next.init(parser)
+		} else {
+			next.share(pr, parser)
+		}
+		pr = next
+		el := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+		parser.RemoveErrorListeners()
+		parser.AddErrorListener(el)
+		parser.AddErrorListener(pr)
+		parser.AddParseListener(pr)
+		o.trace(parser)
+		parser.Sql_script()
+		for _, err := range el.slice {
+			errs.Append(fmt.Errorf("%s:%w", f.Name, err))
+		}
+	}
+	if pr == nil {
+		return &Profile{}, nil
+	}
+	p := pr.profile()
+	p.Time = time.Since(start)
+	if len(errs.slice) != 0 {
+		return p, &errs
+	}
+	return p, nil
+}
+
+// profiler observes the adaptive predictions of the parser through its token stream:
+// a prediction marks the stream, consumes the lookahead tokens, and releases it.
+type profiler struct {
+	*antlr.DefaultErrorListener
+	*antlr.CommonTokenStream
+	parser *plsql.PlSqlParser
+	file   string
+	text   []rune
+
+	// decisionOf is the decision of the ATN states
+	decisionOf  map[int]int
+	rules       []RuleProfile
+	decisions   []DecisionProfile
+	ambiguities *[]Ambiguity
+
+	stack      []time.Time
+	predicting bool
+	decision   int
+	start      time.Time
+	consumed   int
+}
+
+func (pr *profiler) init(parser *plsql.PlSqlParser) {
+	pr.parser = parser
+	atn := parser.GetATN()
+	pr.decisionOf = make(map[int]int, len(atn.DecisionToState))
+	pr.decisions = make([]DecisionProfile, len(atn.DecisionToState))
+	names := parser.GetRuleNames()
+	for i, ds := range atn.DecisionToState {
+		pr.decisionOf[ds.GetStateNumber()] = i
+		pr.decisions[i] = DecisionProfile{Decision: i, Rule: names[ds.GetRuleIndex()]}
+	}
+	pr.rules = make([]RuleProfile, len(names))
+	for i, name := range names {
+		pr.rules[i].Rule = name
+	}
+	pr.ambiguities = new([]Ambiguity)
+}
+
+// share the statistics of the other profiler.
+func (pr *profiler) share(other *profiler, parser *plsql.PlSqlParser) {
+	pr.parser = parser
+	pr.decisionOf, pr.rules, pr.decisions, pr.ambiguities = other.decisionOf, other.rules, other.decisions, other.ambiguities
+}
+
+func (pr *profiler) profile() *Profile {
+	p := Profile{Ambiguities: *pr.ambiguities}
+	for _, r := range pr.rules {
+		if r.Invocations != 0 {
+			p.Rules = append(p.Rules, r)
+		}
+	}
+	for _, d := range pr.decisions {
+		if d.Invocations != 0 {
+			p.Decisions = append(p.Decisions, d)
+		}
+	}
+	sort.SliceStable(p.Rules, func(i, j int) bool { return p.Rules[i].Time > p.Rules[j].Time })
+	sort.SliceStable(p.Decisions, func(i, j int) bool { return p.Decisions[i].Time > p.Decisions[j].Time })
+	return &p
+}
+
+func (pr *profiler) Mark() int {
+	if !pr.predicting {
+		d, ok := pr.decisionOf[pr.parser.GetState()]
+		if !ok {
+			d = -1
+		}
+		pr.predicting, pr.decision, pr.consumed, pr.start = true, d, 0, time.Now()
+	}
+	return pr.CommonTokenStream.Mark()
+}
+
+func (pr *profiler) Consume() {
+	if pr.predicting {
+		pr.consumed++
+	}
+	pr.CommonTokenStream.Consume()
+}
+
+func (pr *profiler) Release(marker int) {
+	pr.CommonTokenStream.Release(marker)
+	if !pr.predicting {
+		return
+	}
+	pr.predicting = false
+	if pr.decision < 0 {
+		return
+	}
+	d := &pr.decisions[pr.decision]
+	d.Invocations++
+	d.Time += time.Since(pr.start)
+	lookahead := pr.consumed + 1
+	d.TotalLookahead += lookahead
+	if lookahead > d.MaxLookahead {
+		d.MaxLookahead = lookahead
+	}
+}
+
+func (pr *profiler) VisitTerminal(node antlr.TerminalNode) {}
+func (pr *profiler) VisitErrorNode(node antlr.ErrorNode)   {}
+func (pr *profiler) EnterEveryRule(ctx antlr.ParserRuleContext) {
+	pr.stack = append(pr.stack, time.Now())
+}
+func (pr *profiler) ExitEveryRule(ctx antlr.ParserRuleContext) {
+	if len(pr.stack) == 0 {
+		return
+	}
+	start := pr.stack[len(pr.stack)-1]
+	pr.stack = pr.stack[:len(pr.stack)-1]
+	r := &pr.rules[ctx.GetRuleIndex()]
+	r.Invocations++
+	r.Time += time.Since(start)
+}
+
+func (pr *profiler) ReportAttemptingFullContext(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, conflictingAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
+	if pr.predicting && pr.decision >= 0 {
+		pr.decisions[pr.decision].LLFallbacks++
+	}
+}
+
+func (pr *profiler) ReportContextSensitivity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex, prediction int, configs antlr.ATNConfigSet) {
+	if pr.predicting && pr.decision >= 0 {
+		pr.decisions[pr.decision].ContextSensitivities++
+	}
+}
+
+func (pr *profiler) ReportAmbiguity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, exact bool, ambigAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
+	a := Ambiguity{File: pr.file, Decision: pr.decision, Exact: exact}
+	if pr.predicting && pr.decision >= 0 {
+		pr.decisions[pr.decision].Ambiguities++
+		a.Rule = pr.decisions[pr.decision].Rule
+	}
+	if ambigAlts == nil && configs != nil {
+		ambigAlts = configs.Alts()
+	}
+	if ambigAlts != nil {
+		for _, s := range strings.Split(strings.Trim(ambigAlts.String(), "{}"), ", ") {
+			if i, err := strconv.Atoi(s); err == nil {
+				a.Alternatives = append(a.Alternatives, i)
+			}
+		}
+	}
+	start, stop := pr.Get(startIndex), pr.Get(stopIndex)
+	a.Line, a.Column = start.GetLine(), start.GetColumn()+1
+	a.Start, a.Stop = start.GetStart(), stop.GetStop()
+	if stop.GetTokenType() == antlr.TokenEOF || a.Stop < a.Start {
+		a.Stop = a.Start - 1
+	}
+	if a.Start >= 0 && a.Stop < len(pr.text) {
+		a.Text = string(pr.text[a.Start : a.Stop+1])
+	}
+	*pr.ambiguities = append(*pr.ambiguities, a)
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestProfileParse(t *testing.T) {
+	files := []plsqlparser.File{
+		{Name: "a.sql", Text: "SELECT a, b FROM t WHERE a = 1;\n"},
+		{Name: "b.sql", Text: "BEGIN UPDATE t SET a = a + 1 WHERE b IN (1, 2); END;\n/\n"},
+	}
+	p, err := plsqlparser.ProfileParse(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Time <= 0 || len(p.Rules) == 0 || len(p.Decisions) == 0 {
+		t.Fatalf("empty profile: %+v", p)
+	}
+	rules := make(map[string]plsqlparser.RuleProfile)
+	for _, r := range p.Rules {
+		rules[r.Rule] = r
+	}
+	if r := rules["sql_script"]; r.Invocations != 2 {
+		t.Errorf("sql_script: got %+v, wanted 2 invocations", r)
+	}
+	if r := rules["where_clause"]; r.Invocations != 2 {
+		t.Errorf("where_clause: got %+v, wanted 2 invocations", r)
+	}
+	for i, d := range p.Decisions {
+		if d.Rule == "" || d.Invocations == 0 || d.MaxLookahead < 1 || d.TotalLookahead < d.Invocations {
+			t.Errorf("decision %+v", d)
+		}
+		if i > 0 && p.Decisions[i-1].Time < d.Time {
+			t.Errorf("decisions are not sorted by time: %v < %v", p.Decisions[i-1].Time, d.Time)
+		}
+	}
+	for _, a := range p.Ambiguities {
+		if len(a.Alternatives) < 2 || a.Text == "" {
+			t.Errorf("ambiguity %s", a)
+		}
+	}
+}
@@ -0,0 +1,451 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// File is a named source text.
+type File struct {
+	Name, Text string
+}
+
+// Edit replaces the Start-Stop range of the named file with NewText.
+//
+// Start and Stop are inclusive character offsets, as in Chunk, and Text is the replaced text.
+type Edit struct {
+	File string
+	Chunk
+	NewText string
+}
+
+func (e Edit) String() string {
+	return fmt.Sprintf("%s:%d-%d: %s -> %s", e.File, e.Start, e.Stop, e.Text, e.NewText)
+}
+
+// ApplyEdits returns the text with the edits applied.
+// The edits must be for this text and must not overlap; an insertion has Stop = Start-1.
+func ApplyEdits(text string, edits []Edit) (string, error) {
+	edits = append([]Edit(nil), edits...)
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Start > edits[j].Start })
+	rs := []rune(text)
+	next := len(rs) + 1 // the start of the previously applied edit
+	for _, e := range edits {
+		if e.Start < 0 || e.Stop < e.Start-1 || e.Stop >= len(rs) {
+			return text, fmt.Errorf("%s: edit %d-%d is out of the text of %d characters", e.File, e.Start, e.Stop, len(rs))
+		}
+		if e.Stop >= next || e.Stop < e.Start && e.Start == next {
+			return text, fmt.Errorf("%s: edit %d-%d overlaps the one at %d", e.File, e.Start, e.Stop, next)
+		}
+		rs = append(rs[:e.Start:e.Start], append([]rune(e.NewText), rs[e.Stop+1:]...)...)
+		next = e.Start
+	}
+	return string(rs), nil
+}
+
+// errorListener collects the syntax errors.
+type errorListener struct {
+	*antlr.DefaultErrorListener
+	Errors
+}
+
+func (el *errorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	el.Append(fmt.Errorf("%d:%d: %s", line, column+1, msg))
+}
+
+// parseFiles parses each file as an SQL script.
+func parseFiles(files []File, o Options) ([]antlr.Tree, error) {
+	trees := make([]antlr.Tree, len(files))
+	for i, f := range files {
+		parser := o.newParser(f.Text)
+		el := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+		parser.AddErrorListener(el)
+		trees[i] = parser.Sql_script()
+		if len(el.slice) != 0 {
+			return nil, fmt.Errorf("%s: %w", f.Name, &el.Errors)
+		}
+	}
+	return trees, nil
+}
+
+// Rename the symbol to newName in all the files.
+//
+// The symbol is the qualified name of a package, subprogram, parameter, variable, constant, cursor,
+// type or exception declared in the files, such as PKG.PROC.P_ID,
+// or a table column (EMP.ENAME) if there is no such declaration.
+// Overloaded subprograms are renamed together.
+//
+// Names are case-insensitive unless quoted. Quoted occurrences stay quoted.
+//
+// The returned edits replace only the names, ordered by file and position.
+// Rename refuses (returns an error) if the new name is already declared in the same scope,
+// or an occurrence would resolve to another declaration after the rename, or vice versa.
+func Rename(files []File, symbol, newName string, opts ...Options) ([]Edit, error) {
+	if err := checkIdentifier(newName); err != nil {
+		return nil, err
+	}
+	trees, err := parseFiles(files, options(opts))
+	if err != nil {
+		return nil, err
+	}
+	st := NewSymbolTable(trees...)
+	names := splitQualified(symbol)
+	for i, name := range names {
+		names[i] = catalog.Normalize(name)
+	}
+	r := renamer{files: files, newName: newName, name: catalog.Normalize(newName)}
+	if sym := st.Canonical(st.Find(names...)); sym != nil {
+		return r.symbol(st, sym)
+	}
+	if len(names) < 2 {
+		return nil, fmt.Errorf("%s is not declared in the files", symbol)
+	}
+	return r.column(trees, strings.Join(names[:len(names)-1], "."), names[len(names)-1])
+}
+
+type renamer struct {
+	files         []File
+	texts         [][]rune
+	newName, name string
+	edits         []Edit
+	Errors
+}
+
+// edit replaces the name at the chunk of the i-th file.
+func (r *renamer) edit(i int, c Chunk) {
+	if r.texts == nil {
+		r.texts = make([][]rune, len(r.files))
+	}
+	if r.texts[i] == nil {
+		r.texts[i] = []rune(r.files[i].Text)
+	}
+	c.Text = string(r.texts[i][c.Start : c.Stop+1])
+	newText := r.newName
+	if strings.HasPrefix(c.Text, `"`) && !strings.HasPrefix(newText, `"`) {
+		newText = `"` + r.name + `"`
+	}
+	if newText != c.Text {
+		r.edits = append(r.edits, Edit{File: r.files[i].Name, Chunk: c, NewText: newText})
+	}
+}
+
+// position returns the file:line:column of the reference.
+func (r *renamer) position(ref Reference) string {
+	tok := ref.Node.GetStop()
+	return fmt.Sprintf("%s:%d:%d", r.files[ref.Tree].Name, tok.GetLine(), tok.GetColumn()+1)
+}
+
+func (r *renamer) result() ([]Edit, error) {
+	if len(r.slice) != 0 {
+		return nil, &r.Errors
+	}
+	order := make(map[string]int, len(r.files))
+	for i, f := range r.files {
+		order[f.Name] = i
+	}
+	sort.SliceStable(r.edits, func(i, j int) bool {
+		a, b := r.edits[i], r.edits[j]
+		if a.File != b.File {
+			return order[a.File] < order[b.File]
+		}
+		return a.Start < b.Start
+	})
+	edits := r.edits[:0]
+	for i, e := range r.edits {
+		if i == 0 || e.File != r.edits[i-1].File || e.Start != r.edits[i-1].Start {
+			edits = append(edits, e)
+		}
+	}
+	return edits, nil
+}
+
+func (r *renamer) symbol(st *SymbolTable, sym *Symbol) ([]Edit, error) {
+	if r.name == sym.Name {
+		return nil, nil
+	}
+	for _, ref := range st.References {
+		switch {
+		case ref.Symbol == sym:
+			if ref.Decl {
+				for _, other := range ref.Scope.Symbols {
+					if other.Name == r.name {
+						r.Append(fmt.Errorf("%s: %s %s is already declared here", r.position(ref), other.Kind, other.Name))
+						break
+					}
+				}
+			} else if !ref.Qualified {
+				for s := ref.Scope; s != nil && !st.Declares(s, sym); s = s.Parent {
+					if other := s.Local(r.name); other != nil {
+						r.Append(fmt.Errorf("%s: %s would refer to %s %s", r.position(ref), r.name, other.Kind, other.Name))
+						break
+					}
+				}
+			}
+			r.edit(ref.Tree, ref.Chunk)
+
+		case ref.Symbol.Name == r.name && !ref.Decl && !ref.Qualified:
+			r.captured(st, ref, sym)
+		}
+	}
+	for _, ref := range st.unresolved {
+		if catalog.Normalize(ref.Text) == r.name {
+			r.captured(st, ref, sym)
+		}
+	}
+	return r.result()
+}
+
+// captured reports whether the renamed symbol would hide the declaration the reference resolves to now.
+func (r *renamer) captured(st *SymbolTable, ref Reference, sym *Symbol) {
+	for s := ref.Scope; s != nil; s = s.Parent {
+		if ref.Symbol != nil && st.Declares(s, ref.Symbol) {
+			return
+		}
+		if st.Declares(s, sym) {
+			r.Append(fmt.Errorf("%s: %s would refer to the renamed %s %s", r.position(ref), ref.Text, sym.Kind, sym.Name))
+			return
+		}
+	}
+}
+
+// column renames the column of the table in the SQL statements and CREATE TABLE of the trees.
+func (r *renamer) column(trees []antlr.Tree, table, column string) ([]Edit, error) {
+	if r.name == column {
+		return nil, nil
+	}
+	for i, tree := range trees {
+		cl := &columnListener{
+			BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()},
+			renamer:          r, tree: i, table: table, column: column,
+		}
+		antlr.ParseTreeWalkerDefault.Walk(cl, tree)
+	}
+	if len(r.edits) == 0 && len(r.slice) == 0 {
+		return nil, fmt.Errorf("no references to %s.%s found", table, column)
+	}
+	return r.result()
+}
+
+type columnListener struct {
+	BaseWalkListener
+	*renamer
+	tree          int
+	table, column string
+}
+
+func (cl *columnListener) rename(node antlr.ParserRuleContext) {
+	tok := node.GetStop()
+	cl.edit(cl.tree, Chunk{Start: tok.GetStart(), Stop: tok.GetStop()})
+}
+
+func (cl *columnListener) fail(node antlr.ParserRuleContext, format string, args ...interface{}) {
+	tok := node.GetStop()
+	cl.Append(fmt.Errorf("%s:%d:%d: %s", cl.files[cl.tree].Name, tok.GetLine(), tok.GetColumn()+1, fmt.Sprintf(format, args...)))
+}
+
+// sameTable reports whether the possibly owner-qualified names denote the same table.
+func sameTable(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ia, ib := strings.LastIndexByte(a, '.'), strings.LastIndexByte(b, '.')
+	return (ia < 0) != (ib < 0) && a[ia+1:] == b[ib+1:]
+}
+
+func (cl *columnListener) ExitGeneral_element(ctx *plsql.General_elementContext) {
+	var ids []antlr.ParserRuleContext
+	for _, p := range ctx.AllGeneral_element_part() {
+		p := p.(*plsql.General_element_partContext)
+		if p.Function_argument() != nil {
+			return
+		}
+		for _, id := range p.AllId_expression() {
+			ids = append(ids, id)
+		}
+	}
+	cl.ref(ctx, ids)
+}
+
+func (cl *columnListener) ExitTable_element(ctx *plsql.Table_elementContext) {
+	var ids []antlr.ParserRuleContext
+	for _, id := range ctx.AllId_expression() {
+		ids = append(ids, id)
+	}
+	cl.ref(ctx, ids)
+}
+
+// ExitType_name renames TABLE.COLUMN%TYPE.
+func (cl *columnListener) ExitType_name(ctx *plsql.Type_nameContext) {
+	if ts, ok := ctx.GetParent().(*plsql.Type_specContext); !ok || ts.PERCENT_TYPE() == nil {
+		return
+	}
+	ids := ctx.AllId_expression()
+	if n := len(ids); n > 1 && catalog.Normalize(ids[n-1].GetText()) == cl.column {
+		var qual []string
+		for _, id := range ids[:n-1] {
+			qual = append(qual, catalog.Normalize(id.GetText()))
+		}
+		if sameTable(strings.Join(qual, "."), cl.table) {
+			cl.rename(ids[n-1])
+		}
+	}
+}
+
+// ref renames the column reference in an SQL statement.
+//
+// Qualified references are matched against the table names and aliases of the enclosing queries.
+// An unqualified reference is renamed if the table is the only one in the innermost query,
+// and refused if it may refer to another table's column.
+func (cl *columnListener) ref(node antlr.Tree, ids []antlr.ParserRuleContext) {
+	n := len(ids)
+	if n == 0 || catalog.Normalize(ids[n-1].GetText()) != cl.column {
+		return
+	}
+	var levels [][]checkTable
+	for p := node; p != nil; p = p.GetParent() {
+		switch p.(type) {
+		case *plsql.Query_blockContext, *plsql.Update_statementContext, *plsql.Delete_statementContext:
+			levels = append(levels, queryTables(p))
+		}
+	}
+	if n > 1 {
+		var qual []string
+		for _, id := range ids[:n-1] {
+			qual = append(qual, catalog.Normalize(id.GetText()))
+		}
+		q := strings.Join(qual, ".")
+		for _, tables := range levels {
+			for _, t := range tables {
+				if t.Alias == q || t.Alias == "" && sameTable(t.Name, q) {
+					if sameTable(t.Name, cl.table) {
+						cl.rename(ids[n-1])
+					}
+					return
+				}
+			}
+		}
+		return
+	}
+	var inner bool
+	for _, tables := range levels {
+		var match, other int
+		for _, t := range tables {
+			if sameTable(t.Name, cl.table) {
+				match++
+			} else {
+				other++
+			}
+		}
+		if match == 0 {
+			inner = inner || other != 0
+			continue
+		}
+		if other == 0 && !inner {
+			cl.rename(ids[0])
+		} else {
+			cl.fail(ids[0], "%s may be a column of another table, qualify it first", cl.column)
+		}
+		return
+	}
+}
+
+// ExitColumn_name renames the column in INSERT and UPDATE column lists and CREATE TABLE.
+func (cl *columnListener) ExitColumn_name(ctx *plsql.Column_nameContext) {
+	var table string
+Loop:
+	for p := ctx.GetParent(); p != nil; p = p.GetParent() {
+		switch x := p.(type) {
+		case *plsql.Insert_into_clauseContext:
+			table = generalTableRef(x.General_table_ref())[0].Name
+			break Loop
+		case *plsql.Update_statementContext:
+			table = generalTableRef(x.General_table_ref())[0].Name
+			break Loop
+		case *plsql.Create_tableContext:
+			table = tableviewName(x.Tableview_name())
+			break Loop
+		case *plsql.Query_blockContext, *plsql.Seq_of_statementsContext:
+			return
+		}
+	}
+	if table == "" || !sameTable(table, cl.table) {
+		return
+	}
+	switch catalog.Normalize(ctx.GetStop().GetText()) {
+	case cl.column:
+		cl.rename(ctx)
+	case cl.name:
+		if _, ok := ctx.GetParent().(*plsql.Column_definitionContext); ok {
+			cl.fail(ctx, "column %s is already defined", cl.name)
+		}
+	}
+}
+
+// splitQualified splits the dotted name, keeping the dots in quoted names.
+func splitQualified(name string) []string {
+	var names []string
+	var inQuote bool
+	var start int
+	for i, r := range name {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == '.' && !inQuote:
+			names = append(names, name[start:i])
+			start = i + 1
+		}
+	}
+	return append(names, name[start:])
+}
+
+// checkIdentifier checks whether the name is a valid quoted or unquoted identifier.
+func checkIdentifier(name string) error {
+	if strings.HasPrefix(name, `"`) {
+		if len(name) < 3 || !strings.HasSuffix(name, `"`) || strings.Contains(name[1:len(name)-1], `"`) {
+			return fmt.Errorf("%s: invalid quoted identifier", name)
+		}
+		if len(name)-2 > 128 {
+			return fmt.Errorf("%s: identifier too long", name)
+		}
+		return nil
+	}
+	if name == "" || len(name) > 128 {
+		return fmt.Errorf("%q: invalid identifier length", name)
+	}
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || i != 0 && (isDigit(r) || r == '_' || r == '$' || r == '#')) {
+			return fmt.Errorf("%s: invalid identifier, quote it", name)
+		}
+	}
+	if reservedWords[strings.ToUpper(name)] {
+		return fmt.Errorf("%s is a reserved word, quote it", name)
+	}
+	return nil
+}
+
+// reservedWords are the words that cannot be used as unquoted identifiers.
+var reservedWords = make(map[string]bool)
+
+func init() {
+	for _, w := range strings.Fields(`ACCESS ADD ALL ALTER AND ANY AS ASC AUDIT BEGIN BETWEEN BY
+	CHAR CHECK CLUSTER COLUMN COMMENT COMPRESS CONNECT CREATE CURRENT CURSOR
+	DATE DECIMAL DECLARE DEFAULT DELETE DESC DISTINCT DROP ELSE END EXCEPTION EXCLUSIVE EXISTS
+	FILE FLOAT FOR FROM FUNCTION GOTO GRANT GROUP HAVING IDENTIFIED IF IMMEDIATE IN INCREMENT INDEX
+	INITIAL INSERT INTEGER INTERSECT INTO IS LEVEL LIKE LOCK LONG LOOP MAXEXTENTS MINUS MLSLABEL
+	MODE MODIFY NOAUDIT NOCOMPRESS NOT NOWAIT NULL NUMBER OF OFFLINE ON ONLINE OPTION OR ORDER
+	PACKAGE PCTFREE PRIOR PROCEDURE PUBLIC RAISE RAW RENAME RESOURCE RETURN REVOKE ROW ROWID ROWNUM ROWS
+	SELECT SESSION SET SHARE SIZE SMALLINT START SUCCESSFUL SYNONYM SYSDATE TABLE THEN TO TRIGGER
+	UID UNION UNIQUE UPDATE USER VALIDATE VALUES VARCHAR VARCHAR2 VIEW WHENEVER WHERE WHILE WITH`) {
+		reservedWords[w] = true
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestRename(t *testing.T) {
+	spec := plsqlparser.File{Name: "emp_pkg.pks", Text: `CREATE OR REPLACE PACKAGE emp_pkg IS
+  PROCEDURE hire(p_ename IN VARCHAR2);
+END emp_pkg;
+`}
+	body := plsqlparser.File{Name: "emp_pkg.pkb", Text: `CREATE OR REPLACE PACKAGE BODY emp_pkg IS
+  g_count PLS_INTEGER := 0;
+  PROCEDURE hire(p_ename IN VARCHAR2) IS
+    v_no emp.empno%TYPE;
+  BEGIN
+    SELECT MAX(empno) + 1 INTO v_no FROM emp;
+    INSERT INTO emp (empno, ename) VALUES (v_no, p_ename);
+    g_count := g_count + 1;
+  END hire;
+END emp_pkg;
+`}
+	caller := plsqlparser.File{Name: "caller.sql", Text: `BEGIN
+  emp_pkg.hire(p_ename => 'KING');
+  "EMP_PKG".hire('SCOTT');
+END;
+`}
+	files := []plsqlparser.File{body, spec, caller}
+
+	for _, tc := range []struct {
+		Symbol, NewName string
+		Want            map[string]string
+		Err             string
+	}{
+		{Symbol: "emp_pkg.hire.p_ename", NewName: "p_name", Want: map[string]string{
+			spec.Name:   "PROCEDURE hire(p_name IN VARCHAR2);",
+			body.Name:   "VALUES (v_no, p_name);",
+			caller.Name: "emp_pkg.hire(p_name => 'KING');",
+		}},
+		{Symbol: "EMP_PKG", NewName: "staff_pkg", Want: map[string]string{
+			spec.Name:   "END staff_pkg;",
+			body.Name:   "PACKAGE BODY staff_pkg IS",
+			caller.Name: `"STAFF_PKG".hire('SCOTT');`,
+		}},
+		{Symbol: "emp_pkg.hire", NewName: "employ", Want: map[string]string{
+			spec.Name:   "PROCEDURE employ(p_ename IN VARCHAR2);",
+			body.Name:   "END employ;",
+			caller.Name: "emp_pkg.employ(p_ename => 'KING');",
+		}},
+		{Symbol: "emp.empno", NewName: "id", Want: map[string]string{
+			body.Name: "v_no emp.id%TYPE;\n  BEGIN\n    SELECT MAX(id) + 1 INTO v_no FROM emp;\n    INSERT INTO emp (id, ename)",
+		}},
+		{Symbol: "emp_pkg.hire.v_no", NewName: "g_count", Err: "G_COUNT would refer to the renamed variable V_NO"},
+		{Symbol: "emp_pkg.g_count", NewName: "hire", Err: "procedure HIRE is already declared here"},
+		{Symbol: "emp_pkg.g_count", NewName: "p_ename", Err: "P_ENAME would refer to parameter P_ENAME"},
+		{Symbol: "emp_pkg.g_count", NewName: "select", Err: "reserved word"},
+	} {
+		edits, err := plsqlparser.Rename(files, tc.Symbol, tc.NewName)
+		if tc.Err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.Err) {
+				t.Errorf("%s -> %s: got error %v, wanted %q", tc.Symbol, tc.NewName, err, tc.Err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s -> %s: %+v", tc.Symbol, tc.NewName, err)
+			continue
+		}
+		for _, f := range files {
+			var fileEdits []plsqlparser.Edit
+			for _, e := range edits {
+				if e.File == f.Name {
+					fileEdits = append(fileEdits, e)
+				}
+			}
+			got, err := plsqlparser.ApplyEdits(f.Text, fileEdits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := tc.Want[f.Name]; want == "" && got != f.Text {
+				t.Errorf("%s -> %s: %s should not change, got\n%s", tc.Symbol, tc.NewName, f.Name, got)
+			} else if !strings.Contains(got, want) {
+				t.Errorf("%s -> %s: %s got\n%s\nwanted %q", tc.Symbol, tc.NewName, f.Name, got, want)
+			}
+		}
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	const text = "SELECT a FROM t"
+	got, err := plsqlparser.ApplyEdits(text, []plsqlparser.Edit{
+		{Chunk: plsqlparser.Chunk{Start: 7, Stop: 7}, NewText: "b, c"},
+		{Chunk: plsqlparser.Chunk{Start: 15, Stop: 14}, NewText: " x"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SELECT b, c FROM t x"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	for _, edits := range [][]plsqlparser.Edit{
+		{{Chunk: plsqlparser.Chunk{Start: 10, Stop: 20}}},
+		{{Chunk: plsqlparser.Chunk{Start: -1, Stop: 0}}},
+		{{Chunk: plsqlparser.Chunk{Start: 0, Stop: 5}}, {Chunk: plsqlparser.Chunk{Start: 5, Stop: 7}}},
+	} {
+		if _, err := plsqlparser.ApplyEdits(text, edits); err == nil {
+			t.Errorf("%+v: wanted error", edits)
+		}
+	}
+}
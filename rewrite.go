@@ -0,0 +1,164 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// ErrConflict is returned for an edit that overlaps a previous one.
+var ErrConflict = errors.New("conflicting edit")
+
+// Node is a parse tree node, or a range of tokens (see Tokens), to edit.
+type Node interface {
+	GetStart() antlr.Token
+	GetStop() antlr.Token
+}
+
+type tokenRange struct{ from, to antlr.Token }
+
+func (tr tokenRange) GetStart() antlr.Token { return tr.from }
+func (tr tokenRange) GetStop() antlr.Token  { return tr.to }
+
+// Tokens returns the range of tokens, inclusive, as a Node.
+func Tokens(from, to antlr.Token) Node { return tokenRange{from: from, to: to} }
+
+// Rewriter edits the source text through the tokens of its parse tree,
+// using the antlr.TokenStreamRewriter.
+//
+// The text outside of the edited tokens, including the whitespace and comments, is kept as is.
+type Rewriter struct {
+	// Tree is the parse tree of the text, the nodes to be edited should come from it.
+	Tree antlr.Tree
+
+	stream *antlr.CommonTokenStream
+	tsr    *antlr.TokenStreamRewriter
+	ops    []rewriteOp
+}
+
+// rewriteOp replaces the from-to tokens, or inserts before from if to < from.
+type rewriteOp struct {
+	name     string
+	from, to int
+}
+
+func (op rewriteOp) insert() bool { return op.to < op.from }
+
+// NewRewriter parses the text as an SQL script, and returns a Rewriter for it.
+func NewRewriter(text string, opts ...Options) (*Rewriter, error) {
+	parser := options(opts).newParser(text)
+	el := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	parser.AddErrorListener(el)
+	tree := parser.Sql_script()
+	if len(el.slice) != 0 {
+		return nil, &el.Errors
+	}
+	stream := parser.GetTokenStream().(*antlr.CommonTokenStream)
+	stream.Fill()
+	return &Rewriter{Tree: tree, stream: stream, tsr: antlr.NewTokenStreamRewriter(stream)}, nil
+}
+
+// TokenAt returns the token at the character position, or nil.
+func (rw *Rewriter) TokenAt(pos int) antlr.Token {
+	tokens := rw.stream.GetAllTokens()
+	i := sort.Search(len(tokens), func(i int) bool { return tokens[i].GetStop() >= pos })
+	if i < len(tokens) && tokens[i].GetStart() <= pos && tokens[i].GetTokenType() != antlr.TokenEOF {
+		return tokens[i]
+	}
+	return nil
+}
+
+// InsertBefore inserts the text before the node.
+func (rw *Rewriter) InsertBefore(node Node, text string) error {
+	i := node.GetStart().GetTokenIndex()
+	if err := rw.add(rewriteOp{name: "insert before", from: i, to: i - 1}); err != nil {
+		return err
+	}
+	rw.tsr.InsertBeforeDefault(i, text)
+	return nil
+}
+
+// InsertAfter inserts the text after the node.
+func (rw *Rewriter) InsertAfter(node Node, text string) error {
+	i := node.GetStop().GetTokenIndex()
+	if err := rw.add(rewriteOp{name: "insert after", from: i + 1, to: i}); err != nil {
+		return err
+	}
+	rw.tsr.InsertAfterDefault(i, text)
+	return nil
+}
+
+// Replace the node with the text.
+func (rw *Rewriter) Replace(node Node, text string) error {
+	op := rewriteOp{name: "replace", from: node.GetStart().GetTokenIndex(), to: node.GetStop().GetTokenIndex()}
+	if err := rw.addRange(op); err != nil {
+		return err
+	}
+	rw.tsr.ReplaceDefault(op.from, op.to, text)
+	return nil
+}
+
+// Delete the node.
+func (rw *Rewriter) Delete(node Node) error {
+	op := rewriteOp{name: "delete", from: node.GetStart().GetTokenIndex(), to: node.GetStop().GetTokenIndex()}
+	if err := rw.addRange(op); err != nil {
+		return err
+	}
+	rw.tsr.DeleteDefault(op.from, op.to)
+	return nil
+}
+
+// addRange adds the replacement of a non-empty token range (an empty rule has its stop token before its start).
+func (rw *Rewriter) addRange(op rewriteOp) error {
+	if op.insert() {
+		return fmt.Errorf("%s: token range %d-%d is empty", op.name, op.from, op.to)
+	}
+	return rw.add(op)
+}
+
+// add the operation, if it does not conflict with the previous ones:
+// replaced ranges must not overlap, and insertions must not be inside replaced ranges.
+// Insertions to the edges of a replaced range are kept.
+//
+// These are the cases the antlr.TokenStreamRewriter panics on (or drops an edit silently),
+// so GetTextDefault is safe to call for the accepted operations.
+func (rw *Rewriter) add(op rewriteOp) error {
+	if op.from < 0 || op.to >= rw.stream.Size() || op.insert() && op.from > rw.stream.Size() {
+		return fmt.Errorf("%s: token range %d-%d is out of the stream", op.name, op.from, op.to)
+	}
+	for _, prev := range rw.ops {
+		var conflict bool
+		switch {
+		case op.insert() && prev.insert():
+		case op.insert():
+			conflict = prev.from < op.from && op.from <= prev.to
+		case prev.insert():
+			conflict = op.from < prev.from && prev.from <= op.to
+		default:
+			conflict = op.from <= prev.to && prev.from <= op.to
+		}
+		if conflict {
+			return fmt.Errorf("%s at %s overlaps %s at %s: %w", op.name, rw.position(op), prev.name, rw.position(prev), ErrConflict)
+		}
+	}
+	rw.ops = append(rw.ops, op)
+	return nil
+}
+
+func (rw *Rewriter) position(op rewriteOp) string {
+	i := op.from
+	if i >= rw.stream.Size() {
+		i = rw.stream.Size() - 1
+	}
+	t := rw.stream.Get(i)
+	return fmt.Sprintf("%d:%d", t.GetLine(), t.GetColumn()+1)
+}
+
+// Text returns the edited text.
+func (rw *Rewriter) Text() string { return rw.tsr.GetTextDefault() }
@@ -0,0 +1,88 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// addColumn adds the DEPTNO column to the INSERTs into EMP.
+type addColumn struct {
+	*plsql.BasePlSqlParserListener
+	rw   *plsqlparser.Rewriter
+	into bool
+	err  error
+}
+
+func (ac *addColumn) ExitInsert_into_clause(ctx *plsql.Insert_into_clauseContext) {
+	ac.into = strings.EqualFold(ctx.General_table_ref().GetText(), "EMP")
+	if pcl := ctx.Paren_column_list(); ac.into && pcl != nil {
+		if err := ac.rw.InsertAfter(pcl.(*plsql.Paren_column_listContext).Column_list(), ", deptno"); err != nil && ac.err == nil {
+			ac.err = err
+		}
+	}
+}
+func (ac *addColumn) ExitValues_clause(ctx *plsql.Values_clauseContext) {
+	if ac.into && ctx.Expressions() != nil {
+		if err := ac.rw.InsertAfter(ctx.Expressions(), ", 10"); err != nil && ac.err == nil {
+			ac.err = err
+		}
+	}
+}
+
+func TestRewriter(t *testing.T) {
+	const text = `BEGIN
+  -- hire them
+  INSERT INTO emp (empno, ename) VALUES (1, 'King');
+  INSERT INTO dept (deptno) VALUES (10);
+  Old_Pkg.Do_It(1);
+END;
+`
+	rw, err := plsqlparser.NewRewriter(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ac := addColumn{BasePlSqlParserListener: &plsql.BasePlSqlParserListener{}, rw: rw}
+	antlr.ParseTreeWalkerDefault.Walk(&ac, rw.Tree)
+	if ac.err != nil {
+		t.Fatal(ac.err)
+	}
+	// replace the deprecated call, by token range
+	from := rw.TokenAt(len("BEGIN\n  -- hire them\n  INSERT INTO emp (empno, ename) VALUES (1, 'King');\n  INSERT INTO dept (deptno) VALUES (10);\n  "))
+	if from == nil || from.GetText() != "Old_Pkg" {
+		t.Fatalf("TokenAt: got %v", from)
+	}
+	to := rw.TokenAt(from.GetStop() + 2)
+	if err := rw.Replace(plsqlparser.Tokens(from, to), "new_pkg.do_it"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rw.Delete(plsqlparser.Tokens(to, to)); !errors.Is(err, plsqlparser.ErrConflict) {
+		t.Errorf("delete inside replaced range: got %v, wanted ErrConflict", err)
+	}
+	if err := rw.Replace(plsqlparser.Tokens(from, rw.TokenAt(to.GetStop()+1)), "x("); !errors.Is(err, plsqlparser.ErrConflict) {
+		t.Errorf("replace around replaced range: got %v, wanted ErrConflict", err)
+	}
+	if err := rw.InsertBefore(plsqlparser.Tokens(from, from), "/* moved */ "); err != nil {
+		t.Errorf("insert before replaced range: %+v", err)
+	}
+
+	got := rw.Text()
+	const want = `BEGIN
+  -- hire them
+  INSERT INTO emp (empno, ename, deptno) VALUES (1, 'King', 10);
+  INSERT INTO dept (deptno) VALUES (10);
+  /* moved */ new_pkg.do_it(1);
+END;
+`
+	if got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+}
@@ -0,0 +1,699 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// SymbolKind is the kind of a declared name.
+type SymbolKind uint8
+
+const (
+	VariableSym = SymbolKind(iota + 1)
+	ConstantSym
+	ParameterSym
+	CursorSym
+	TypeSym
+	ExceptionSym
+	ProcedureSym
+	FunctionSym
+	PackageSym
+)
+
+func (k SymbolKind) String() string {
+	switch k {
+	case VariableSym:
+		return "variable"
+	case ConstantSym:
+		return "constant"
+	case ParameterSym:
+		return "parameter"
+	case CursorSym:
+		return "cursor"
+	case TypeSym:
+		return "type"
+	case ExceptionSym:
+		return "exception"
+	case ProcedureSym:
+		return "procedure"
+	case FunctionSym:
+		return "function"
+	case PackageSym:
+		return "package"
+	}
+	return "unknown"
+}
+
+// Symbol is a declared name.
+type Symbol struct {
+	// Name is normalized: uppercased if it was not quoted.
+	Name string
+	Kind SymbolKind
+	// Type of the variable, constant, parameter, type, or the return type of the function.
+	Type Type
+	// Mode of the parameter: IN, OUT or IN OUT.
+	Mode string
+	// Decl is the name in the declaration.
+	Decl Chunk
+	// Scope is where the symbol is declared.
+	Scope *Scope
+	// Own is the scope opened by the package or subprogram.
+	Own *Scope
+}
+
+// Scope is a declaration region: a package, subprogram, block or FOR loop.
+type Scope struct {
+	Chunk
+	Parent   *Scope
+	Name     string
+	Symbols  []*Symbol
+	Children []*Scope
+	owner    *Symbol
+	node     antlr.ParserRuleContext
+}
+
+// Local returns the symbol declared in this scope, or nil.
+func (s *Scope) Local(name string) *Symbol {
+	if s == nil {
+		return nil
+	}
+	name = catalog.Normalize(name)
+	for _, sym := range s.Symbols {
+		if sym.Name == name {
+			return sym
+		}
+	}
+	return nil
+}
+
+// Lookup the name in this scope and its parents.
+func (s *Scope) Lookup(name string) *Symbol {
+	for ; s != nil; s = s.Parent {
+		if sym := s.Local(name); sym != nil {
+			return sym
+		}
+	}
+	return nil
+}
+
+// Innermost returns the innermost scope containing the position.
+func (s *Scope) Innermost(pos int) *Scope {
+	for _, c := range s.Children {
+		if c.Start <= pos && pos <= c.Stop {
+			return c.Innermost(pos)
+		}
+	}
+	return s
+}
+
+func (s *Scope) declare(sym *Symbol) *Symbol {
+	sym.Scope = s
+	s.Symbols = append(s.Symbols, sym)
+	return sym
+}
+
+// SymbolTable holds the scopes, declarations and references of parse trees.
+type SymbolTable struct {
+	Root *Scope
+	// References lists the declarations and uses of the symbols, in tree order.
+	References []Reference
+
+	// unresolved holds the uses of names not declared in the trees.
+	unresolved []Reference
+
+	scopes map[antlr.Tree]*Scope
+	decls  map[antlr.Tree]*Symbol
+	// specs maps the scope of a package body to the scope of its specification.
+	specs map[*Scope]*Scope
+}
+
+// Reference is a declaration or use of a symbol.
+type Reference struct {
+	// Chunk is the referring name.
+	Chunk
+	Node antlr.ParserRuleContext
+	// Symbol is the canonical declaration, see SymbolTable.Canonical.
+	Symbol *Symbol
+	// Tree is the index of the tree given to NewSymbolTable.
+	Tree int
+	// Decl is true for the declaring name.
+	Decl bool
+	// Qualified is true if the name is not looked up in the enclosing scopes,
+	// as it is prefixed with the package or subprogram name, or follows an END.
+	Qualified bool
+	// Scope is where the name is resolved from, or where it is declared.
+	Scope *Scope
+}
+
+// NewSymbolTable collects the declarations of the trees and resolves the references to them.
+//
+// The trees share a common root scope, so a package body sees its specification
+// even if they are in different files.
+func NewSymbolTable(trees ...antlr.Tree) *SymbolTable {
+	st := &SymbolTable{Root: &Scope{},
+		scopes: make(map[antlr.Tree]*Scope),
+		decls:  make(map[antlr.Tree]*Symbol),
+		specs:  make(map[*Scope]*Scope),
+	}
+	if len(trees) == 1 {
+		if pt, ok := trees[0].(antlr.ParserRuleContext); ok && pt.GetStart() != nil && pt.GetStop() != nil {
+			st.Root.Start, st.Root.Stop = pt.GetStart().GetStart(), pt.GetStop().GetStop()
+		}
+	}
+	sl := &scopeListener{BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}, st: st, stack: []*Scope{st.Root}}
+	for _, tree := range trees {
+		antlr.ParseTreeWalkerDefault.Walk(sl, tree)
+	}
+	sl.linkBodies()
+	for i, tree := range trees {
+		antlr.ParseTreeWalkerDefault.Walk(&refListener{BaseWalkListener: sl.BaseWalkListener, st: st, tree: i}, tree)
+	}
+	return st
+}
+
+// ScopeOf returns the innermost scope enclosing the node.
+func (st *SymbolTable) ScopeOf(node antlr.Tree) *Scope {
+	for ; node != nil; node = node.GetParent() {
+		if s := st.scopes[node]; s != nil {
+			return s
+		}
+	}
+	return st.Root
+}
+
+// Find the symbol by its qualified name, such as PKG.PROC.V_X, and returns its canonical declaration.
+//
+// The first part is looked up in the root scope, the rest in the scopes opened by the previous part,
+// including the package body and the subprogram body of the specification.
+// Blocks and loops are searched, too, as they have no name.
+func (st *SymbolTable) Find(names ...string) *Symbol {
+	var sym *Symbol
+	scopes := []*Scope{st.Root}
+	for _, name := range names {
+		name = catalog.Normalize(name)
+		for _, s := range scopes {
+			for body, spec := range st.specs {
+				if spec == s {
+					scopes = append(scopes, body)
+				}
+			}
+		}
+		sym = nil
+		var next []*Scope
+		for _, s := range scopes {
+			found := findLocal(s, name)
+			if found == nil {
+				continue
+			}
+			if sym == nil {
+				sym = st.Canonical(found)
+			}
+			if found.Own != nil && st.Canonical(found) == sym {
+				next = append(next, found.Own)
+			}
+		}
+		if sym == nil {
+			return nil
+		}
+		scopes = next
+	}
+	return sym
+}
+
+func findLocal(s *Scope, name string) *Symbol {
+	if sym := s.Local(name); sym != nil {
+		return sym
+	}
+	for _, c := range s.Children {
+		if c.Name != "" {
+			continue
+		}
+		if sym := findLocal(c, name); sym != nil {
+			return sym
+		}
+	}
+	return nil
+}
+
+// Canonical returns the first declaration of the symbol:
+// the one in the package specification for a subprogram (or its parameter) defined in the body,
+// or the forward declaration of a subprogram.
+func (st *SymbolTable) Canonical(sym *Symbol) *Symbol {
+	if sym == nil || sym.Scope == nil {
+		return sym
+	}
+	scopes := []*Scope{sym.Scope}
+	if spec := st.specs[sym.Scope]; spec != nil {
+		scopes = append([]*Scope{spec}, scopes...)
+	} else if o := sym.Scope.owner; o != nil && sym.Kind == ParameterSym {
+		if c := st.Canonical(o); c != o && c.Own != nil {
+			scopes = append([]*Scope{c.Own}, scopes...)
+		}
+	}
+	for _, s := range scopes {
+		for _, other := range s.Symbols {
+			if other.Name == sym.Name && other.Kind == sym.Kind {
+				return other
+			}
+		}
+	}
+	return sym
+}
+
+// Declares reports whether the scope has a declaration of the (canonical) symbol.
+func (st *SymbolTable) Declares(s *Scope, sym *Symbol) bool {
+	for _, other := range s.Symbols {
+		if other.Name == sym.Name && st.Canonical(other) == sym {
+			return true
+		}
+	}
+	return false
+}
+
+type scopeListener struct {
+	BaseWalkListener
+	st     *SymbolTable
+	stack  []*Scope
+	bodies []*Scope
+}
+
+func (sl *scopeListener) top() *Scope { return sl.stack[len(sl.stack)-1] }
+
+// push a new scope for ctx, declaring name in the current scope if kind is not 0.
+func (sl *scopeListener) push(ctx antlr.ParserRuleContext, name interface {
+	GetStart() antlr.Token
+	GetStop() antlr.Token
+	GetText() string
+}, kind SymbolKind, typ Type) *Scope {
+	parent := sl.top()
+	s := &Scope{Chunk: ctxChunk(ctx), Parent: parent, node: ctx}
+	if name != nil {
+		s.Name = catalog.Normalize(name.GetText())
+		if kind != 0 {
+			s.owner = parent.declare(&Symbol{Name: s.Name, Kind: kind, Type: typ, Decl: tokenChunk(name), Own: s})
+			sl.st.decls[name.(antlr.Tree)] = s.owner
+		}
+	}
+	parent.Children = append(parent.Children, s)
+	sl.st.scopes[ctx] = s
+	sl.stack = append(sl.stack, s)
+	return s
+}
+func (sl *scopeListener) pop() { sl.stack = sl.stack[:len(sl.stack)-1] }
+
+func (sl *scopeListener) declare(name interface {
+	GetStart() antlr.Token
+	GetStop() antlr.Token
+	GetText() string
+}, kind SymbolKind, typ Type) *Symbol {
+	sym := sl.top().declare(&Symbol{Name: catalog.Normalize(name.GetText()), Kind: kind, Type: typ, Decl: tokenChunk(name)})
+	sl.st.decls[name.(antlr.Tree)] = sym
+	return sym
+}
+
+func (sl *scopeListener) EnterCreate_package(ctx *plsql.Create_packageContext) {
+	sl.push(ctx, ctx.Package_name(0), PackageSym, Type{})
+}
+func (sl *scopeListener) ExitCreate_package(ctx *plsql.Create_packageContext) { sl.pop() }
+
+// EnterCreate_package_body opens the scope of the package body
+// as a child of the specification, if that is known.
+//
+// Bodies read before their specification are linked by linkBodies.
+func (sl *scopeListener) EnterCreate_package_body(ctx *plsql.Create_package_bodyContext) {
+	name := ctx.Package_name(0)
+	spec := sl.top().Local(name.GetText())
+	if spec == nil || spec.Kind != PackageSym {
+		sl.bodies = append(sl.bodies, sl.push(ctx, name, PackageSym, Type{}))
+		return
+	}
+	s := sl.push(ctx, name, 0, Type{})
+	s.Parent = spec.Own
+	sl.st.specs[s] = spec.Own
+}
+func (sl *scopeListener) ExitCreate_package_body(ctx *plsql.Create_package_bodyContext) { sl.pop() }
+
+// linkBodies links the package bodies to their specifications read later.
+func (sl *scopeListener) linkBodies() {
+	root := sl.st.Root
+	for _, b := range sl.bodies {
+		var spec *Symbol
+		for _, sym := range root.Symbols {
+			if sym.Name == b.Name && sym.Kind == PackageSym && sym.Own != b {
+				spec = sym
+				break
+			}
+		}
+		if spec == nil {
+			continue
+		}
+		for i, sym := range root.Symbols {
+			if sym == b.owner {
+				root.Symbols = append(root.Symbols[:i], root.Symbols[i+1:]...)
+				break
+			}
+		}
+		for k, sym := range sl.st.decls {
+			if sym == b.owner {
+				delete(sl.st.decls, k)
+			}
+		}
+		b.owner, b.Parent = nil, spec.Own
+		sl.st.specs[b] = spec.Own
+	}
+}
+
+func (sl *scopeListener) EnterCreate_procedure_body(ctx *plsql.Create_procedure_bodyContext) {
+	pn := ctx.Procedure_name().(*plsql.Procedure_nameContext)
+	sl.push(ctx, objectName(pn.Identifier(), pn.Id_expression()), ProcedureSym, Type{})
+}
+func (sl *scopeListener) ExitCreate_procedure_body(ctx *plsql.Create_procedure_bodyContext) {
+	sl.pop()
+}
+func (sl *scopeListener) EnterCreate_function_body(ctx *plsql.Create_function_bodyContext) {
+	fn := ctx.Function_name().(*plsql.Function_nameContext)
+	sl.push(ctx, objectName(fn.Identifier(), fn.Id_expression()), FunctionSym, typeOfSpec(ctx.Type_spec()))
+}
+func (sl *scopeListener) ExitCreate_function_body(ctx *plsql.Create_function_bodyContext) {
+	sl.pop()
+}
+
+// objectName returns the name of a possibly schema-qualified object.
+func objectName(first plsql.IIdentifierContext, second plsql.IId_expressionContext) antlr.ParserRuleContext {
+	if second != nil {
+		return second
+	}
+	return first
+}
+
+func (sl *scopeListener) EnterProcedure_spec(ctx *plsql.Procedure_specContext) {
+	sl.push(ctx, ctx.Identifier(), ProcedureSym, Type{})
+}
+func (sl *scopeListener) ExitProcedure_spec(ctx *plsql.Procedure_specContext) { sl.pop() }
+func (sl *scopeListener) EnterFunction_spec(ctx *plsql.Function_specContext) {
+	sl.push(ctx, ctx.Identifier(), FunctionSym, typeOfSpec(ctx.Type_spec()))
+}
+func (sl *scopeListener) ExitFunction_spec(ctx *plsql.Function_specContext) { sl.pop() }
+func (sl *scopeListener) EnterProcedure_body(ctx *plsql.Procedure_bodyContext) {
+	sl.push(ctx, ctx.Identifier(), ProcedureSym, Type{})
+}
+func (sl *scopeListener) ExitProcedure_body(ctx *plsql.Procedure_bodyContext) { sl.pop() }
+func (sl *scopeListener) EnterFunction_body(ctx *plsql.Function_bodyContext) {
+	sl.push(ctx, ctx.Identifier(), FunctionSym, typeOfSpec(ctx.Type_spec()))
+}
+func (sl *scopeListener) ExitFunction_body(ctx *plsql.Function_bodyContext) { sl.pop() }
+func (sl *scopeListener) EnterCreate_trigger(ctx *plsql.Create_triggerContext) {
+	sl.push(ctx, ctx.Trigger_name(), 0, Type{})
+}
+func (sl *scopeListener) ExitCreate_trigger(ctx *plsql.Create_triggerContext) { sl.pop() }
+func (sl *scopeListener) EnterAnonymous_block(ctx *plsql.Anonymous_blockContext) {
+	sl.push(ctx, nil, 0, Type{})
+}
+func (sl *scopeListener) ExitAnonymous_block(ctx *plsql.Anonymous_blockContext) { sl.pop() }
+func (sl *scopeListener) EnterBlock(ctx *plsql.BlockContext)                    { sl.push(ctx, nil, 0, Type{}) }
+func (sl *scopeListener) ExitBlock(ctx *plsql.BlockContext)                     { sl.pop() }
+
+// EnterLoop_statement opens a scope for the index or record of a FOR loop.
+func (sl *scopeListener) EnterLoop_statement(ctx *plsql.Loop_statementContext) {
+	if ctx.FOR() == nil {
+		return
+	}
+	sl.push(ctx, nil, 0, Type{})
+	clp := ctx.Cursor_loop_param().(*plsql.Cursor_loop_paramContext)
+	if idx := clp.Index_name(); idx != nil {
+		sl.declare(idx, VariableSym, Type{Kind: NumberType, Name: "PLS_INTEGER"})
+		return
+	}
+	typ := Type{Kind: RecordType}
+	if cn := clp.Cursor_name(); cn != nil {
+		typ.Ref = catalog.Normalize(cn.GetText()) + "%ROWTYPE"
+	}
+	sl.declare(clp.Record_name(), VariableSym, typ)
+}
+func (sl *scopeListener) ExitLoop_statement(ctx *plsql.Loop_statementContext) {
+	if ctx.FOR() != nil {
+		sl.pop()
+	}
+}
+
+func (sl *scopeListener) ExitParameter(ctx *plsql.ParameterContext) {
+	sym := sl.declare(ctx.Parameter_name(), ParameterSym, typeOfSpec(ctx.Type_spec()))
+	sym.Mode = "IN"
+	if len(ctx.AllOUT()) != 0 || len(ctx.AllINOUT()) != 0 {
+		sym.Mode = "OUT"
+		if len(ctx.AllIN()) != 0 || len(ctx.AllINOUT()) != 0 {
+			sym.Mode = "IN OUT"
+		}
+	}
+}
+
+func (sl *scopeListener) ExitVariable_declaration(ctx *plsql.Variable_declarationContext) {
+	kind := VariableSym
+	if ctx.CONSTANT() != nil {
+		kind = ConstantSym
+	}
+	sl.declare(ctx.Identifier(), kind, typeOfSpec(ctx.Type_spec()))
+}
+
+func (sl *scopeListener) ExitSubtype_declaration(ctx *plsql.Subtype_declarationContext) {
+	typ := typeOfSpec(ctx.Type_spec())
+	typ.Name = catalog.Normalize(ctx.Identifier().GetText())
+	sl.declare(ctx.Identifier(), TypeSym, typ)
+}
+
+func (sl *scopeListener) ExitCursor_declaration(ctx *plsql.Cursor_declarationContext) {
+	typ := Type{Kind: CursorType}
+	if ts := ctx.Type_spec(); ts != nil {
+		rt := typeOfSpec(ts)
+		typ.Elem = &rt
+	}
+	sl.declare(ctx.Identifier(), CursorSym, typ)
+}
+
+func (sl *scopeListener) ExitException_declaration(ctx *plsql.Exception_declarationContext) {
+	sl.declare(ctx.Identifier(), ExceptionSym, Type{})
+}
+
+func (sl *scopeListener) ExitType_declaration(ctx *plsql.Type_declarationContext) {
+	typ := Type{Name: catalog.Normalize(ctx.Identifier().GetText())}
+	switch {
+	case ctx.Record_type_def() != nil:
+		typ.Kind = RecordType
+		for _, fs := range ctx.Record_type_def().(*plsql.Record_type_defContext).AllField_spec() {
+			fs := fs.(*plsql.Field_specContext)
+			typ.Fields = append(typ.Fields, Field{
+				Name: catalog.Normalize(fs.Column_name().GetText()),
+				Type: typeOfSpec(fs.Type_spec()),
+			})
+		}
+	case ctx.Table_type_def() != nil:
+		typ.Kind = CollectionType
+		elem := typeOfSpec(ctx.Table_type_def().(*plsql.Table_type_defContext).Type_spec())
+		typ.Elem = &elem
+	case ctx.Varray_type_def() != nil:
+		typ.Kind = CollectionType
+		elem := typeOfSpec(ctx.Varray_type_def().(*plsql.Varray_type_defContext).Type_spec())
+		typ.Elem = &elem
+	case ctx.Ref_cursor_type_def() != nil:
+		typ.Kind = CursorType
+	}
+	sl.declare(ctx.Identifier(), TypeSym, typ)
+}
+
+// refListener resolves the names used in a tree to their declarations.
+type refListener struct {
+	BaseWalkListener
+	st   *SymbolTable
+	tree int
+}
+
+func (rl *refListener) add(node antlr.ParserRuleContext, sym *Symbol, decl, qualified bool) {
+	// the last token is the name itself, without the schema or the character set introducer
+	tok := node.GetStop()
+	scope := sym.Scope
+	if !decl {
+		scope = rl.st.ScopeOf(node)
+	}
+	rl.st.References = append(rl.st.References, Reference{
+		Chunk:  Chunk{Start: tok.GetStart(), Stop: tok.GetStop(), Text: tok.GetText()},
+		Node:   node,
+		Symbol: rl.st.Canonical(sym), Tree: rl.tree,
+		Decl: decl, Qualified: qualified,
+		Scope: scope,
+	})
+}
+
+func (rl *refListener) EnterEveryRule(ctx antlr.ParserRuleContext) {
+	if sym := rl.st.decls[ctx]; sym != nil {
+		rl.add(ctx, sym, true, false)
+	}
+}
+
+// path resolves the dotted name: the first part in the scope of node, the rest in the scope opened by the previous part.
+// A leading schema name is skipped if the next part is a top-level object.
+// It returns the last symbol resolved.
+func (rl *refListener) path(node antlr.Tree, ids []antlr.ParserRuleContext) *Symbol {
+	if len(ids) == 0 {
+		return nil
+	}
+	scope := rl.st.ScopeOf(node)
+	sym := scope.Lookup(ids[0].GetText())
+	if sym == nil && len(ids) > 1 {
+		if sym = rl.st.Root.Local(ids[1].GetText()); sym == nil || sym.Own == nil {
+			return nil
+		}
+		ids = ids[1:]
+	}
+	if sym == nil {
+		tok := ids[0].GetStop()
+		rl.st.unresolved = append(rl.st.unresolved, Reference{
+			Chunk: Chunk{Start: tok.GetStart(), Stop: tok.GetStop(), Text: tok.GetText()},
+			Node:  ids[0], Tree: rl.tree, Scope: scope,
+		})
+		return nil
+	}
+	rl.add(ids[0], sym, false, false)
+	for _, id := range ids[1:] {
+		next := sym.Own.Local(id.GetText())
+		if next == nil {
+			break
+		}
+		rl.add(id, next, false, true)
+		sym = next
+	}
+	return sym
+}
+
+// elementIDs returns the names of the general element, up to the first call.
+func elementIDs(ge *plsql.General_elementContext, till antlr.Tree) []antlr.ParserRuleContext {
+	var ids []antlr.ParserRuleContext
+	for _, p := range ge.AllGeneral_element_part() {
+		p := p.(*plsql.General_element_partContext)
+		for _, id := range p.AllId_expression() {
+			ids = append(ids, id)
+		}
+		if p.Function_argument() != nil || p == till {
+			break
+		}
+	}
+	return ids
+}
+
+func (rl *refListener) ExitGeneral_element(ctx *plsql.General_elementContext) {
+	rl.path(ctx, elementIDs(ctx, nil))
+}
+
+func (rl *refListener) ExitVariable_name(ctx *plsql.Variable_nameContext) {
+	var ids []antlr.ParserRuleContext
+	for _, id := range ctx.AllId_expression() {
+		ids = append(ids, id)
+	}
+	rl.path(ctx, ids)
+}
+
+func (rl *refListener) ExitTable_element(ctx *plsql.Table_elementContext) {
+	var ids []antlr.ParserRuleContext
+	for _, id := range ctx.AllId_expression() {
+		ids = append(ids, id)
+	}
+	rl.path(ctx, ids)
+}
+
+func (rl *refListener) ExitType_name(ctx *plsql.Type_nameContext) {
+	var ids []antlr.ParserRuleContext
+	for _, id := range ctx.AllId_expression() {
+		ids = append(ids, id)
+	}
+	rl.path(ctx, ids)
+}
+
+func (rl *refListener) ExitRoutine_name(ctx *plsql.Routine_nameContext) {
+	rl.path(ctx, qualifiedIDs(ctx.Identifier(), ctx.AllId_expression()))
+}
+
+func (rl *refListener) ExitException_name(ctx *plsql.Exception_nameContext) {
+	rl.path(ctx, qualifiedIDs(ctx.Identifier(), ctx.AllId_expression()))
+}
+
+func qualifiedIDs(first plsql.IIdentifierContext, rest []plsql.IId_expressionContext) []antlr.ParserRuleContext {
+	ids := make([]antlr.ParserRuleContext, 0, 1+len(rest))
+	ids = append(ids, first)
+	for _, id := range rest {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ExitArgument resolves the parameter name of the named notation (name => value).
+func (rl *refListener) ExitArgument(ctx *plsql.ArgumentContext) {
+	id := ctx.Identifier()
+	if id == nil {
+		return
+	}
+	var callee *Symbol
+	switch p := ctx.GetParent().GetParent().(type) {
+	case *plsql.General_element_partContext:
+		ge, ok := p.GetParent().(*plsql.General_elementContext)
+		if !ok {
+			return
+		}
+		callee = rl.silently(func() *Symbol { return rl.path(ge, elementIDs(ge, p)) })
+	case *plsql.Procedure_callContext:
+		rn := p.Routine_name().(*plsql.Routine_nameContext)
+		callee = rl.silently(func() *Symbol { return rl.path(rn, qualifiedIDs(rn.Identifier(), rn.AllId_expression())) })
+	case *plsql.Function_callContext:
+		rn := p.Routine_name().(*plsql.Routine_nameContext)
+		callee = rl.silently(func() *Symbol { return rl.path(rn, qualifiedIDs(rn.Identifier(), rn.AllId_expression())) })
+	}
+	if callee == nil || callee.Own == nil {
+		return
+	}
+	if param := callee.Own.Local(id.GetText()); param != nil && param.Kind == ParameterSym {
+		rl.add(id, param, false, true)
+	}
+}
+
+// silently calls f without recording the references it finds.
+func (rl *refListener) silently(f func() *Symbol) *Symbol {
+	n := len(rl.st.References)
+	sym := f()
+	rl.st.References = rl.st.References[:n]
+	return sym
+}
+
+// ExitBody resolves the name after the END of a subprogram.
+func (rl *refListener) ExitBody(ctx *plsql.BodyContext) {
+	ln := ctx.Label_name()
+	if ln == nil {
+		return
+	}
+	if s := rl.st.scopes[ctx.GetParent()]; s != nil && s.owner != nil && s.Name == catalog.Normalize(ln.GetText()) {
+		rl.add(ln, s.owner, false, true)
+	}
+}
+
+// ExitCreate_package resolves the name after the END of the package specification.
+func (rl *refListener) ExitCreate_package(ctx *plsql.Create_packageContext) {
+	if pn := ctx.Package_name(1); pn != nil {
+		if s := rl.st.scopes[ctx]; s != nil && s.owner != nil {
+			rl.add(pn, s.owner, false, true)
+		}
+	}
+}
+
+// ExitCreate_package_body resolves the package names of the body.
+func (rl *refListener) ExitCreate_package_body(ctx *plsql.Create_package_bodyContext) {
+	for _, pn := range ctx.AllPackage_name() {
+		if rl.st.decls[pn] != nil {
+			continue
+		}
+		if sym := rl.st.Root.Local(pn.GetText()); sym != nil && sym.Kind == PackageSym {
+			rl.add(pn, sym, false, true)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"strings"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// Injection is a possible SQL injection: an unsanitized value flowing into dynamic SQL.
+type Injection struct {
+	// Sink is the dynamic SQL: EXECUTE IMMEDIATE, OPEN FOR or DBMS_SQL.PARSE.
+	Sink Diagnostic
+	// Flow is the path of the value, from the source parameter to the last assignment before the sink.
+	Flow []Diagnostic
+}
+
+func (inj Injection) String() string {
+	var buf strings.Builder
+	buf.WriteString(inj.Sink.String())
+	for _, d := range inj.Flow {
+		buf.WriteString("\n\t")
+		buf.WriteString(d.String())
+	}
+	return buf.String()
+}
+
+// sanitizers return values safe to concatenate into SQL: integers, converted to text the same way in any session.
+//
+// Dates and other numbers are not: their implicit conversion depends on NLS_DATE_FORMAT
+// and NLS_NUMERIC_CHARACTERS, which may inject text (lateral SQL injection).
+var sanitizers = map[string]bool{
+	"LENGTH": true, "INSTR": true, "COUNT": true,
+}
+
+func isSanitizer(name string) bool {
+	name = strings.TrimPrefix(name, "SYS.")
+	return strings.HasPrefix(name, "DBMS_ASSERT.") || sanitizers[name]
+}
+
+// FindInjections follows the parameters (but the BOOLEAN ones) of the public subprograms
+// (declared in a package specification, or standalone) through concatenations, assignments
+// and calls into EXECUTE IMMEDIATE, OPEN FOR and DBMS_SQL.PARSE.
+//
+// Values passed through DBMS_ASSERT, formatted by TO_CHAR with a literal format mask (without the D, G, L, C and U
+// number format elements), or passed as bind variables (USING) are safe. Dates and numbers are not, as their implicit conversion depends on the NLS settings.
+// The analysis is flow-insensitive: a variable is tainted if any assignment taints it.
+func FindInjections(files []File, opts ...Options) ([]Injection, error) {
+	trees, err := parseFiles(files, options(opts))
+	if err != nil {
+		return nil, err
+	}
+	st := NewSymbolTable(trees...)
+	ta := &taintAnalysis{
+		BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()},
+		st:               st, files: files,
+		refs:    make(map[antlr.Tree]Reference),
+		tainted: make(map[*Symbol][]Diagnostic),
+	}
+	for _, ref := range st.References {
+		if !ref.Decl {
+			ta.refs[ref.Node] = ref
+			continue
+		}
+		sym := ref.Symbol
+		if sym.Kind != ParameterSym || ta.tainted[sym] != nil || !strings.Contains(sym.Mode, "IN") {
+			continue
+		}
+		if sym.Type.Kind == BooleanType {
+			continue
+		}
+		if owner := sym.Scope.owner; owner != nil {
+			if owner = st.Canonical(owner); owner.Scope == st.Root || isSpec(owner.Scope) {
+				ta.tainted[sym] = []Diagnostic{ta.diagnostic(ref.Tree, ref.Node, "source: parameter %s", st.qualifiedName(sym))}
+			}
+		}
+	}
+	for i, tree := range trees {
+		ta.tree = i
+		antlr.ParseTreeWalkerDefault.Walk(ta, tree)
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, e := range ta.edges {
+			if ta.tainted[e.target] != nil {
+				continue
+			}
+			if flow := ta.taintOf(e.expr); flow != nil {
+				ta.tainted[e.target] = append(append(make([]Diagnostic, 0, len(flow)+1), flow...), e.step)
+				changed = true
+			}
+		}
+	}
+	var injections []Injection
+	for _, s := range ta.sinks {
+		if flow := ta.taintOf(s.expr); flow != nil {
+			injections = append(injections, Injection{Sink: s.step, Flow: flow})
+		}
+	}
+	return injections, nil
+}
+
+type taintAnalysis struct {
+	BaseWalkListener
+	st      *SymbolTable
+	files   []File
+	tree    int
+	refs    map[antlr.Tree]Reference
+	tainted map[*Symbol][]Diagnostic
+	edges   []taintEdge
+	sinks   []taintEdge
+}
+
+// taintEdge is an assignment of expr to the target, or the dynamic SQL of a sink (without target).
+type taintEdge struct {
+	target *Symbol
+	expr   antlr.Tree
+	step   Diagnostic
+}
+
+func (ta *taintAnalysis) diagnostic(tree int, ctx antlr.ParserRuleContext, format string, args ...interface{}) Diagnostic {
+	d := newDiagnostic(ctx, format, args...)
+	d.File = ta.files[tree].Name
+	return d
+}
+
+// taintOf returns the flow of the first tainted value in the expression, or nil.
+func (ta *taintAnalysis) taintOf(node antlr.Tree) []Diagnostic {
+	switch x := node.(type) {
+	case *plsql.Bind_variableContext:
+		return nil
+	case *plsql.General_elementContext:
+		if name, call := elementName(x); call && (isSanitizer(name) || name == "TO_CHAR" && hasSafeMask(x)) {
+			return nil
+		}
+	case antlr.ParserRuleContext:
+		if ref, ok := ta.refs[x]; ok {
+			if flow := ta.tainted[ref.Symbol]; flow != nil {
+				return flow
+			}
+		}
+	}
+	for _, ch := range node.GetChildren() {
+		if flow := ta.taintOf(ch); flow != nil {
+			return flow
+		}
+	}
+	return nil
+}
+
+// hasSafeMask reports whether the call (of TO_CHAR) has a string literal format mask,
+// which is not a number mask with NLS dependent elements: D and G (NLS_NUMERIC_CHARACTERS),
+// L, C and U (NLS_CURRENCY, NLS_ISO_CURRENCY, NLS_DUAL_CURRENCY).
+func hasSafeMask(ge *plsql.General_elementContext) bool {
+	for _, p := range ge.AllGeneral_element_part() {
+		fa, ok := p.(*plsql.General_element_partContext).Function_argument().(*plsql.Function_argumentContext)
+		if !ok {
+			continue
+		}
+		args := fa.AllArgument()
+		if len(args) < 2 || !strings.HasPrefix(args[1].GetText(), "'") {
+			return false
+		}
+		mask := strings.ToUpper(args[1].GetText())
+		return !strings.ContainsAny(mask, "09") || !strings.ContainsAny(mask, "DGLCU")
+	}
+	return false
+}
+
+// elementName returns the dotted name of the general element up to the first call, and whether it is a call.
+func elementName(ge *plsql.General_elementContext) (string, bool) {
+	var names []string
+	for _, p := range ge.AllGeneral_element_part() {
+		p := p.(*plsql.General_element_partContext)
+		for _, id := range p.AllId_expression() {
+			names = append(names, catalog.Normalize(id.GetText()))
+		}
+		if p.Function_argument() != nil {
+			return strings.Join(names, "."), true
+		}
+	}
+	return strings.Join(names, "."), false
+}
+
+// symbolOf returns the symbol the name refers to.
+func (ta *taintAnalysis) symbolOf(id antlr.Tree) *Symbol {
+	if ref, ok := ta.refs[id]; ok {
+		return ref.Symbol
+	}
+	return nil
+}
+
+func (ta *taintAnalysis) ExitAssignment_statement(ctx *plsql.Assignment_statementContext) {
+	ge, ok := ctx.General_element().(*plsql.General_elementContext)
+	if !ok {
+		return
+	}
+	if ids := elementIDs(ge, nil); len(ids) != 0 {
+		if target := ta.symbolOf(ids[0]); target != nil {
+			ta.edges = append(ta.edges, taintEdge{target: target, expr: ctx.Expression(),
+				step: ta.diagnostic(ta.tree, ctx, "assigned to %s", target.Name)})
+		}
+	}
+}
+
+func (ta *taintAnalysis) ExitVariable_declaration(ctx *plsql.Variable_declarationContext) {
+	dv, ok := ctx.Default_value_part().(*plsql.Default_value_partContext)
+	if !ok {
+		return
+	}
+	if target := ta.st.Canonical(ta.st.decls[ctx.Identifier()]); target != nil {
+		ta.edges = append(ta.edges, taintEdge{target: target, expr: dv.Expression(),
+			step: ta.diagnostic(ta.tree, ctx, "assigned to %s", target.Name)})
+	}
+}
+
+func (ta *taintAnalysis) ExitExecute_immediate(ctx *plsql.Execute_immediateContext) {
+	ta.sinks = append(ta.sinks, taintEdge{expr: ctx.Expression(),
+		step: ta.diagnostic(ta.tree, ctx, "possible SQL injection: EXECUTE IMMEDIATE of an unsanitized value")})
+}
+
+func (ta *taintAnalysis) ExitOpen_for_statement(ctx *plsql.Open_for_statementContext) {
+	if e := ctx.Expression(); e != nil {
+		ta.sinks = append(ta.sinks, taintEdge{expr: e,
+			step: ta.diagnostic(ta.tree, ctx, "possible SQL injection: OPEN FOR of an unsanitized value")})
+	}
+}
+
+func (ta *taintAnalysis) ExitGeneral_element(ctx *plsql.General_elementContext) {
+	var names []string
+	for _, p := range ctx.AllGeneral_element_part() {
+		p := p.(*plsql.General_element_partContext)
+		ids := p.AllId_expression()
+		for _, id := range ids {
+			names = append(names, catalog.Normalize(id.GetText()))
+		}
+		if fa := p.Function_argument(); fa != nil && len(ids) != 0 {
+			ta.call(p, strings.Join(names, "."), ids[len(ids)-1], fa)
+			return
+		}
+	}
+}
+
+func (ta *taintAnalysis) ExitProcedure_call(ctx *plsql.Procedure_callContext) {
+	ta.routineCall(ctx, ctx.Routine_name(), ctx.Function_argument())
+}
+
+func (ta *taintAnalysis) ExitFunction_call(ctx *plsql.Function_callContext) {
+	ta.routineCall(ctx, ctx.Routine_name(), ctx.Function_argument())
+}
+
+func (ta *taintAnalysis) routineCall(ctx antlr.ParserRuleContext, rn plsql.IRoutine_nameContext, fa plsql.IFunction_argumentContext) {
+	if fa == nil {
+		return
+	}
+	r := rn.(*plsql.Routine_nameContext)
+	ids := qualifiedIDs(r.Identifier(), r.AllId_expression())
+	ta.call(ctx, routineName(rn), ids[len(ids)-1], fa)
+}
+
+// call records the DBMS_SQL.PARSE sinks, and the arguments passed to the parameters of the called subprogram.
+func (ta *taintAnalysis) call(ctx antlr.ParserRuleContext, name string, last antlr.Tree, fa plsql.IFunction_argumentContext) {
+	if strings.TrimPrefix(name, "SYS.") == "DBMS_SQL.PARSE" {
+		if e := dbmsSQLStatement(fa); e != nil {
+			ta.sinks = append(ta.sinks, taintEdge{expr: e,
+				step: ta.diagnostic(ta.tree, ctx, "possible SQL injection: DBMS_SQL.PARSE of an unsanitized value")})
+		}
+		return
+	}
+	args := fa.(*plsql.Function_argumentContext).AllArgument()
+	callee := ta.symbolOf(last)
+	if callee == nil || callee.Own == nil || (callee.Kind != ProcedureSym && callee.Kind != FunctionSym) {
+		return
+	}
+	var params []*Symbol
+	for _, sym := range callee.Own.Symbols {
+		if sym.Kind == ParameterSym {
+			params = append(params, sym)
+		}
+	}
+	for i, a := range args {
+		a := a.(*plsql.ArgumentContext)
+		var param *Symbol
+		if id := a.Identifier(); id != nil {
+			param = callee.Own.Local(id.GetText())
+		} else if i < len(params) {
+			param = params[i]
+		}
+		if param != nil && param.Kind == ParameterSym {
+			ta.edges = append(ta.edges, taintEdge{target: param, expr: a.Expression(),
+				step: ta.diagnostic(ta.tree, a, "passed as %s to %s", param.Name, ta.st.qualifiedName(callee))})
+		}
+	}
+}
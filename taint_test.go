@@ -0,0 +1,92 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestFindInjections(t *testing.T) {
+	files := []plsqlparser.File{
+		{Name: "rep.pks", Text: `CREATE OR REPLACE PACKAGE rep IS
+  PROCEDURE run(p_table IN VARCHAR2, p_where IN VARCHAR2, p_id IN NUMBER);
+END rep;
+`},
+		{Name: "rep.pkb", Text: `CREATE OR REPLACE PACKAGE BODY rep IS
+  PROCEDURE exec_sql(p_sql IN VARCHAR2) IS
+    c INTEGER := DBMS_SQL.OPEN_CURSOR;
+  BEGIN
+    DBMS_SQL.PARSE(c, p_sql, DBMS_SQL.NATIVE);
+  END exec_sql;
+
+  PROCEDURE run(p_table IN VARCHAR2, p_where IN VARCHAR2, p_id IN NUMBER) IS
+    v_sql VARCHAR2(1000);
+    v_safe VARCHAR2(1000) := 'SELECT * FROM ' || DBMS_ASSERT.SQL_OBJECT_NAME(p_table);
+    cur SYS_REFCURSOR;
+  BEGIN
+    EXECUTE IMMEDIATE v_safe || ' WHERE id = :1' USING p_where;
+    EXECUTE IMMEDIATE 'DELETE FROM t WHERE id = ' || p_id;
+    v_sql := 'DELETE FROM t WHERE ' || p_where;
+    OPEN cur FOR 'SELECT 1 FROM DUAL WHERE ' || UPPER(p_where);
+    exec_sql(v_sql);
+  END run;
+END rep;
+`},
+	}
+	injections, err := plsqlparser.FindInjections(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, inj := range injections {
+		got = append(got, inj.String())
+	}
+	want := []string{
+		`rep.pkb:5:5: possible SQL injection: DBMS_SQL.PARSE of an unsanitized value
+	rep.pks:2:38: source: parameter REP.RUN.P_WHERE
+	rep.pkb:15:5: assigned to V_SQL
+	rep.pkb:17:14: passed as P_SQL to REP.EXEC_SQL`,
+		`rep.pkb:14:5: possible SQL injection: EXECUTE IMMEDIATE of an unsanitized value
+	rep.pks:2:59: source: parameter REP.RUN.P_ID`,
+		`rep.pkb:16:5: possible SQL injection: OPEN FOR of an unsanitized value
+	rep.pks:2:38: source: parameter REP.RUN.P_WHERE`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwanted\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestFindInjectionsConversions(t *testing.T) {
+	injections, err := plsqlparser.FindInjections([]plsqlparser.File{{Name: "rep.sql", Text: `CREATE OR REPLACE PROCEDURE rep(p_day IN VARCHAR2, p_date IN DATE) IS
+BEGIN
+  EXECUTE IMMEDIATE 'DELETE FROM t WHERE d = ' || TO_DATE(p_day);
+  EXECUTE IMMEDIATE 'DELETE FROM t WHERE n = ' || TO_NUMBER(p_day);
+  EXECUTE IMMEDIATE 'DELETE FROM t WHERE d = ' || TO_CHAR(TO_DATE(p_day, 'YYYY-MM-DD'), 'YYYYMMDD');
+  EXECUTE IMMEDIATE 'DELETE FROM t WHERE l = ' || LENGTH(p_day);
+  EXECUTE IMMEDIATE 'DELETE FROM t WHERE d = ' || p_date;
+  EXECUTE IMMEDIATE 'DELETE FROM t WHERE n = ' || TO_CHAR(TO_NUMBER(p_day), '999D99');
+  EXECUTE IMMEDIATE 'DELETE FROM t WHERE n = ' || TO_CHAR(TO_NUMBER(p_day), 'FM9999');
+END;
+`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, inj := range injections {
+		got = append(got, inj.Sink.String())
+	}
+	want := []string{
+		"rep.sql:3:3: possible SQL injection: EXECUTE IMMEDIATE of an unsanitized value",
+		"rep.sql:4:3: possible SQL injection: EXECUTE IMMEDIATE of an unsanitized value",
+		"rep.sql:7:3: possible SQL injection: EXECUTE IMMEDIATE of an unsanitized value",
+		"rep.sql:8:3: possible SQL injection: EXECUTE IMMEDIATE of an unsanitized value",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwanted\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,38 @@
+REM Tokenizer corpus, compared with the PlSqlLexer
+PROMPT creating the package
+CREATE OR REPLACE PACKAGE BODY "My Pkg" AS
+  -- constants
+  C_PI CONSTANT NUMBER := 3.14159;
+  C_EXP CONSTANT BINARY_DOUBLE := 1.5E-3D + 2F - .5 * 1E10;
+  C_MSG CONSTANT VARCHAR2(100) := 'It''s a ''quoted'' string';
+  C_Q CONSTANT VARCHAR2(100) := Q'[It's [bracketed]]' || Q'{x}' || Q'!y!';
+  C_N CONSTANT NVARCHAR2(10) := N'nemzeti';
+  C_HEX CONSTANT RAW(2) := X'0AFF';
+
+  /* block
+     comment */
+  FUNCTION F(P_X IN NUMBER, P_Y IN NUMBER DEFAULT 2) RETURN NUMBER IS
+    V_I PLS_INTEGER;
+    V#TMP$ NUMBER;
+  BEGIN
+    <<OUTER_LOOP>>
+    FOR V_I IN 1..10 LOOP
+      IF P_X ** 2 <> P_Y AND P_X != 1 AND P_X ^= 2 AND P_X ~= 3 AND P_X <= 4 OR P_X >= 5 THEN
+        EXIT OUTER_LOOP WHEN V_I > 5;
+      END IF;
+    END LOOP OUTER_LOOP;
+    SELECT COUNT(*) INTO V#TMP$ FROM EMP@REMOTE_DB E WHERE E.EMPNO = :B1 AND E.ENAME = :"x" AND E.SAL > :1;
+    RETURN G(P_A => P_X, P_B => V_I) * 100 / 2 - 1;
+  EXCEPTION
+    WHEN NO_DATA_FOUND THEN
+      RETURN NULL;
+  END F;
+
+  PROCEDURE P(P_REC IN EMP%ROWTYPE) IS
+    V_TYPE EMP.ENAME%TYPE := P_REC.ENAME;
+  BEGIN
+    DBMS_OUTPUT.PUT_LINE('a' || 'b' || TO_CHAR(SYSDATE, 'YYYY-MM-DD'));
+  END P;
+END "My Pkg";
+/
+@install.sql
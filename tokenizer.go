@@ -0,0 +1,400 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Tokenizer splits PL/SQL text into tokens, without ANTLR.
+//
+// It splits the text at the same places as the generated PlSqlLexer
+// (on the uppercased text), except that it keeps the compound operators
+// (=>, <=, >=, ||, <<, >>) and the <<labels>> in one token.
+// It is case insensitive, and allocates only for strings with doubled quotes.
+type Tokenizer struct {
+	text         string
+	pos          int // byte offset
+	rpos         int // rune offset
+	line, column int
+	seenDefault  bool
+}
+
+// NewTokenizer returns a Tokenizer for the text.
+func NewTokenizer(text string) *Tokenizer { return &Tokenizer{text: text, line: 1} }
+
+// Tokenize returns all the tokens of the text, including the spaces and comments (see Token.Hidden).
+func Tokenize(text string) ([]Token, error) {
+	tz := NewTokenizer(text)
+	tokens := make([]Token, 0, len(text)/4)
+	for {
+		tok := tz.Next()
+		if tok.Err == io.EOF {
+			return tokens, nil
+		}
+		if tok.Err != nil {
+			return tokens, tok.Err
+		}
+		tokens = append(tokens, tok)
+	}
+}
+
+// Next returns the next token, with io.EOF as Err at the end of the text.
+//
+// An unknown character is returned with an error, and skipped.
+func (tz *Tokenizer) Next() Token {
+	text, start := tz.text, tz.pos
+	if start >= len(text) {
+		return Token{Err: io.EOF, Chunk: Chunk{Start: tz.rpos, Stop: tz.rpos - 1}, Line: tz.line, Column: tz.column}
+	}
+	c := text[start]
+	var next byte
+	if start+1 < len(text) {
+		next = text[start+1]
+	}
+	typ, end := OpTok, start+1
+	var value string
+
+	switch {
+	case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		typ, end = SpaceTok, tz.skip(start, func(c byte) bool { return c == ' ' || c == '\t' || c == '\r' || c == '\n' })
+
+	case c == '-' && next == '-':
+		typ, end = LineCommentTok, tz.eol(start)
+		value = strings.TrimRight(text[start+2:end], "\r\n")
+
+	case c == '/' && next == '*' && strings.Contains(text[start+2:], "*/"):
+		typ, end = BlockCommentTok, start+2+strings.Index(text[start+2:], "*/")+2
+		value = text[start+2 : end-2]
+
+	case (c == 'R' || c == 'r') && tz.lineStart(start) && tz.sqlplus(start, "REM", "ARK"):
+		typ, end = LineCommentTok, tz.eol(start)
+		value = strings.TrimSpace(text[start+tz.wordLen(start) : end])
+
+	case (c == 'P' || c == 'p') && tz.lineStart(start) && tz.sqlplus(start, "PRO", "MPT"):
+		typ, end = SQLPlusTok, tz.eol(start)
+		value = strings.TrimRight(text[start:end], "\r\n")
+
+	case c == '@' && tz.lineStart(start):
+		typ, end = SQLPlusTok, tz.eol(start)
+		value = strings.TrimRight(text[start:end], "\r\n")
+
+	case c == '\'':
+		typ = StringTok
+		var err error
+		if end, value, err = tz.quoted(start, '\''); err != nil {
+			return tz.token(typ, start, len(text), "", err)
+		}
+
+	case (c == 'N' || c == 'n') && next == '\'':
+		typ = StringTok
+		var err error
+		if end, value, err = tz.quoted(start+1, '\''); err != nil {
+			return tz.token(typ, start, len(text), "", err)
+		}
+
+	case (c == 'Q' || c == 'q') && next == '\'' && start+2 < len(text):
+		typ = StringTok
+		closing := text[start+2]
+		switch closing {
+		case '[':
+			closing = ']'
+		case '{':
+			closing = '}'
+		case '(':
+			closing = ')'
+		case '<':
+			closing = '>'
+		}
+		i := strings.Index(text[start+3:], string([]byte{closing, '\''}))
+		if i < 0 {
+			return tz.token(typ, start, len(text), "", errors.Errorf("%d:%d: unterminated q'' string", tz.line, tz.column+1))
+		}
+		value, end = text[start+3:start+3+i], start+3+i+2
+
+	case (c == 'B' || c == 'b') && next == '\'' && tz.digitString(start+1, "01"),
+		(c == 'X' || c == 'x') && next == '\'' && tz.digitString(start+1, "0123456789ABCDEFabcdef"):
+		typ, end = StringTok, tz.skipDigitStrings(start+1)
+		value = strings.ReplaceAll(text[start+2:end-1], "''", "")
+
+	case c == '"':
+		typ = AtomTok
+		var err error
+		if end, value, err = tz.quoted(start, '"'); err != nil || end == start+2 {
+			if err == nil {
+				err = errors.Errorf("%d:%d: empty quoted identifier", tz.line, tz.column+1)
+			}
+			return tz.token(typ, start, len(text), "", err)
+		}
+
+	case isLetter(c) || c >= utf8.RuneSelf && tz.isUnicodeLetter(start):
+		typ, end = AtomTok, tz.skipWord(start)
+		value = text[start:end]
+
+	case isDigit(rune(c)) || c == '.' && isDigit(rune(next)):
+		typ, end = NumberTok, tz.number(start)
+		value = text[start:end]
+
+	case c == ':':
+		switch {
+		case next == '=':
+			end = start + 2
+		case isLetter(next):
+			typ = BindTok
+			end = tz.skip(start+1, func(c byte) bool { return isLetter(c) || isDigit(rune(c)) || c == '_' })
+			value = text[start+1 : end]
+		case isDigit(rune(next)):
+			typ = BindTok
+			end = tz.skip(start+1, func(c byte) bool { return isDigit(rune(c)) })
+			value = text[start+1 : end]
+		case next == '"':
+			if e, v, err := tz.quoted(start+1, '"'); err == nil {
+				typ, end, value = BindTok, e, v
+			}
+		}
+	case c == '?':
+		typ = BindTok
+
+	case c == '<' && next == '<':
+		if i := strings.Index(text[start+2:], ">>"); i > 0 && tz.skipWord(start+2) == start+2+i {
+			typ, end, value = LabelTok, start+2+i+2, text[start+2:start+2+i]
+		} else {
+			end = start + 2
+		}
+	case c == '<' && (next == '>' || next == '='),
+		c == '>' && (next == '=' || next == '>'),
+		c == '=' && next == '>',
+		(c == '!' || c == '^' || c == '~') && next == '=',
+		c == '*' && next == '*',
+		c == '|' && next == '|',
+		c == '.' && next == '.':
+		end = start + 2
+
+	case c == '(':
+		typ = OpenParenTok
+	case c == ')':
+		typ = CloseParenTok
+	case c == ',':
+		typ = CommaTok
+	case c == ';':
+		typ = EndTok
+
+	case c == '%':
+		if j := tz.attribute(start + 1); j > 0 {
+			end = j
+		}
+	case strings.IndexByte(".&*+-/@=<>!^~|[]_", c) >= 0:
+
+	default:
+		_, size := utf8.DecodeRuneInString(text[start:])
+		return tz.token(OpTok, start, start+size, "", errors.Errorf("%d:%d: unknown character %q", tz.line, tz.column+1, text[start:start+size]))
+	}
+	if typ == OpTok && value == "" {
+		value = text[start:end]
+	}
+	return tz.token(typ, start, end, value, nil)
+}
+
+// token returns the token of text[start:end], and advances the position to its end.
+func (tz *Tokenizer) token(typ TokenType, start, end int, value string, err error) Token {
+	s := tz.text[start:end]
+	n := len(s)
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			n = utf8.RuneCountInString(s)
+			break
+		}
+	}
+	tok := Token{Type: typ, Value: value, Err: err, Chunk: Chunk{Text: s, Start: tz.rpos, Stop: tz.rpos + n - 1},
+		Line: tz.line, Column: tz.column}
+	switch typ {
+	case OpenParenTok, CloseParenTok, CommaTok, EndTok:
+		tok.Value = ""
+	}
+	if !tok.Hidden() {
+		tz.seenDefault = true
+	}
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		tz.line += strings.Count(s, "\n")
+		tz.column = utf8.RuneCountInString(s[i+1:])
+	} else {
+		tz.column += n
+	}
+	tz.pos, tz.rpos = end, tz.rpos+n
+	return tok
+}
+
+func isLetter(c byte) bool { return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' }
+
+func (tz *Tokenizer) isUnicodeLetter(i int) bool {
+	r, _ := utf8.DecodeRuneInString(tz.text[i:])
+	return unicode.IsLetter(r)
+}
+
+// skip the bytes while ok.
+func (tz *Tokenizer) skip(i int, ok func(byte) bool) int {
+	for i < len(tz.text) && ok(tz.text[i]) {
+		i++
+	}
+	return i
+}
+
+// skipWord skips the identifier: letters, digits, $, _ and #.
+func (tz *Tokenizer) skipWord(i int) int {
+	for i < len(tz.text) {
+		c := tz.text[i]
+		if c < utf8.RuneSelf {
+			if !(isLetter(c) || isDigit(rune(c)) || c == '$' || c == '_' || c == '#') {
+				return i
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(tz.text[i:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return i
+		}
+		i += size
+	}
+	return i
+}
+
+// wordLen returns the length of the word at i.
+func (tz *Tokenizer) wordLen(i int) int { return tz.skipWord(i) - i }
+
+// eol returns the end of the line, after the newline.
+func (tz *Tokenizer) eol(i int) int {
+	if j := strings.IndexByte(tz.text[i:], '\n'); j >= 0 {
+		return i + j + 1
+	}
+	return len(tz.text)
+}
+
+// lineStart reports whether a SQL*Plus command can start at i,
+// as the PlSqlLexer's IsNewlineAtPos: at the start of a line, or before the first token.
+func (tz *Tokenizer) lineStart(i int) bool {
+	return !tz.seenDefault || i == 0 || tz.text[i-1] == '\n'
+}
+
+// sqlplus reports whether the abbreviated SQL*Plus command (prefix, optional suffix)
+// is at i, followed by a space or the end of the line.
+func (tz *Tokenizer) sqlplus(i int, prefix, suffix string) bool {
+	text := tz.text[i:]
+	if len(text) < len(prefix) || !strings.EqualFold(text[:len(prefix)], prefix) {
+		return false
+	}
+	text = text[len(prefix):]
+	if len(text) >= len(suffix) && strings.EqualFold(text[:len(suffix)], suffix) {
+		text = text[len(suffix):]
+	}
+	return text == "" || text[0] == ' ' || text[0] == '\n' || strings.HasPrefix(text, "\r\n")
+}
+
+// attributes are the words of the %TYPE, %ROWTYPE... attributes, which are one token after the %.
+var attributes = [...]string{"FOUND", "ISOPEN", "NOTFOUND", "ROWCOUNT", "ROWTYPE", "TYPE"}
+
+// attribute returns the end of the attribute word at i, after optional spaces, or -1.
+func (tz *Tokenizer) attribute(i int) int {
+	i = tz.skip(i, func(c byte) bool { return c == ' ' || c == '\t' })
+	for _, a := range attributes {
+		if len(tz.text)-i >= len(a) && strings.EqualFold(tz.text[i:i+len(a)], a) {
+			return i + len(a)
+		}
+	}
+	return -1
+}
+
+// quoted returns the end and the unquoted value of the quote-delimited string or identifier at i.
+// Doubled quotes stand for one.
+func (tz *Tokenizer) quoted(i int, quote byte) (int, string, error) {
+	var doubled bool
+	for j := i + 1; j < len(tz.text); j++ {
+		c := tz.text[j]
+		if quote == '"' && (c == '\n' || c == '\r') {
+			break
+		}
+		if c != quote {
+			continue
+		}
+		if j+1 < len(tz.text) && tz.text[j+1] == quote {
+			doubled = true
+			j++
+			continue
+		}
+		value := tz.text[i+1 : j]
+		if doubled {
+			q := string([]byte{quote})
+			value = strings.ReplaceAll(value, q+q, q)
+		}
+		return j + 1, value, nil
+	}
+	return len(tz.text), "", errors.Errorf("%d:%d: unterminated %c", tz.line, tz.column+1, quote)
+}
+
+// digitString reports whether a B'0101' or X'FF' string, with only the digits, starts at i.
+func (tz *Tokenizer) digitString(i int, digits string) bool {
+	j := strings.IndexByte(tz.text[i+1:], '\'')
+	if j < 0 {
+		return false
+	}
+	for _, c := range []byte(tz.text[i+1 : i+1+j]) {
+		if strings.IndexByte(digits, c) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// skipDigitStrings skips the '...' parts of a B or X string ('01' '10' is one string).
+func (tz *Tokenizer) skipDigitStrings(i int) int {
+	for i < len(tz.text) && tz.text[i] == '\'' {
+		j := strings.IndexByte(tz.text[i+1:], '\'')
+		if j < 0 {
+			break
+		}
+		i += j + 2
+	}
+	return i
+}
+
+// number returns the end of the number at i: 123, 1.5, .5, 1.5E-3, 2F, 1.0D.
+func (tz *Tokenizer) number(i int) int {
+	digits := func(i int) int { return tz.skip(i, func(c byte) bool { return isDigit(rune(c)) }) }
+	// FLOAT_FRAGMENT: [0-9]* '.'? [0-9]+
+	fraction := func(i int) int {
+		j := digits(i)
+		if j < len(tz.text) && tz.text[j] == '.' {
+			if k := digits(j + 1); k > j+1 {
+				return k
+			}
+		}
+		if j > i {
+			return j
+		}
+		return -1
+	}
+	end := fraction(i)
+	if end < 0 {
+		return i + 1
+	}
+	if end < len(tz.text) && (tz.text[end] == 'E' || tz.text[end] == 'e') {
+		j := end + 1
+		if j < len(tz.text) && (tz.text[j] == '+' || tz.text[j] == '-') {
+			j++
+		}
+		if k := fraction(j); k > 0 {
+			end = k
+		}
+	}
+	if end < len(tz.text) && strings.IndexByte("DdFf", tz.text[end]) >= 0 {
+		end++
+	}
+	return end
+}
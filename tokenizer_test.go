@@ -0,0 +1,185 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+func TestTokenizer(t *testing.T) {
+	for _, tc := range []struct {
+		In   string
+		Want []plsqlparser.Token
+	}{
+		{In: `q'[it's]' n'x' 'a''b' "Quoted Name"`, Want: []plsqlparser.Token{
+			{Type: plsqlparser.StringTok, Value: "it's"},
+			{Type: plsqlparser.StringTok, Value: "x"},
+			{Type: plsqlparser.StringTok, Value: "a'b"},
+			{Type: plsqlparser.AtomTok, Value: "Quoted Name"},
+		}},
+		{In: `1 1.5 .5 1e-3 2.5E+10F 3d 1..10`, Want: []plsqlparser.Token{
+			{Type: plsqlparser.NumberTok, Value: "1"},
+			{Type: plsqlparser.NumberTok, Value: "1.5"},
+			{Type: plsqlparser.NumberTok, Value: ".5"},
+			{Type: plsqlparser.NumberTok, Value: "1e-3"},
+			{Type: plsqlparser.NumberTok, Value: "2.5E+10F"},
+			{Type: plsqlparser.NumberTok, Value: "3d"},
+			{Type: plsqlparser.NumberTok, Value: "1"},
+			{Type: plsqlparser.OpTok, Value: ".."},
+			{Type: plsqlparser.NumberTok, Value: "10"},
+		}},
+		{In: `a=>b<>c!=d^=e**f<<lbl>>g%type@db||:x`, Want: []plsqlparser.Token{
+			{Type: plsqlparser.AtomTok, Value: "a"},
+			{Type: plsqlparser.OpTok, Value: "=>"},
+			{Type: plsqlparser.AtomTok, Value: "b"},
+			{Type: plsqlparser.OpTok, Value: "<>"},
+			{Type: plsqlparser.AtomTok, Value: "c"},
+			{Type: plsqlparser.OpTok, Value: "!="},
+			{Type: plsqlparser.AtomTok, Value: "d"},
+			{Type: plsqlparser.OpTok, Value: "^="},
+			{Type: plsqlparser.AtomTok, Value: "e"},
+			{Type: plsqlparser.OpTok, Value: "**"},
+			{Type: plsqlparser.AtomTok, Value: "f"},
+			{Type: plsqlparser.LabelTok, Value: "lbl"},
+			{Type: plsqlparser.AtomTok, Value: "g"},
+			{Type: plsqlparser.OpTok, Value: "%type"},
+			{Type: plsqlparser.OpTok, Value: "@"},
+			{Type: plsqlparser.AtomTok, Value: "db"},
+			{Type: plsqlparser.OpTok, Value: "||"},
+			{Type: plsqlparser.BindTok, Value: "x"},
+		}},
+	} {
+		tokens, err := plsqlparser.Tokenize(tc.In)
+		if err != nil {
+			t.Fatalf("%q: %+v", tc.In, err)
+		}
+		var got []plsqlparser.Token
+		for _, tok := range tokens {
+			if !tok.Hidden() {
+				got = append(got, plsqlparser.Token{Type: tok.Type, Value: tok.Value})
+			}
+		}
+		if len(got) != len(tc.Want) {
+			t.Errorf("%q: got %d tokens (%v), wanted %d", tc.In, len(got), got, len(tc.Want))
+			continue
+		}
+		for i, want := range tc.Want {
+			if got[i] != want {
+				t.Errorf("%q: %d. got %+v, wanted %+v", tc.In, i, got[i], want)
+			}
+		}
+	}
+
+	tokens, err := plsqlparser.Tokenize("SELECT\n  'é' FROM dual")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok := tokens[len(tokens)-1]; tok.Start != 18 || tok.Line != 2 || tok.Column != 11 {
+		t.Errorf("position of %q: got %d (%d:%d), wanted 18 (2:11)", tok.Text, tok.Start, tok.Line, tok.Column)
+	}
+}
+
+// TestTokenizerLexer checks that the Tokenizer splits the corpus at the same places as the PlSqlLexer.
+func TestTokenizerLexer(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatal(files, err)
+	}
+	for _, fn := range files {
+		b, err := os.ReadFile(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		text := string(b)
+		tokens, err := plsqlparser.Tokenize(text)
+		if err != nil {
+			t.Fatalf("%s: %+v", fn, err)
+		}
+		var lexed []antlr.Token
+		for _, tok := range plsqlparser.NewPlSqlStringLexer(text).GetAllTokens() {
+			if tok.GetTokenType() != antlr.TokenEOF {
+				lexed = append(lexed, tok)
+			}
+		}
+		var j int
+		for _, tok := range tokens {
+			if j >= len(lexed) || lexed[j].GetStart() != tok.Start {
+				t.Fatalf("%s:%d:%d: %q does not start at a PlSqlLexer token", fn, tok.Line, tok.Column, tok.Text)
+			}
+			k := j
+			for k < len(lexed) && lexed[k].GetStop() < tok.Stop {
+				k++
+			}
+			if k >= len(lexed) || lexed[k].GetStop() != tok.Stop {
+				t.Fatalf("%s:%d:%d: %q does not stop at a PlSqlLexer token", fn, tok.Line, tok.Column, tok.Text)
+			}
+			if k != j && tok.Type != plsqlparser.OpTok && tok.Type != plsqlparser.LabelTok {
+				t.Errorf("%s:%d:%d: %q is %d PlSqlLexer tokens", fn, tok.Line, tok.Column, tok.Text, k-j+1)
+			}
+			if hidden := lexed[j].GetChannel() != antlr.TokenDefaultChannel; hidden != tok.Hidden() {
+				t.Errorf("%s:%d:%d: %q hidden=%t, PlSqlLexer's is %t", fn, tok.Line, tok.Column, tok.Text, tok.Hidden(), hidden)
+			}
+			if tok.Line != lexed[j].GetLine() || tok.Column != lexed[j].GetColumn() {
+				t.Errorf("%s: %q at %d:%d, PlSqlLexer's at %d:%d", fn, tok.Text, tok.Line, tok.Column, lexed[j].GetLine(), lexed[j].GetColumn())
+			}
+			j = k + 1
+		}
+		if j != len(lexed) {
+			t.Errorf("%s: %d PlSqlLexer tokens remained", fn, len(lexed)-j)
+		}
+	}
+}
+
+func BenchmarkTokenizer(b *testing.B) {
+	text, err := os.ReadFile(filepath.Join("testdata", "tokens.sql"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tz := plsqlparser.NewTokenizer(string(text))
+		for tok := tz.Next(); tok.Err == nil; tok = tz.Next() {
+		}
+	}
+}
+
+func TestTokenizerIsCaseInsensitive(t *testing.T) {
+	upper, _ := plsqlparser.Tokenize("SELECT Q'[x]' FROM DUAL")
+	lower, _ := plsqlparser.Tokenize("select q'[x]' from dual")
+	if len(upper) != len(lower) {
+		t.Fatalf("got %d and %d tokens", len(upper), len(lower))
+	}
+	for i := range upper {
+		if upper[i].Type != lower[i].Type || !strings.EqualFold(upper[i].Text, lower[i].Text) {
+			t.Errorf("%d. %+v != %+v", i, upper[i], lower[i])
+		}
+	}
+}
+
+func TestPlSqlStringLexerFoldsCase(t *testing.T) {
+	const text = `select "MixedCase" from dual -- don't
+where x = 'It''s'`
+	var got []string
+	for _, tok := range plsqlparser.NewPlSqlStringLexer(text).GetAllTokens() {
+		if tok.GetChannel() == antlr.TokenDefaultChannel && tok.GetTokenType() != antlr.TokenEOF {
+			got = append(got, tok.GetText())
+		}
+	}
+	if want := []string{"select", `"MixedCase"`, "from", "dual", "where", "x", "=", "'It''s'"}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	lexer := plsqlparser.NewPlSqlStringLexer("where")
+	if tok := lexer.NextToken(); tok.GetTokenType() != plsql.PlSqlLexerWHERE {
+		t.Errorf("lowercase where lexed as %d", tok.GetTokenType())
+	}
+}
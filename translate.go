@@ -0,0 +1,1218 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// TranslateToPostgres translates the PL/SQL sources to PostgreSQL, the files analysed together.
+//
+// The translation works on the parse tree, and keeps the whitespace and comments of the untouched parts:
+//
+//   - a package becomes a schema, its procedures and functions schema-qualified PL/pgSQL functions
+//     (procedures return void, or their OUT parameters), with the calls qualified and PERFORMed,
+//   - the Oracle types are mapped (NUMBER to numeric, VARCHAR2 to varchar, DATE to timestamp(0), ...),
+//     %TYPE and %ROWTYPE are kept, and the %ROWTYPE of a cursor becomes record,
+//   - cursors become bound cursor variables; the cursor FOR loops over a query get their record declared,
+//     the REVERSE loops their bounds swapped, and %FOUND and %NOTFOUND become FOUND and NOT FOUND,
+//   - the predefined exceptions get their PostgreSQL condition names; the user-defined ones, and the codes
+//     of RAISE_APPLICATION_ERROR, get SQLSTATEs: U0nnn for ORA-20nnn, U9nnn for the unbound exceptions,
+//   - NVL becomes COALESCE, DECODE a CASE, SYSDATE LOCALTIMESTAMP(0), ROWNUM limits LIMIT,
+//     seq.NEXTVAL nextval('seq'), MINUS EXCEPT, and FROM DUAL is dropped,
+//   - the (+) outer joins become LEFT JOINs,
+//   - CONNECT BY on a single table becomes a WITH RECURSIVE query of the table plus a level column.
+//
+// The constructs that cannot be translated are left as they are (or commented out, if they have no
+// place in PostgreSQL, as package variables) and reported as Diagnostics, as are the translations
+// with a different meaning.
+//
+// The returned error is only for syntax errors.
+func TranslateToPostgres(files []File, opts ...Options) ([]File, []Diagnostic, error) {
+	rws := make([]*Rewriter, len(files))
+	for i, f := range files {
+		rw, err := NewRewriter(f.Text, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		rws[i] = rw
+	}
+	t := pgTranslator{exceptions: make(map[string]string)}
+	for _, rw := range rws {
+		t.collectExceptions(rw.Tree)
+	}
+	t.assignSQLStates()
+
+	out := make([]File, len(files))
+	var ds []Diagnostic
+	for i, rw := range rws {
+		t.tokens, t.diags, t.seen = rw.stream.GetAllTokens(), nil, make(map[string]bool)
+		out[i] = File{Name: files[i].Name, Text: t.text(rw.Tree)}
+		for _, d := range t.diags {
+			d.File = files[i].Name
+			ds = append(ds, d)
+		}
+	}
+	sort.SliceStable(ds, func(i, j int) bool {
+		if ds[i].File != ds[j].File {
+			return ds[i].File < ds[j].File
+		}
+		return ds[i].Start < ds[j].Start
+	})
+	return out, ds, nil
+}
+
+// pgTranslator renders the parse tree as PostgreSQL text.
+type pgTranslator struct {
+	tokens []antlr.Token
+	diags  []Diagnostic
+	seen   map[string]bool
+
+	// exceptions are the SQLSTATEs of the user-defined exceptions, by normalized name.
+	exceptions map[string]string
+	excOrder   []string
+	excInit    map[string]int
+
+	// schema is the name of the package being translated, subprograms its subprograms.
+	schema      string
+	subprograms map[string]bool
+
+	// the state of the subprogram (or anonymous block) being translated
+	inSubprogram bool
+	locals       map[string]bool
+	cursors      map[string]bool
+	records      []string
+
+	hier *pgHier
+}
+
+// pgHier is the state of translating the conditions of a CONNECT BY query.
+type pgHier struct {
+	alias string
+	// level is the translation of LEVEL, prior is set within PRIOR.
+	level string
+	prior bool
+	// qualify the columns with the alias
+	qualify bool
+}
+
+var pgExceptions = map[string]string{
+	"OTHERS":              "OTHERS",
+	"NO_DATA_FOUND":       "no_data_found",
+	"TOO_MANY_ROWS":       "too_many_rows",
+	"DUP_VAL_ON_INDEX":    "unique_violation",
+	"ZERO_DIVIDE":         "division_by_zero",
+	"INVALID_NUMBER":      "invalid_text_representation",
+	"VALUE_ERROR":         "data_exception",
+	"INVALID_CURSOR":      "invalid_cursor_state",
+	"CURSOR_ALREADY_OPEN": "duplicate_cursor",
+	"TIMEOUT_ON_RESOURCE": "lock_not_available",
+	"CASE_NOT_FOUND":      "case_not_found",
+	"ROWTYPE_MISMATCH":    "datatype_mismatch",
+	"LOGIN_DENIED":        "invalid_authorization_specification",
+	"PROGRAM_ERROR":       "internal_error",
+	"STORAGE_ERROR":       "out_of_memory",
+}
+
+// oraSQLStates are the SQLSTATEs of the Oracle error codes bound with EXCEPTION_INIT.
+var oraSQLStates = map[int]string{
+	-1: "23505", -1400: "23502", -1407: "23502", -2290: "23514", -2291: "23503", -2292: "23503",
+	-54: "55P03", -60: "40P01", -100: "P0002", -1403: "P0002", -1422: "P0003",
+	-1476: "22012", -1722: "22P02", -6502: "22000", -1031: "42501",
+}
+
+// oraSQLState returns the SQLSTATE for the Oracle error code: U0nnn for the user-defined -20nnn.
+func oraSQLState(code int) (string, bool) {
+	if -20999 <= code && code <= -20000 {
+		return fmt.Sprintf("U%04d", -code-20000), true
+	}
+	s, ok := oraSQLStates[code]
+	return s, ok
+}
+
+// pgTypes are the PostgreSQL types of the Oracle types, if they differ.
+var pgTypes = map[string]string{
+	"BINARY_INTEGER": "integer", "PLS_INTEGER": "integer", "SIMPLE_INTEGER": "integer",
+	"NATURAL": "integer", "NATURALN": "integer", "POSITIVE": "integer", "POSITIVEN": "integer", "SIGNTYPE": "integer",
+	"NUMBER": "numeric", "VARCHAR2": "varchar", "NVARCHAR2": "varchar", "STRING": "varchar", "NCHAR": "char",
+	"BINARY_FLOAT": "real", "BINARY_DOUBLE": "double precision",
+	"LONG": "text", "CLOB": "text", "NCLOB": "text",
+	"RAW": "bytea", "LONGRAW": "bytea", "BLOB": "bytea", "BFILE": "bytea",
+	"DATE": "timestamp(0)",
+}
+
+// collectExceptions collects the user-defined exceptions and the error codes bound to them.
+func (t *pgTranslator) collectExceptions(tree antlr.Tree) {
+	switch x := tree.(type) {
+	case *plsql.Exception_declarationContext:
+		name := catalog.Normalize(x.Identifier().GetText())
+		if _, ok := t.exceptions[name]; !ok {
+			t.exceptions[name] = ""
+			t.excOrder = append(t.excOrder, name)
+		}
+	case *plsql.Pragma_declarationContext:
+		if x.EXCEPTION_INIT() != nil && x.Exception_name() != nil && x.Numeric_negative() != nil {
+			if code, err := strconv.Atoi(x.Numeric_negative().GetText()); err == nil {
+				if t.excInit == nil {
+					t.excInit = make(map[string]int)
+				}
+				t.excInit[lastName(x.Exception_name().GetText())] = code
+			}
+		}
+	}
+	for _, ch := range tree.GetChildren() {
+		t.collectExceptions(ch)
+	}
+}
+
+func (t *pgTranslator) assignSQLStates() {
+	var n int
+	for _, name := range t.excOrder {
+		if code, ok := t.excInit[name]; ok {
+			if s, ok := oraSQLState(code); ok {
+				t.exceptions[name] = s
+				continue
+			}
+		}
+		n++
+		t.exceptions[name] = fmt.Sprintf("U9%03d", n)
+	}
+}
+
+// lastName returns the normalized last part of the dotted name.
+func lastName(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	return catalog.Normalize(name)
+}
+
+func (t *pgTranslator) diag(ctx antlr.ParserRuleContext, format string, args ...interface{}) {
+	d := newDiagnostic(ctx, format, args...)
+	// parts may be rendered more than once (the table of a CONNECT BY)
+	if k := fmt.Sprintf("%d:%s", d.Start, d.Message); !t.seen[k] {
+		t.seen[k] = true
+		t.diags = append(t.diags, d)
+	}
+}
+
+// lead returns the whitespace and comments before the token.
+func (t *pgTranslator) lead(tok antlr.Token) string {
+	i := tok.GetTokenIndex()
+	j := i
+	for j > 0 && t.tokens[j-1].GetChannel() != antlr.TokenDefaultChannel {
+		j--
+	}
+	var buf strings.Builder
+	for ; j < i; j++ {
+		buf.WriteString(t.tokens[j].GetText())
+	}
+	return buf.String()
+}
+
+// original returns the source text of the node, with the whitespace and comments within.
+func (t *pgTranslator) original(ctx antlr.ParserRuleContext) string {
+	var buf strings.Builder
+	for i := ctx.GetStart().GetTokenIndex(); i <= ctx.GetStop().GetTokenIndex(); i++ {
+		if t.tokens[i].GetTokenType() != antlr.TokenEOF {
+			buf.WriteString(t.tokens[i].GetText())
+		}
+	}
+	return buf.String()
+}
+
+// plain returns the tokens of the node, without whitespace and comments.
+func (t *pgTranslator) plain(ctx antlr.ParserRuleContext) string {
+	var buf strings.Builder
+	for i := ctx.GetStart().GetTokenIndex(); i <= ctx.GetStop().GetTokenIndex(); i++ {
+		if tok := t.tokens[i]; tok.GetChannel() == antlr.TokenDefaultChannel && tok.GetTokenType() != antlr.TokenEOF {
+			buf.WriteString(tok.GetText())
+		}
+	}
+	return buf.String()
+}
+
+// commentOut returns the source of the node as line comments.
+func (t *pgTranslator) commentOut(ctx antlr.ParserRuleContext) string {
+	return t.lead(ctx.GetStart()) + "-- " + strings.ReplaceAll(t.original(ctx), "\n", "\n-- ")
+}
+
+// trim returns the translation of the node, without the leading whitespace.
+func (t *pgTranslator) trim(tree antlr.Tree) string {
+	return strings.TrimLeft(t.text(tree), " \t\r\n")
+}
+
+// text returns the translation of the node.
+func (t *pgTranslator) text(tree antlr.Tree) string {
+	switch x := tree.(type) {
+	case antlr.TerminalNode:
+		tok := x.GetSymbol()
+		if tok.GetTokenType() == antlr.TokenEOF {
+			return t.lead(tok)
+		}
+		return t.lead(tok) + tok.GetText()
+	case antlr.ParserRuleContext:
+		if x.GetStart() == nil || x.GetStop() == nil || x.GetStop().GetTokenIndex() < x.GetStart().GetTokenIndex() {
+			return ""
+		}
+		if s, ok := t.translate(x); ok {
+			return s
+		}
+		return t.children(x, nil)
+	}
+	return ""
+}
+
+// children returns the translation of the children of the node, with the terminals of the
+// token types in repl replaced by their texts, or dropped if that is empty.
+func (t *pgTranslator) children(ctx antlr.ParserRuleContext, repl map[int]string) string {
+	var buf strings.Builder
+	for _, ch := range ctx.GetChildren() {
+		if tn, ok := ch.(antlr.TerminalNode); ok {
+			if s, ok := repl[tn.GetSymbol().GetTokenType()]; ok {
+				if s != "" {
+					buf.WriteString(t.lead(tn.GetSymbol()) + s)
+				}
+				continue
+			}
+		}
+		buf.WriteString(t.text(ch))
+	}
+	return buf.String()
+}
+
+// translate returns the translation of the node, if it differs from the translation of its children.
+func (t *pgTranslator) translate(ctx antlr.ParserRuleContext) (string, bool) {
+	switch x := ctx.(type) {
+	case *plsql.Sql_plus_commandContext:
+		if x.SOLIDUS() != nil {
+			return "", true
+		}
+		return t.commentOut(x), true
+	case *plsql.Create_packageContext:
+		return t.packageSpec(x), true
+	case *plsql.Create_package_bodyContext:
+		return t.packageBody(x), true
+	case *plsql.Create_procedure_bodyContext:
+		return t.subprogram(x, t.trim(x.Procedure_name()), x.AllParameter(), nil, x.Seq_of_declare_specs(), x.Body()), true
+	case *plsql.Create_function_bodyContext:
+		return t.subprogram(x, t.trim(x.Function_name()), x.AllParameter(), x.Type_spec(), x.Seq_of_declare_specs(), x.Body()), true
+	case *plsql.Procedure_bodyContext:
+		return t.subprogram(x, t.qualify(x.Identifier()), x.AllParameter(), nil, x.Seq_of_declare_specs(), x.Body()), true
+	case *plsql.Function_bodyContext:
+		return t.subprogram(x, t.qualify(x.Identifier()), x.AllParameter(), x.Type_spec(), x.Seq_of_declare_specs(), x.Body()), true
+	case *plsql.Anonymous_blockContext:
+		return t.anonymousBlock(x), true
+	case *plsql.Declare_specContext:
+		return t.declareSpec(x), true
+	case *plsql.Variable_declarationContext:
+		t.local(x.Identifier())
+	case *plsql.Cursor_declarationContext:
+		return t.cursorDeclaration(x), true
+	case *plsql.Type_specContext:
+		return t.typeSpec(x)
+	case *plsql.Native_datatype_elementContext:
+		if s, ok := pgTypes[strings.ToUpper(t.plain(x))]; ok {
+			return t.lead(x.GetStart()) + s, true
+		}
+		if s := strings.ToUpper(t.plain(x)); s == "ROWID" || s == "UROWID" || s == "MLSLABEL" {
+			t.diag(x, "type %s has no PostgreSQL equivalent", s)
+		}
+	case *plsql.Precision_partContext:
+		return t.children(x, map[int]string{plsql.PlSqlParserCHAR: "", plsql.PlSqlParserBYTE: ""}), true
+	case *plsql.Exception_nameContext:
+		return t.exceptionName(x), true
+	case *plsql.Procedure_callContext:
+		return t.call(x, x.Routine_name(), x.Function_argument()), true
+	case *plsql.Function_callContext:
+		return t.call(x, x.Routine_name(), x.Function_argument()), true
+	case *plsql.Transaction_control_statementsContext:
+		if t.inSubprogram {
+			t.diag(x, "%s is not allowed in a PostgreSQL function", strings.ToUpper(x.GetStart().GetText()))
+		}
+	case *plsql.Execute_immediateContext:
+		return t.children(x, map[int]string{plsql.PlSqlParserIMMEDIATE: ""}), true
+	case *plsql.Cursor_loop_paramContext:
+		return t.cursorLoopParam(x)
+	case *plsql.Other_functionContext:
+		return t.cursorAttribute(x)
+	case *plsql.String_functionContext:
+		switch {
+		case x.NVL() != nil:
+			return t.children(x, map[int]string{plsql.PlSqlParserNVL: "COALESCE"}), true
+		case x.DECODE() != nil:
+			return t.decode(x), true
+		}
+	case *plsql.General_elementContext:
+		return t.generalElement(x)
+	case *plsql.Outer_join_signContext:
+		// handled by the query block
+		return "", true
+	case *plsql.Query_blockContext:
+		return t.queryBlock(x), true
+	case *plsql.Subquery_operation_partContext:
+		return t.children(x, map[int]string{plsql.PlSqlParserMINUS: "EXCEPT"}), true
+	case *plsql.Unary_expressionContext:
+		return t.unaryExpression(x)
+	case *plsql.Order_by_clauseContext:
+		if x.SIBLINGS() != nil {
+			t.diag(x, "ORDER SIBLINGS BY is not translated: the rows are ordered, but not within the hierarchy")
+			return t.children(x, map[int]string{plsql.PlSqlParserSIBLINGS: ""}), true
+		}
+	}
+	return "", false
+}
+
+// qualify returns the name of the package subprogram, qualified with the schema of the package.
+func (t *pgTranslator) qualify(id antlr.Tree) string {
+	name := t.trim(id)
+	if t.schema == "" {
+		return name
+	}
+	return t.schema + "." + name
+}
+
+func (t *pgTranslator) local(id antlr.ParserRuleContext) {
+	if t.locals != nil && id != nil {
+		t.locals[catalog.Normalize(id.GetText())] = true
+	}
+}
+
+// packageSpec translates the package specification to the creation of its schema.
+func (t *pgTranslator) packageSpec(ctx *plsql.Create_packageContext) string {
+	name := t.trim(ctx.Package_name(0))
+	var buf strings.Builder
+	buf.WriteString(t.lead(ctx.GetStart()) + "CREATE SCHEMA IF NOT EXISTS " + name + ";")
+	for _, obj := range ctx.AllPackage_obj_spec() {
+		o := obj.(*plsql.Package_obj_specContext)
+		switch {
+		case o.Procedure_spec() != nil, o.Function_spec() != nil, o.Exception_declaration() != nil:
+		case o.Pragma_declaration() != nil && o.Pragma_declaration().(*plsql.Pragma_declarationContext).EXCEPTION_INIT() != nil:
+			t.exceptionInit(o.Pragma_declaration().(*plsql.Pragma_declarationContext))
+		default:
+			t.diag(o, "package %s: %s is not translated, PostgreSQL has no package state", name, t.declKind(o))
+			buf.WriteString(t.commentOut(o))
+		}
+	}
+	return buf.String()
+}
+
+// packageBody translates the package body to the functions of its schema.
+func (t *pgTranslator) packageBody(ctx *plsql.Create_package_bodyContext) string {
+	name := t.trim(ctx.Package_name(0))
+	t.schema, t.subprograms = name, make(map[string]bool)
+	defer func() { t.schema, t.subprograms = "", nil }()
+	objs := ctx.AllPackage_obj_body()
+	for _, obj := range objs {
+		o := obj.(*plsql.Package_obj_bodyContext)
+		if p, ok := o.Procedure_body().(*plsql.Procedure_bodyContext); ok {
+			t.subprograms[catalog.Normalize(p.Identifier().GetText())] = true
+		} else if f, ok := o.Function_body().(*plsql.Function_bodyContext); ok {
+			t.subprograms[catalog.Normalize(f.Identifier().GetText())] = true
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString(t.lead(ctx.GetStart()) + "CREATE SCHEMA IF NOT EXISTS " + name + ";")
+	for _, obj := range objs {
+		o := obj.(*plsql.Package_obj_bodyContext)
+		switch {
+		case o.Procedure_body() != nil, o.Function_body() != nil:
+			buf.WriteString(t.text(o))
+		case o.Procedure_spec() != nil, o.Function_spec() != nil, o.Exception_declaration() != nil:
+			// forward declarations are not needed, the exceptions have SQLSTATEs
+		default:
+			t.diag(o, "package %s: %s is not translated, PostgreSQL has no package state", name, t.declKind(o))
+			buf.WriteString(t.commentOut(o))
+		}
+	}
+	if seq := ctx.Seq_of_statements(); seq != nil {
+		t.diag(seq.(antlr.ParserRuleContext), "package %s: the initialization section is not translated", name)
+		buf.WriteString(t.commentOut(seq.(antlr.ParserRuleContext)))
+	}
+	return buf.String()
+}
+
+// declKind returns the kind of the declaration, for the Diagnostics.
+func (t *pgTranslator) declKind(ctx antlr.ParserRuleContext) string {
+	if ctx.GetChildCount() == 0 {
+		return "declaration"
+	}
+	switch x := ctx.GetChild(0).(type) {
+	case *plsql.Variable_declarationContext:
+		return "variable " + t.plain(x.Identifier())
+	case *plsql.Cursor_declarationContext:
+		return "cursor " + t.plain(x.Identifier())
+	case *plsql.Type_declarationContext, *plsql.Subtype_declarationContext:
+		return "type"
+	case *plsql.Pragma_declarationContext:
+		return "pragma"
+	case *plsql.Procedure_bodyContext, *plsql.Function_bodyContext:
+		return "nested subprogram"
+	}
+	return "declaration"
+}
+
+// subprogram translates a procedure or function to a PL/pgSQL function.
+func (t *pgTranslator) subprogram(ctx antlr.ParserRuleContext, name string, params []plsql.IParameterContext, ret plsql.IType_specContext, decls plsql.ISeq_of_declare_specsContext, body plsql.IBodyContext) string {
+	if body == nil {
+		t.diag(ctx, "%s: external subprograms are not translated", name)
+		return t.commentOut(ctx)
+	}
+	t.inSubprogram, t.locals, t.cursors, t.records = true, make(map[string]bool), make(map[string]bool), nil
+	defer func() { t.inSubprogram, t.locals, t.cursors, t.records = false, nil, nil, nil }()
+
+	var buf strings.Builder
+	buf.WriteString(t.lead(ctx.GetStart()) + "CREATE OR REPLACE FUNCTION " + name + "(")
+	var outs bool
+	for i, p := range params {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		s, out := t.parameter(p.(*plsql.ParameterContext))
+		buf.WriteString(s)
+		outs = outs || out
+	}
+	buf.WriteString(")")
+	switch {
+	case ret != nil:
+		if outs {
+			t.diag(ctx, "%s: a function with OUT parameters cannot have a RETURN type in PostgreSQL", name)
+		}
+		buf.WriteString(" RETURNS " + t.trim(ret))
+	case outs:
+		t.diag(ctx, "%s: the OUT parameters are returned as the result of the function", name)
+	default:
+		buf.WriteString(" RETURNS void")
+	}
+	buf.WriteString(" AS $$")
+	buf.WriteString(t.block(decls, body.GetChildren()))
+	buf.WriteString("\n$$ LANGUAGE plpgsql;")
+	return buf.String()
+}
+
+// block returns the DECLARE section (with the records of the cursor FOR loops) and the body,
+// from BEGIN to END.
+func (t *pgTranslator) block(decls plsql.ISeq_of_declare_specsContext, body []antlr.Tree) string {
+	var declText string
+	if decls != nil {
+		declText = t.text(decls)
+	}
+	var bodyText strings.Builder
+	for _, ch := range body {
+		if _, ok := ch.(*plsql.Label_nameContext); !ok { // END name
+			bodyText.WriteString(t.text(ch))
+		}
+	}
+	var buf strings.Builder
+	if decls != nil || len(t.records) != 0 {
+		buf.WriteString("\nDECLARE" + declText)
+		for _, r := range t.records {
+			buf.WriteString("\n  " + r + " record;")
+		}
+	}
+	buf.WriteString(bodyText.String())
+	if s := buf.String(); !strings.HasSuffix(s, ";") {
+		buf.WriteString(";")
+	}
+	return buf.String()
+}
+
+// parameter translates the parameter, reporting whether it is an OUT parameter.
+func (t *pgTranslator) parameter(p *plsql.ParameterContext) (string, bool) {
+	t.local(p.Parameter_name())
+	var mode string
+	switch {
+	case len(p.AllINOUT()) != 0, len(p.AllIN()) != 0 && len(p.AllOUT()) != 0:
+		mode = "INOUT "
+	case len(p.AllOUT()) != 0:
+		mode = "OUT "
+	}
+	s := mode + t.trim(p.Parameter_name())
+	if ts := p.Type_spec(); ts != nil {
+		s += " " + t.trim(ts)
+	}
+	if d, ok := p.Default_value_part().(*plsql.Default_value_partContext); ok {
+		s += " DEFAULT " + t.trim(d.Expression())
+	}
+	return s, mode != ""
+}
+
+// anonymousBlock translates the block to a DO statement.
+func (t *pgTranslator) anonymousBlock(ctx *plsql.Anonymous_blockContext) string {
+	t.locals, t.cursors, t.records = make(map[string]bool), make(map[string]bool), nil
+	defer func() { t.locals, t.cursors, t.records = nil, nil, nil }()
+	var decls plsql.ISeq_of_declare_specsContext
+	if ctx.DECLARE() != nil {
+		decls = ctx.Seq_of_declare_specs()
+	}
+	// the body is from BEGIN to END, without the closing ;
+	var body []antlr.Tree
+	for _, ch := range ctx.GetChildren() {
+		if isToken(ch, plsql.PlSqlParserDECLARE) || ch == ctx.SEMICOLON() {
+			continue
+		}
+		if _, ok := ch.(*plsql.Seq_of_declare_specsContext); ok {
+			continue
+		}
+		body = append(body, ch)
+	}
+	return t.lead(ctx.GetStart()) + "DO $$" + t.block(decls, body) + "\n$$;"
+}
+
+// declareSpec translates a declaration of a subprogram or block.
+func (t *pgTranslator) declareSpec(ctx *plsql.Declare_specContext) string {
+	switch {
+	case ctx.Exception_declaration() != nil, ctx.Procedure_spec() != nil, ctx.Function_spec() != nil:
+		// the exceptions have SQLSTATEs
+		return ""
+	case ctx.Pragma_declaration() != nil:
+		p := ctx.Pragma_declaration().(*plsql.Pragma_declarationContext)
+		switch {
+		case p.EXCEPTION_INIT() != nil:
+			t.exceptionInit(p)
+			return ""
+		case p.AUTONOMOUS_TRANSACTION() != nil:
+			t.diag(p, "autonomous transactions are not translated")
+		}
+		return t.commentOut(ctx)
+	case ctx.Type_declaration() != nil, ctx.Subtype_declaration() != nil:
+		t.diag(ctx, "type declarations are not translated")
+		return t.commentOut(ctx)
+	case ctx.Procedure_body() != nil, ctx.Function_body() != nil:
+		t.diag(ctx, "nested subprograms are not translated")
+		return t.commentOut(ctx)
+	}
+	return t.children(ctx, nil)
+}
+
+// exceptionInit reports the error codes bound to exceptions that have no SQLSTATE.
+func (t *pgTranslator) exceptionInit(p *plsql.Pragma_declarationContext) {
+	code, err := strconv.Atoi(t.plain(p.Numeric_negative()))
+	if _, ok := oraSQLState(code); err != nil || !ok {
+		t.diag(p, "EXCEPTION_INIT: the error code %s has no SQLSTATE, %s is not raised by the database", t.plain(p.Numeric_negative()), t.plain(p.Exception_name()))
+	}
+}
+
+// cursorDeclaration translates the cursor to a bound cursor variable.
+func (t *pgTranslator) cursorDeclaration(ctx *plsql.Cursor_declarationContext) string {
+	name := t.trim(ctx.Identifier())
+	if t.cursors != nil {
+		t.cursors[catalog.Normalize(name)] = true
+	}
+	t.local(ctx.Identifier())
+	if ctx.Select_statement() == nil {
+		t.diag(ctx, "cursor %s: cursor declarations without a query are not translated", name)
+		return t.commentOut(ctx)
+	}
+	s := t.lead(ctx.GetStart()) + name + " CURSOR"
+	if params := ctx.AllParameter_spec(); len(params) != 0 {
+		ps := make([]string, len(params))
+		for i, p := range params {
+			p := p.(*plsql.Parameter_specContext)
+			ps[i] = t.trim(p.Parameter_name())
+			if ts := p.Type_spec(); ts != nil {
+				ps[i] += " " + t.trim(ts)
+			}
+			if d, ok := p.Default_value_part().(*plsql.Default_value_partContext); ok {
+				ps[i] += " DEFAULT " + t.trim(d.Expression())
+			}
+		}
+		s += " (" + strings.Join(ps, ", ") + ")"
+	}
+	return s + " FOR " + t.trim(ctx.Select_statement()) + ";"
+}
+
+func (t *pgTranslator) typeSpec(ctx *plsql.Type_specContext) (string, bool) {
+	tn := ctx.Type_name()
+	if tn == nil {
+		return "", false
+	}
+	name := catalog.Normalize(tn.GetText())
+	switch {
+	case ctx.PERCENT_ROWTYPE() != nil:
+		if t.cursors[name] {
+			return t.lead(ctx.GetStart()) + "record", true
+		}
+	case ctx.PERCENT_TYPE() != nil:
+	case name == "SYS_REFCURSOR":
+		return t.lead(ctx.GetStart()) + "refcursor", true
+	default:
+		t.diag(ctx, "type %s is not translated", t.plain(ctx))
+	}
+	return "", false
+}
+
+// exceptionName returns the PostgreSQL condition name of the exception, or its SQLSTATE.
+func (t *pgTranslator) exceptionName(ctx *plsql.Exception_nameContext) string {
+	lead, name := t.lead(ctx.GetStart()), lastName(t.plain(ctx))
+	if s, ok := pgExceptions[name]; ok {
+		return lead + s
+	}
+	if s := t.exceptions[name]; s != "" {
+		return lead + "SQLSTATE '" + s + "'"
+	}
+	t.diag(ctx, "exception %s is not translated", t.plain(ctx))
+	return lead + t.plain(ctx)
+}
+
+// call translates the procedure call statement.
+func (t *pgTranslator) call(ctx antlr.ParserRuleContext, rn plsql.IRoutine_nameContext, fa plsql.IFunction_argumentContext) string {
+	lead := t.lead(ctx.GetStart())
+	var args []plsql.IArgumentContext
+	argText := "()"
+	if fa != nil {
+		args = fa.(*plsql.Function_argumentContext).AllArgument()
+		argText = t.trim(fa)
+	}
+	switch strings.ToUpper(t.plain(rn)) {
+	case "RAISE_APPLICATION_ERROR":
+		if len(args) < 2 {
+			break
+		}
+		state := "P0001"
+		if code, err := strconv.Atoi(t.plain(args[0])); err == nil {
+			if s, ok := oraSQLState(code); ok {
+				state = s
+			}
+		}
+		if state == "P0001" {
+			t.diag(ctx, "RAISE_APPLICATION_ERROR: the error code %s is not translated", t.plain(args[0]))
+		}
+		return lead + "RAISE EXCEPTION USING ERRCODE = '" + state + "', MESSAGE = " + t.trim(args[1])
+	case "DBMS_OUTPUT.PUT_LINE":
+		if len(args) == 1 {
+			return lead + "RAISE NOTICE '%', " + t.trim(args[0])
+		}
+	}
+	return lead + "PERFORM " + t.routineName(rn.(*plsql.Routine_nameContext)) + argText
+}
+
+func (t *pgTranslator) routineName(rn *plsql.Routine_nameContext) string {
+	if len(rn.AllId_expression()) == 0 && t.subprograms[catalog.Normalize(rn.Identifier().GetText())] {
+		return t.qualify(rn.Identifier())
+	}
+	return t.trim(rn)
+}
+
+func (t *pgTranslator) cursorLoopParam(ctx *plsql.Cursor_loop_paramContext) (string, bool) {
+	lead := t.lead(ctx.GetStart())
+	switch {
+	case ctx.REVERSE() != nil:
+		// Oracle iterates from the upper bound down to the lower, PostgreSQL from the first one to the second
+		t.local(ctx.Index_name())
+		return lead + t.trim(ctx.Index_name()) + " IN REVERSE " + t.trim(ctx.Upper_bound()) + " .. " + t.trim(ctx.Lower_bound()), true
+	case ctx.Index_name() != nil:
+		t.local(ctx.Index_name())
+	case ctx.Select_statement() != nil:
+		name := t.trim(ctx.Record_name())
+		t.local(ctx.Record_name())
+		t.records = append(t.records, name)
+		return lead + name + " IN " + t.trim(ctx.Select_statement()), true
+	default:
+		t.local(ctx.Record_name())
+	}
+	return "", false
+}
+
+func (t *pgTranslator) cursorAttribute(ctx *plsql.Other_functionContext) (string, bool) {
+	if ctx.Cursor_name() == nil {
+		return "", false
+	}
+	lead := t.lead(ctx.GetStart())
+	switch {
+	case ctx.PERCENT_FOUND() != nil:
+		return lead + "FOUND", true
+	case ctx.PERCENT_NOTFOUND() != nil:
+		return lead + "NOT FOUND", true
+	case ctx.PERCENT_ROWCOUNT() != nil:
+		t.diag(ctx, "%s is not translated, use GET DIAGNOSTICS", t.plain(ctx))
+	default:
+		t.diag(ctx, "%s is not translated", t.plain(ctx))
+	}
+	return "", false
+}
+
+// decode translates DECODE to a CASE expression, a searched one if a search value is NULL.
+func (t *pgTranslator) decode(ctx *plsql.String_functionContext) string {
+	exprs := ctx.Expressions().(*plsql.ExpressionsContext).AllExpression()
+	if len(exprs) < 3 {
+		t.diag(ctx, "DECODE needs at least three arguments")
+		return t.children(ctx, nil)
+	}
+	var searched bool
+	for i := 1; i+1 < len(exprs); i += 2 {
+		if strings.EqualFold(t.plain(exprs[i]), "NULL") {
+			searched = true
+		}
+	}
+	x := t.trim(exprs[0])
+	var buf strings.Builder
+	buf.WriteString(t.lead(ctx.GetStart()) + "CASE")
+	if !searched {
+		buf.WriteString(" " + x)
+	}
+	i := 1
+	for ; i+1 < len(exprs); i += 2 {
+		switch {
+		case !searched:
+			buf.WriteString(" WHEN " + t.trim(exprs[i]))
+		case strings.EqualFold(t.plain(exprs[i]), "NULL"):
+			buf.WriteString(" WHEN " + x + " IS NULL")
+		default:
+			buf.WriteString(" WHEN " + x + " = " + t.trim(exprs[i]))
+		}
+		buf.WriteString(" THEN " + t.trim(exprs[i+1]))
+	}
+	if i < len(exprs) {
+		buf.WriteString(" ELSE " + t.trim(exprs[i]))
+	}
+	buf.WriteString(" END")
+	return buf.String()
+}
+
+// pgHierarchical are the functions of the hierarchical queries not translated.
+var pgHierarchical = map[string]bool{"SYS_CONNECT_BY_PATH": true, "CONNECT_BY_ISLEAF": true, "CONNECT_BY_ISCYCLE": true}
+
+func (t *pgTranslator) generalElement(ctx *plsql.General_elementContext) (string, bool) {
+	parts := ctx.AllGeneral_element_part()
+	last := parts[len(parts)-1].(*plsql.General_element_partContext)
+	ids := elementIDs(ctx, nil)
+	if len(ids) == 0 {
+		return "", false
+	}
+	for _, p := range parts[:len(parts)-1] {
+		if p.(*plsql.General_element_partContext).Function_argument() != nil {
+			return "", false
+		}
+	}
+	lead := t.lead(ctx.GetStart())
+	first := catalog.Normalize(ids[0].GetText())
+	lastID := catalog.Normalize(ids[len(ids)-1].GetText())
+	args := last.Function_argument()
+
+	if args != nil {
+		switch {
+		case pgHierarchical[lastID]:
+			t.diag(ctx, "%s is not translated", lastID)
+		case lastID == "NVL2" && len(ids) == 1:
+			as := args.(*plsql.Function_argumentContext).AllArgument()
+			if len(as) == 3 {
+				return lead + "CASE WHEN " + t.trim(as[0]) + " IS NOT NULL THEN " + t.trim(as[1]) + " ELSE " + t.trim(as[2]) + " END", true
+			}
+		case len(ids) == 1 && t.subprograms[first] && !t.locals[first]:
+			return lead + t.qualify(ids[0]) + t.trim(args), true
+		}
+		return "", false
+	}
+
+	if h := t.hier; h != nil {
+		switch {
+		case len(ids) == 1 && first == "LEVEL":
+			if h.prior {
+				return lead + "hier.level", true
+			}
+			return lead + h.level, true
+		case len(ids) == 1 && h.qualify && !t.locals[first]:
+			if h.prior {
+				return lead + "hier." + t.trim(ids[0]), true
+			}
+			return lead + h.alias + "." + t.trim(ids[0]), true
+		case len(ids) == 2 && h.prior && first == catalog.Normalize(h.alias):
+			return lead + "hier." + t.trim(ids[1]), true
+		}
+	}
+	switch {
+	case len(ids) == 1 && first == "SYSDATE":
+		return lead + "LOCALTIMESTAMP(0)", true
+	case len(ids) == 1 && first == "SYSTIMESTAMP":
+		return lead + "CURRENT_TIMESTAMP", true
+	case len(ids) == 1 && first == "ROWNUM":
+		if inSelectList(ctx) {
+			return lead + "row_number() OVER ()", true
+		}
+		t.diag(ctx, "ROWNUM is only translated in the select list, and as a limit in the WHERE clause (ROWNUM <= n)")
+	case len(ids) == 1 && first == "SQLCODE":
+		t.diag(ctx, "SQLCODE is not translated, use SQLSTATE")
+	case len(ids) == 1 && pgHierarchical[first]:
+		t.diag(ctx, "%s is not translated", first)
+	case len(ids) >= 2 && (lastID == "NEXTVAL" || lastID == "CURRVAL"):
+		seq := make([]string, len(ids)-1)
+		for i, id := range ids[:len(ids)-1] {
+			seq[i] = strings.TrimSpace(t.plain(id))
+		}
+		return lead + strings.ToLower(lastID) + "('" + strings.ReplaceAll(strings.Join(seq, "."), "'", "''") + "')", true
+	case len(ids) == 1 && t.subprograms[first] && !t.locals[first]:
+		return lead + t.qualify(ids[0]) + "()", true
+	}
+	return "", false
+}
+
+// inSelectList reports whether the node is within the select list of its query block.
+func inSelectList(node antlr.Tree) bool {
+	for node = node.GetParent(); node != nil; node = node.GetParent() {
+		switch node.(type) {
+		case *plsql.Selected_listContext:
+			return true
+		case *plsql.Query_blockContext:
+			return false
+		}
+	}
+	return false
+}
+
+func (t *pgTranslator) unaryExpression(ctx *plsql.Unary_expressionContext) (string, bool) {
+	switch {
+	case ctx.PRIOR() != nil:
+		if t.hier == nil {
+			t.diag(ctx, "PRIOR outside of a translated CONNECT BY")
+			return "", false
+		}
+		prior := t.hier.prior
+		t.hier.prior = true
+		s := t.lead(ctx.GetStart()) + t.trim(ctx.Unary_expression())
+		t.hier.prior = prior
+		return s, true
+	case ctx.CONNECT_BY_ROOT() != nil:
+		t.diag(ctx, "CONNECT_BY_ROOT is not translated")
+	}
+	return "", false
+}
+
+// pgTable is a table of the FROM clause.
+type pgTable struct {
+	ref *plsql.Table_refContext
+	// name is the normalized alias, or the name of the table without the owner.
+	name  string
+	alias string
+	// plain is set for a table (or view) without joins.
+	plain bool
+}
+
+func (t *pgTranslator) fromTables(ctx *plsql.Query_blockContext) []pgTable {
+	fc, ok := ctx.From_clause().(*plsql.From_clauseContext)
+	if !ok {
+		return nil
+	}
+	var tables []pgTable
+	for _, ref := range fc.Table_ref_list().(*plsql.Table_ref_listContext).AllTable_ref() {
+		ref := ref.(*plsql.Table_refContext)
+		aux := ref.Table_ref_aux().(*plsql.Table_ref_auxContext)
+		tbl := pgTable{ref: ref}
+		if one, ok := aux.Table_ref_aux_internal().(*plsql.Table_ref_aux_internal_oneContext); ok {
+			name := tableName(one.Dml_table_expression_clause()).Name
+			tbl.name = name[strings.LastIndexByte(name, '.')+1:]
+			tbl.plain = name != "" && len(ref.AllJoin_clause()) == 0 && ref.Pivot_clause() == nil && ref.Unpivot_clause() == nil
+			if tv, ok := one.Dml_table_expression_clause().(*plsql.Dml_table_expression_clauseContext); ok && tv.Tableview_name() != nil {
+				tvn := tv.Tableview_name().(*plsql.Tableview_nameContext)
+				tbl.alias = t.trim(tvn.Identifier())
+				if id := tvn.Id_expression(); id != nil {
+					tbl.alias = t.trim(id)
+				}
+			}
+		}
+		if a := aux.Table_alias(); a != nil {
+			tbl.name, tbl.alias = catalog.Normalize(a.GetText()), t.trim(a)
+		}
+		tables = append(tables, tbl)
+	}
+	return tables
+}
+
+// conjuncts splits the expression at the top-level ANDs.
+func conjuncts(tree antlr.Tree) []antlr.ParserRuleContext {
+	switch x := tree.(type) {
+	case *plsql.ExpressionContext:
+		if le := x.Logical_expression(); le != nil {
+			return conjuncts(le)
+		}
+	case *plsql.Logical_expressionContext:
+		if x.AND() != nil {
+			les := x.AllLogical_expression()
+			return append(conjuncts(les[0]), conjuncts(les[1])...)
+		}
+	}
+	return []antlr.ParserRuleContext{tree.(antlr.ParserRuleContext)}
+}
+
+// qualifiers returns the normalized table qualifiers of the columns referenced in the tree.
+func qualifiers(tree antlr.Tree, qs map[string]bool) {
+	switch x := tree.(type) {
+	case *plsql.General_elementContext:
+		if ids := elementIDs(x, nil); len(ids) >= 2 {
+			qs[catalog.Normalize(ids[len(ids)-2].GetText())] = true
+		}
+	case *plsql.Table_elementContext:
+		if ids := x.AllId_expression(); len(ids) >= 2 {
+			qs[catalog.Normalize(ids[len(ids)-2].GetText())] = true
+		}
+	}
+	for _, ch := range tree.GetChildren() {
+		qualifiers(ch, qs)
+	}
+}
+
+// outerJoined returns the qualifiers of the columns marked with (+) in the tree.
+func outerJoined(tree antlr.Tree, qs map[string]bool) (found bool) {
+	if a, ok := tree.(*plsql.AtomContext); ok && a.Outer_join_sign() != nil {
+		ids := a.Table_element().(*plsql.Table_elementContext).AllId_expression()
+		if len(ids) >= 2 {
+			qs[catalog.Normalize(ids[len(ids)-2].GetText())] = true
+		} else {
+			qs[""] = true
+		}
+		return true
+	}
+	for _, ch := range tree.GetChildren() {
+		if outerJoined(ch, qs) {
+			found = true
+		}
+	}
+	return found
+}
+
+var rxRownum = regexp.MustCompile(`^(?i:ROWNUM)\s*(<=|<|=)\s*(\S+)$`)
+
+// rownumLimit returns the LIMIT of the ROWNUM condition: ROWNUM <= n, ROWNUM < n or ROWNUM = 1.
+func (t *pgTranslator) rownumLimit(cond antlr.ParserRuleContext) (string, bool) {
+	m := rxRownum.FindStringSubmatch(strings.TrimSpace(t.original(cond)))
+	if m == nil {
+		return "", false
+	}
+	switch m[1] {
+	case "<=":
+		return m[2], true
+	case "<":
+		if n, err := strconv.Atoi(m[2]); err == nil {
+			return strconv.Itoa(n - 1), true
+		}
+		return "(" + m[2] + ") - 1", true
+	}
+	if m[2] == "1" {
+		return m[2], true
+	}
+	t.diag(cond, "%s is never true", t.plain(cond))
+	return "", false
+}
+
+// queryBlock translates the query block: FROM DUAL, ROWNUM limits, (+) outer joins and CONNECT BY.
+func (t *pgTranslator) queryBlock(ctx *plsql.Query_blockContext) string {
+	tables := t.fromTables(ctx)
+	if hq, ok := ctx.Hierarchical_query_clause().(*plsql.Hierarchical_query_clauseContext); ok {
+		if len(tables) == 1 && tables[0].plain {
+			return t.wrapQuery(ctx, t.connectBy(ctx, hq, tables[0]), true)
+		}
+		t.diag(hq, "CONNECT BY is only translated for a single table")
+	}
+
+	known := make(map[string]bool, len(tables))
+	for _, tbl := range tables {
+		known[tbl.name] = true
+	}
+	var conds []antlr.ParserRuleContext
+	var limit string
+	joins := make(map[string][]antlr.ParserRuleContext)
+	where, _ := ctx.Where_clause().(*plsql.Where_clauseContext)
+	if where != nil && where.Expression() != nil {
+		for _, c := range conjuncts(where.Expression()) {
+			if n, ok := t.rownumLimit(c); ok {
+				limit = n
+				continue
+			}
+			qs := make(map[string]bool)
+			if !outerJoined(c, qs) {
+				conds = append(conds, c)
+				continue
+			}
+			var q string
+			for k := range qs {
+				q = k
+			}
+			if len(qs) != 1 || !known[q] {
+				t.diag(c, "the outer join %s is not translated, it is an inner join", t.plain(c))
+				conds = append(conds, c)
+				continue
+			}
+			joins[q] = append(joins[q], c)
+		}
+	}
+	if len(joins) != 0 && len(joins) == len(tables) {
+		t.diag(where, "the outer joins are not translated: there is no inner table")
+		joins = nil
+		conds = conjuncts(where.Expression())
+	}
+	modified := limit != "" || len(joins) != 0
+
+	var buf strings.Builder
+	for _, ch := range ctx.GetChildren() {
+		switch x := ch.(type) {
+		case antlr.TerminalNode:
+			if x.GetSymbol().GetTokenType() == plsql.PlSqlParserUNIQUE {
+				buf.WriteString(t.lead(x.GetSymbol()) + "DISTINCT")
+				continue
+			}
+			buf.WriteString(t.text(x))
+		case *plsql.From_clauseContext:
+			buf.WriteString(t.fromClause(x, tables, joins))
+		case *plsql.Where_clauseContext:
+			if !modified {
+				buf.WriteString(t.text(x))
+			} else if len(conds) != 0 {
+				ss := make([]string, len(conds))
+				for i, c := range conds {
+					ss[i] = t.trim(c)
+				}
+				buf.WriteString(t.lead(x.GetStart()) + "WHERE " + strings.Join(ss, " AND "))
+			}
+		default:
+			buf.WriteString(t.text(x))
+		}
+	}
+	if limit == "" {
+		return buf.String()
+	}
+	if ctx.Order_by_clause() != nil {
+		t.diag(where, "ROWNUM limits the rows before ORDER BY, LIMIT after it")
+	}
+	buf.WriteString(" LIMIT " + limit)
+	return t.wrapQuery(ctx, buf.String(), false)
+}
+
+// wrapQuery puts the translated query block in parentheses, if it is a part of a set operation
+// (or of a query with a WITH clause, if the translation starts with WITH).
+func (t *pgTranslator) wrapQuery(ctx *plsql.Query_blockContext, s string, with bool) string {
+	var wrap bool
+	switch p := ctx.GetParent().(type) {
+	case *plsql.Subquery_operation_partContext:
+		wrap = true
+	case *plsql.Subquery_basic_elementsContext:
+		if sq, ok := p.GetParent().(*plsql.SubqueryContext); ok {
+			wrap = len(sq.AllSubquery_operation_part()) != 0
+			if sos, ok := sq.GetParent().(*plsql.Select_only_statementContext); ok && with && sos.Subquery_factoring_clause() != nil {
+				wrap = true
+			}
+		}
+	}
+	if !wrap {
+		return s
+	}
+	lead := t.lead(ctx.GetStart())
+	return lead + "(" + strings.TrimPrefix(s, lead) + ")"
+}
+
+// fromClause translates the FROM clause, dropping FROM DUAL, and turning the tables
+// of the (+) outer joins into LEFT JOINs.
+func (t *pgTranslator) fromClause(ctx *plsql.From_clauseContext, tables []pgTable, joins map[string][]antlr.ParserRuleContext) string {
+	if len(tables) == 1 && tables[0].plain && tables[0].name == "DUAL" {
+		return ""
+	}
+	if len(joins) == 0 {
+		return t.text(ctx)
+	}
+	placed := make(map[string]bool, len(tables))
+	var inner, outer []pgTable
+	for _, tbl := range tables {
+		if _, ok := joins[tbl.name]; ok {
+			outer = append(outer, tbl)
+			continue
+		}
+		inner = append(inner, tbl)
+		placed[tbl.name] = true
+	}
+	var buf strings.Builder
+	buf.WriteString(t.lead(ctx.GetStart()) + "FROM ")
+	for i, tbl := range inner {
+		if i != 0 {
+			buf.WriteString(" CROSS JOIN ")
+		}
+		buf.WriteString(t.trim(tbl.ref))
+	}
+	// a table is joined after the tables its conditions reference
+	for len(outer) != 0 {
+		i := 0
+		for ; i < len(outer); i++ {
+			qs := make(map[string]bool)
+			for _, c := range joins[outer[i].name] {
+				qualifiers(c, qs)
+			}
+			ready := true
+			for q := range qs {
+				if q != outer[i].name && !placed[q] {
+					ready = false
+				}
+			}
+			if ready {
+				break
+			}
+		}
+		if i == len(outer) {
+			t.diag(ctx, "the outer joins of %s depend on each other", outer[0].name)
+			i = 0
+		}
+		tbl := outer[i]
+		outer = append(outer[:i], outer[i+1:]...)
+		placed[tbl.name] = true
+		ss := make([]string, len(joins[tbl.name]))
+		for j, c := range joins[tbl.name] {
+			ss[j] = t.trim(c)
+		}
+		buf.WriteString(" LEFT JOIN " + t.trim(tbl.ref) + " ON " + strings.Join(ss, " AND "))
+	}
+	return buf.String()
+}
+
+// connectBy translates the hierarchical query to a recursive one:
+//
+//	WITH RECURSIVE hier AS (
+//	  SELECT t.*, 1 AS level FROM tbl t WHERE start_condition
+//	  UNION ALL
+//	  SELECT t.*, hier.level + 1 FROM tbl t JOIN hier ON connect_condition_with_prior_as_hier
+//	) SELECT ... FROM hier t WHERE ...
+func (t *pgTranslator) connectBy(ctx *plsql.Query_blockContext, hq *plsql.Hierarchical_query_clauseContext, tbl pgTable) string {
+	if hq.NOCYCLE() != nil {
+		t.diag(hq, "NOCYCLE is not translated, a cycle recurses infinitely")
+	}
+	aux := tbl.ref.Table_ref_aux().(*plsql.Table_ref_auxContext)
+	table := t.trim(aux.Table_ref_aux_internal())
+	alias := tbl.alias
+
+	var start string
+	if sp, ok := hq.Start_part().(*plsql.Start_partContext); ok {
+		t.hier = &pgHier{alias: alias, level: "1"}
+		start = " WHERE " + t.trim(sp.Condition())
+	}
+	t.hier = &pgHier{alias: alias, level: "(hier.level + 1)", qualify: true}
+	cond := t.trim(hq.Condition())
+	t.hier = nil
+
+	var buf strings.Builder
+	buf.WriteString(t.lead(ctx.GetStart()) + "WITH RECURSIVE hier AS (SELECT " + alias + ".*, 1 AS level FROM " + table + " " + alias + start +
+		" UNION ALL SELECT " + alias + ".*, hier.level + 1 FROM " + table + " " + alias + " JOIN hier ON " + cond + ") ")
+	var started bool
+	for _, ch := range ctx.GetChildren() {
+		var s string
+		switch x := ch.(type) {
+		case *plsql.Hierarchical_query_clauseContext:
+			continue
+		case *plsql.From_clauseContext:
+			s = t.lead(x.GetStart()) + "FROM hier " + alias
+		default:
+			s = t.text(x)
+		}
+		if !started {
+			s, started = strings.TrimLeft(s, " \t\r\n"), true
+		}
+		buf.WriteString(s)
+	}
+	return buf.String()
+}
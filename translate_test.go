@@ -0,0 +1,210 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestTranslateToPostgres(t *testing.T) {
+	for _, tc := range []struct {
+		Name, Text string
+		Want       []string
+		NotWant    []string
+	}{
+		{
+			Name: "package",
+			Text: `CREATE OR REPLACE PACKAGE BODY hr_api IS
+  PROCEDURE log_it(p_msg IN VARCHAR2) IS
+  BEGIN
+    INSERT INTO log_t (id, msg, ts) VALUES (log_seq.NEXTVAL, p_msg, SYSDATE);
+  END log_it;
+
+  FUNCTION name_of(p_id IN emp.id%TYPE) RETURN VARCHAR2 IS
+    v_row emp%ROWTYPE;
+  BEGIN
+    SELECT * INTO v_row FROM emp WHERE id = p_id;
+    log_it('found');
+    RETURN NVL(v_row.name, 'none');
+  EXCEPTION
+    WHEN NO_DATA_FOUND THEN
+      RETURN NULL;
+  END name_of;
+END hr_api;
+/
+`,
+			Want: []string{
+				"CREATE SCHEMA IF NOT EXISTS hr_api;",
+				"CREATE OR REPLACE FUNCTION hr_api.log_it(p_msg varchar) RETURNS void AS $$",
+				"VALUES (nextval('log_seq'), p_msg, LOCALTIMESTAMP(0));",
+				"END;\n$$ LANGUAGE plpgsql;",
+				"CREATE OR REPLACE FUNCTION hr_api.name_of(p_id emp.id%TYPE) RETURNS varchar AS $$\nDECLARE\n    v_row emp%ROWTYPE;",
+				"PERFORM hr_api.log_it('found');",
+				"RETURN COALESCE(v_row.name, 'none');",
+				"WHEN no_data_found THEN",
+			},
+			NotWant: []string{"END hr_api", "END log_it", "\n/"},
+		},
+
+		{
+			Name: "cursors",
+			Text: `CREATE OR REPLACE PROCEDURE raise_all(p_pct NUMBER) IS
+  CURSOR c_emp(p_dept NUMBER) IS SELECT id, salary FROM emp WHERE dept = p_dept;
+  r_emp c_emp%ROWTYPE;
+BEGIN
+  OPEN c_emp(10);
+  LOOP
+    FETCH c_emp INTO r_emp;
+    EXIT WHEN c_emp%NOTFOUND;
+  END LOOP;
+  CLOSE c_emp;
+  FOR r IN (SELECT id FROM emp WHERE ROWNUM <= 10) LOOP
+    UPDATE emp SET salary = salary * (1 + p_pct / 100) WHERE id = r.id;
+  END LOOP;
+  FOR i IN REVERSE 1..3 LOOP
+    NULL;
+  END LOOP;
+END raise_all;
+`,
+			Want: []string{
+				"CREATE OR REPLACE FUNCTION raise_all(p_pct numeric) RETURNS void AS $$",
+				"c_emp CURSOR (p_dept numeric) FOR SELECT id, salary FROM emp WHERE dept = p_dept;",
+				"r_emp record;",
+				"  r record;",
+				"EXIT WHEN NOT FOUND;",
+				"FOR r IN SELECT id FROM emp LIMIT 10 LOOP",
+				"FOR i IN REVERSE 3 .. 1 LOOP",
+			},
+		},
+
+		{
+			Name: "outer join",
+			Text: `SELECT e.name, DECODE(e.status, 'A', 'active', NULL, 'unknown', 'other') st, d.name
+  FROM emp e, dept d
+  WHERE e.dept_id = d.id(+) AND d.active(+) = 'Y' AND e.salary > 100`,
+			Want: []string{
+				"CASE WHEN e.status = 'A' THEN 'active' WHEN e.status IS NULL THEN 'unknown' ELSE 'other' END st",
+				"FROM emp e LEFT JOIN dept d ON e.dept_id = d.id AND d.active = 'Y'",
+				"\n  WHERE e.salary > 100",
+			},
+			NotWant: []string{"(+)", "DECODE"},
+		},
+
+		{
+			Name: "connect by",
+			Text: `SELECT id, name, LEVEL FROM emp e START WITH manager_id IS NULL CONNECT BY PRIOR id = manager_id ORDER BY name`,
+			Want: []string{
+				"WITH RECURSIVE hier AS (SELECT e.*, 1 AS level FROM emp e WHERE manager_id IS NULL" +
+					" UNION ALL SELECT e.*, hier.level + 1 FROM emp e JOIN hier ON hier.id = e.manager_id)" +
+					" SELECT id, name, LEVEL FROM hier e ORDER BY name",
+			},
+		},
+
+		{
+			Name: "exceptions",
+			Text: `DECLARE
+  e_bad EXCEPTION;
+  PRAGMA EXCEPTION_INIT(e_bad, -20001);
+  e_other EXCEPTION;
+BEGIN
+  IF 1 = 0 THEN
+    RAISE e_other;
+  END IF;
+  RAISE_APPLICATION_ERROR(-20001, 'bad');
+EXCEPTION
+  WHEN e_bad THEN
+    DBMS_OUTPUT.PUT_LINE(SQLERRM);
+  WHEN DUP_VAL_ON_INDEX OR ZERO_DIVIDE THEN
+    NULL;
+END;
+/
+`,
+			Want: []string{
+				"DO $$\nDECLARE\nBEGIN",
+				"RAISE SQLSTATE 'U9001';",
+				"RAISE EXCEPTION USING ERRCODE = 'U0001', MESSAGE = 'bad';",
+				"WHEN SQLSTATE 'U0001' THEN",
+				"RAISE NOTICE '%', SQLERRM;",
+				"WHEN unique_violation OR division_by_zero THEN",
+				"END;\n$$;",
+			},
+			NotWant: []string{"EXCEPTION_INIT", "e_bad EXCEPTION"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			files, ds, err := plsqlparser.TranslateToPostgres([]plsqlparser.File{{Name: tc.Name, Text: tc.Text}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range ds {
+				t.Log(d)
+			}
+			got := files[0].Text
+			for _, want := range tc.Want {
+				if !strings.Contains(got, want) {
+					t.Errorf("%q not found in\n%s", want, got)
+				}
+			}
+			for _, nw := range tc.NotWant {
+				if strings.Contains(got, nw) {
+					t.Errorf("%q found in\n%s", nw, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslateToPostgresDiagnostics(t *testing.T) {
+	_, ds, err := plsqlparser.TranslateToPostgres([]plsqlparser.File{{Name: "p.pkb", Text: `CREATE OR REPLACE PACKAGE BODY p IS
+  g_count NUMBER := 0;
+  PROCEDURE x IS
+  BEGIN
+    COMMIT;
+    DBMS_OUTPUT.PUT_LINE(SQLCODE);
+  END x;
+END p;
+`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range ds {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"p.pkb:2:3: package p: variable g_count is not translated, PostgreSQL has no package state",
+		"p.pkb:5:5: COMMIT is not allowed in a PostgreSQL function",
+		"p.pkb:6:26: SQLCODE is not translated, use SQLSTATE",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwanted\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTranslateToPostgresDiagnosticsFiles(t *testing.T) {
+	const text = `CREATE OR REPLACE PROCEDURE x IS
+BEGIN
+  COMMIT;
+END x;
+`
+	_, ds, err := plsqlparser.TranslateToPostgres([]plsqlparser.File{{Name: "a.prc", Text: text}, {Name: "b.prc", Text: text}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range ds {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"a.prc:3:3: COMMIT is not allowed in a PostgreSQL function",
+		"b.prc:3:3: COMMIT is not allowed in a PostgreSQL function",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwanted\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
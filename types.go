@@ -0,0 +1,890 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/UNO-SOFT/plsql-parser/catalog"
+	plsql "github.com/UNO-SOFT/plsql-parser/plsql"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+)
+
+// TypeKind is the kind of a static type.
+type TypeKind uint8
+
+const (
+	UnknownType = TypeKind(iota)
+	NumberType
+	CharType
+	DateType
+	TimestampType
+	IntervalType
+	BooleanType
+	BinaryType
+	RowidType
+	RecordType
+	CollectionType
+	CursorType
+	ObjectType
+)
+
+// Type is the static type of an expression or a declaration.
+type Type struct {
+	Kind TypeKind
+	// Name is the type name, such as NUMBER, VARCHAR2, PLS_INTEGER or the name of a user-defined type.
+	Name                     string
+	Length, Precision, Scale int
+	// Ref is the anchor of a %TYPE or %ROWTYPE declaration, as EMP.ENAME%TYPE,
+	// kept even after the type is resolved.
+	Ref string
+	// Fields of a record.
+	Fields []Field
+	// Elem is the element type of a collection, or the row type of a cursor.
+	Elem *Type
+}
+
+// Field of a record type.
+type Field struct {
+	Name string
+	Type Type
+}
+
+var kindNames = [...]string{
+	UnknownType: "", NumberType: "NUMBER", CharType: "VARCHAR2", DateType: "DATE",
+	TimestampType: "TIMESTAMP", IntervalType: "INTERVAL", BooleanType: "BOOLEAN", BinaryType: "RAW",
+	RowidType: "ROWID", RecordType: "RECORD", CollectionType: "TABLE", CursorType: "REF CURSOR", ObjectType: "OBJECT",
+}
+
+func (t Type) String() string {
+	name := t.Name
+	if name == "" {
+		name = kindNames[t.Kind]
+	}
+	switch t.Kind {
+	case UnknownType:
+		return t.Ref
+	case CharType, BinaryType:
+		if t.Length != 0 {
+			return fmt.Sprintf("%s(%d)", name, t.Length)
+		}
+	case NumberType:
+		if t.Precision != 0 {
+			if t.Scale != 0 {
+				return fmt.Sprintf("%s(%d,%d)", name, t.Precision, t.Scale)
+			}
+			return fmt.Sprintf("%s(%d)", name, t.Precision)
+		}
+	case RecordType:
+		if t.Name == "" {
+			if t.Ref != "" {
+				return t.Ref
+			}
+			var buf strings.Builder
+			buf.WriteString("RECORD(")
+			for i, f := range t.Fields {
+				if i != 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(f.Name + " " + f.Type.String())
+			}
+			buf.WriteByte(')')
+			return buf.String()
+		}
+	case CollectionType:
+		if t.Name == "" && t.Elem != nil {
+			return "TABLE OF " + t.Elem.String()
+		}
+	}
+	return name
+}
+
+// Field returns the type of the named field of a record.
+func (t Type) Field(name string) (Type, bool) {
+	name = catalog.Normalize(name)
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f.Type, true
+		}
+	}
+	return Type{}, false
+}
+
+// kindOf returns the kind of the named built-in type.
+func kindOf(name string) TypeKind {
+	switch name {
+	case "NUMBER", "INTEGER", "INT", "SMALLINT", "DEC", "DECIMAL", "NUMERIC", "FLOAT", "REAL", "DOUBLE PRECISION",
+		"BINARY_INTEGER", "PLS_INTEGER", "NATURAL", "NATURALN", "POSITIVE", "POSITIVEN", "SIGNTYPE", "SIMPLE_INTEGER",
+		"BINARY_FLOAT", "BINARY_DOUBLE":
+		return NumberType
+	case "VARCHAR2", "VARCHAR", "NVARCHAR2", "CHAR", "NCHAR", "CHARACTER", "STRING", "LONG", "CLOB", "NCLOB":
+		return CharType
+	case "DATE":
+		return DateType
+	case "BOOLEAN":
+		return BooleanType
+	case "RAW", "LONG RAW", "BLOB", "BFILE":
+		return BinaryType
+	case "ROWID", "UROWID":
+		return RowidType
+	}
+	switch {
+	case strings.HasPrefix(name, "TIMESTAMP"):
+		return TimestampType
+	case strings.HasPrefix(name, "INTERVAL"):
+		return IntervalType
+	}
+	return UnknownType
+}
+
+// typeOfSpec returns the (unresolved) type of the type_spec.
+func typeOfSpec(spec plsql.IType_specContext) Type {
+	ts, ok := spec.(*plsql.Type_specContext)
+	if !ok {
+		return Type{}
+	}
+	if dt := ts.Datatype(); dt != nil {
+		return typeOfDatatype(dt.(*plsql.DatatypeContext))
+	}
+	if ts.Type_name() == nil {
+		return Type{}
+	}
+	var parts []string
+	for _, id := range ts.Type_name().(*plsql.Type_nameContext).AllId_expression() {
+		parts = append(parts, catalog.Normalize(id.GetText()))
+	}
+	name := strings.Join(parts, ".")
+	switch {
+	case ts.PERCENT_TYPE() != nil:
+		return Type{Ref: name + "%TYPE"}
+	case ts.PERCENT_ROWTYPE() != nil:
+		return Type{Kind: RecordType, Ref: name + "%ROWTYPE"}
+	}
+	return Type{Kind: kindOf(name), Name: name}
+}
+
+// typeOfDatatype returns the type of a native data type.
+func typeOfDatatype(d *plsql.DatatypeContext) Type {
+	if d.INTERVAL() != nil {
+		return Type{Kind: IntervalType, Name: strings.Join(strings.Fields(intervalName(d)), " ")}
+	}
+	nde := d.Native_datatype_element().(*plsql.Native_datatype_elementContext)
+	var words []string
+	for _, ch := range nde.GetChildren() {
+		words = append(words, ch.(antlr.ParseTree).GetText())
+	}
+	t := Type{Name: strings.ToUpper(strings.Join(words, " "))}
+	if d.TIME() != nil {
+		t.Name += " WITH TIME ZONE"
+		if d.LOCAL() != nil {
+			t.Name = strings.Replace(t.Name, "WITH", "WITH LOCAL", 1)
+		}
+	}
+	t.Kind = kindOf(t.Name)
+	if pp := d.Precision_part(); pp != nil {
+		var nums []int
+		for _, ch := range pp.GetChildren() {
+			switch ch.(type) {
+			case *plsql.NumericContext, *plsql.Numeric_negativeContext:
+				n, _ := strconv.Atoi(ch.(antlr.ParseTree).GetText())
+				nums = append(nums, n)
+			case antlr.TerminalNode:
+				if ch.(antlr.TerminalNode).GetText() == "*" {
+					nums = append(nums, 0)
+				}
+			}
+		}
+		if len(nums) != 0 {
+			switch t.Kind {
+			case CharType, BinaryType:
+				t.Length = nums[0]
+			default:
+				t.Precision = nums[0]
+				if len(nums) > 1 {
+					t.Scale = nums[1]
+				}
+			}
+		}
+	}
+	return t
+}
+
+func intervalName(d *plsql.DatatypeContext) string {
+	var buf strings.Builder
+	for _, ch := range d.GetChildren() {
+		if t, ok := ch.(antlr.TerminalNode); ok {
+			buf.WriteString(strings.ToUpper(t.GetText()))
+			buf.WriteByte(' ')
+		}
+	}
+	return strings.NewReplacer("( ", "", ") ", "").Replace(buf.String())
+}
+
+// columnType returns the type of the catalog column.
+func columnType(col *catalog.Column, ref string) Type {
+	return Type{
+		Kind: kindOf(col.DataType), Name: col.DataType,
+		Length: col.Length, Precision: col.Precision, Scale: col.Scale,
+		Ref: ref,
+	}
+}
+
+// TypeInfo holds the inferred static types of the expressions of a parse tree.
+type TypeInfo struct {
+	Catalog *catalog.Catalog
+	Symbols *SymbolTable
+	// Types of the expression nodes.
+	Types map[antlr.ParserRuleContext]Type
+}
+
+// InferTypes infers the static type of each expression of the tree,
+// using the declarations of the tree, the catalog and the signatures of the built-in functions.
+//
+// The catalog may be nil.
+func InferTypes(tree antlr.Tree, cat *catalog.Catalog) *TypeInfo {
+	ti := &TypeInfo{
+		Catalog: cat, Symbols: NewSymbolTable(tree),
+		Types: make(map[antlr.ParserRuleContext]Type),
+	}
+	antlr.ParseTreeWalkerDefault.Walk(&typeListener{
+		BaseWalkListener: BaseWalkListener{DefaultErrorListener: antlr.NewDefaultErrorListener()},
+		ti:               ti,
+	}, tree)
+	return ti
+}
+
+type typeListener struct {
+	BaseWalkListener
+	ti *TypeInfo
+}
+
+func (tl *typeListener) ExitExpression(ctx *plsql.ExpressionContext)       { tl.ti.TypeOf(ctx) }
+func (tl *typeListener) ExitConcatenation(ctx *plsql.ConcatenationContext) { tl.ti.TypeOf(ctx) }
+func (tl *typeListener) ExitAtom(ctx *plsql.AtomContext)                   { tl.ti.TypeOf(ctx) }
+
+// At returns the innermost expression containing the position, with its type.
+func (ti *TypeInfo) At(pos int) (Chunk, Type, bool) {
+	var found antlr.ParserRuleContext
+	for ctx := range ti.Types {
+		if ctx.GetStart().GetStart() <= pos && pos <= ctx.GetStop().GetStop() &&
+			(found == nil || ctx.GetStop().GetStop()-ctx.GetStart().GetStart() < found.GetStop().GetStop()-found.GetStart().GetStart()) {
+			found = ctx
+		}
+	}
+	if found == nil {
+		return Chunk{}, Type{}, false
+	}
+	return ctxChunk(found), ti.Types[found], true
+}
+
+// TypeOf returns the type of the expression node.
+func (ti *TypeInfo) TypeOf(node antlr.Tree) Type {
+	ctx, ok := node.(antlr.ParserRuleContext)
+	if !ok || ctx == nil {
+		return Type{}
+	}
+	if t, ok := ti.Types[ctx]; ok {
+		return t
+	}
+	t := ti.typeOf(ctx)
+	ti.Types[ctx] = t
+	return t
+}
+
+var booleanType = Type{Kind: BooleanType}
+
+func (ti *TypeInfo) typeOf(ctx antlr.ParserRuleContext) Type {
+	switch x := ctx.(type) {
+	case *plsql.ExpressionContext:
+		if x.Cursor_expression() != nil {
+			return Type{Kind: CursorType}
+		}
+		return ti.TypeOf(x.Logical_expression())
+	case *plsql.ConditionContext:
+		return ti.TypeOf(x.Expression())
+	case *plsql.Logical_expressionContext:
+		if x.Unary_logical_expression() == nil {
+			return booleanType
+		}
+		return ti.TypeOf(x.Unary_logical_expression())
+	case *plsql.Unary_logical_expressionContext:
+		if len(x.AllNOT()) != 0 || len(x.AllIS()) != 0 {
+			return booleanType
+		}
+		return ti.TypeOf(x.Multiset_expression())
+	case *plsql.Multiset_expressionContext:
+		if x.GetMultiset_type() != nil {
+			return booleanType
+		}
+		return ti.TypeOf(x.Relational_expression())
+	case *plsql.Relational_expressionContext:
+		if x.Relational_operator() != nil {
+			return booleanType
+		}
+		return ti.TypeOf(x.Compound_expression())
+	case *plsql.Compound_expressionContext:
+		if x.GetChildCount() > 1 {
+			return booleanType
+		}
+		return ti.TypeOf(x.Concatenation(0))
+	case *plsql.ConcatenationContext:
+		return ti.concatenationType(x)
+	case *plsql.Model_expressionContext:
+		return ti.TypeOf(x.Unary_expression())
+	case *plsql.Unary_expressionContext:
+		switch {
+		case x.Unary_expression() != nil:
+			return ti.TypeOf(x.Unary_expression())
+		case x.Case_statement() != nil:
+			return ti.caseType(x.Case_statement())
+		case x.Quantified_expression() != nil:
+			if x.Quantified_expression().(*plsql.Quantified_expressionContext).EXISTS() != nil {
+				return booleanType
+			}
+			return Type{}
+		case x.Standard_function() != nil:
+			return ti.functionType(x.Standard_function())
+		}
+		return ti.TypeOf(x.Atom())
+	case *plsql.AtomContext:
+		switch {
+		case x.Table_element() != nil:
+			return ti.TypeOf(x.Table_element())
+		case x.Constant() != nil:
+			return ti.TypeOf(x.Constant())
+		case x.General_element() != nil:
+			return ti.TypeOf(x.General_element())
+		case x.Expressions() != nil:
+			if exprs := x.Expressions().(*plsql.ExpressionsContext).AllExpression(); len(exprs) == 1 {
+				return ti.TypeOf(exprs[0])
+			}
+		case x.Subquery() != nil:
+			return ti.subqueryType(x.Subquery())
+		}
+		return Type{}
+	case *plsql.ConstantContext:
+		return constantType(x)
+	case *plsql.General_elementContext:
+		return ti.generalElementType(x)
+	case *plsql.Table_elementContext:
+		var names []string
+		for _, id := range x.AllId_expression() {
+			names = append(names, catalog.Normalize(id.GetText()))
+		}
+		return ti.nameType(x, names)
+	case *plsql.Standard_functionContext:
+		return ti.functionType(x)
+	}
+	return Type{}
+}
+
+func constantType(c *plsql.ConstantContext) Type {
+	switch {
+	case c.NULL_() != nil:
+		return Type{Name: "NULL"}
+	case c.TRUE() != nil || c.FALSE() != nil:
+		return booleanType
+	case c.DATE() != nil:
+		return Type{Kind: DateType}
+	case c.TIMESTAMP() != nil:
+		return Type{Kind: TimestampType}
+	case c.INTERVAL() != nil:
+		return Type{Kind: IntervalType}
+	case c.Numeric() != nil:
+		return Type{Kind: NumberType}
+	case len(c.AllQuoted_string()) != 0:
+		qs := c.Quoted_string(0).(*plsql.Quoted_stringContext)
+		if qs.NATIONAL_CHAR_STRING_LIT() != nil {
+			return Type{Kind: CharType, Name: "NVARCHAR2", Length: len([]rune(unquote(qs.GetText()[1:])))}
+		}
+		if qs.CHAR_STRING() != nil {
+			return Type{Kind: CharType, Name: "CHAR", Length: len([]rune(unquote(qs.GetText())))}
+		}
+	}
+	return Type{}
+}
+
+func (ti *TypeInfo) concatenationType(x *plsql.ConcatenationContext) Type {
+	if x.Model_expression() != nil {
+		if x.Interval_expression() != nil {
+			return Type{Kind: IntervalType}
+		}
+		return ti.TypeOf(x.Model_expression())
+	}
+	if len(x.AllBAR()) != 0 {
+		return Type{Kind: CharType}
+	}
+	operands := x.AllConcatenation()
+	if len(operands) != 2 || x.GetOp() == nil {
+		return Type{}
+	}
+	a, b := ti.TypeOf(operands[0]), ti.TypeOf(operands[1])
+	switch x.GetOp().GetText() {
+	case "+":
+		if a.Kind == DateType || a.Kind == TimestampType {
+			return a
+		}
+		if b.Kind == DateType || b.Kind == TimestampType {
+			return b
+		}
+	case "-":
+		switch {
+		case a.Kind == DateType && b.Kind == DateType:
+			return Type{Kind: NumberType}
+		case (a.Kind == DateType || a.Kind == TimestampType) && (b.Kind == DateType || b.Kind == TimestampType):
+			return Type{Kind: IntervalType, Name: "INTERVAL DAY TO SECOND"}
+		case a.Kind == DateType || a.Kind == TimestampType:
+			return a
+		}
+	}
+	if a.Kind == IntervalType {
+		return a
+	}
+	return Type{Kind: NumberType}
+}
+
+// caseType is the type of the first non-NULL result of the CASE expression.
+func (ti *TypeInfo) caseType(cs plsql.ICase_statementContext) Type {
+	var results []antlr.Tree
+	for _, ch := range cs.GetChildren() {
+		for _, part := range ch.(antlr.Tree).GetChildren() {
+			switch p := part.(type) {
+			case *plsql.Searched_case_when_partContext:
+				results = append(results, p.Expression(1))
+			case *plsql.Simple_case_when_partContext:
+				results = append(results, p.Expression(1))
+			case *plsql.Case_else_partContext:
+				results = append(results, p.Expression())
+			}
+		}
+	}
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		if t := ti.TypeOf(r); t.Kind != UnknownType {
+			return t
+		}
+	}
+	return Type{}
+}
+
+// subqueryType is the type of the first selected column.
+func (ti *TypeInfo) subqueryType(sq plsql.ISubqueryContext) Type {
+	sbe := sq.(*plsql.SubqueryContext).Subquery_basic_elements().(*plsql.Subquery_basic_elementsContext)
+	if sbe.Subquery() != nil {
+		return ti.subqueryType(sbe.Subquery())
+	}
+	sl := sbe.Query_block().(*plsql.Query_blockContext).Selected_list().(*plsql.Selected_listContext)
+	if sle := sl.AllSelect_list_elements(); len(sle) != 0 {
+		if e := sle[0].(*plsql.Select_list_elementsContext).Expression(); e != nil {
+			return ti.TypeOf(e)
+		}
+	}
+	return Type{}
+}
+
+// generalElementType resolves a variable, record field, column, collection element or function call.
+func (ti *TypeInfo) generalElementType(x *plsql.General_elementContext) Type {
+	var names []string
+	var args []Type
+	var call bool
+	for _, p := range x.AllGeneral_element_part() {
+		p := p.(*plsql.General_element_partContext)
+		for _, id := range p.AllId_expression() {
+			names = append(names, catalog.Normalize(id.GetText()))
+		}
+		if fa := p.Function_argument(); fa != nil {
+			call = true
+			args = args[:0]
+			for _, a := range fa.(*plsql.Function_argumentContext).AllArgument() {
+				args = append(args, ti.TypeOf(a.(*plsql.ArgumentContext).Expression()))
+			}
+		}
+	}
+	if len(names) == 0 {
+		return Type{}
+	}
+	if !call {
+		return ti.nameType(x, names)
+	}
+	scope := ti.Symbols.ScopeOf(x)
+	// collection element
+	if sym := scope.Lookup(names[0]); sym != nil && len(names) == 1 {
+		switch sym.Kind {
+		case FunctionSym:
+			return ti.resolve(sym.Type, scope)
+		case VariableSym, ConstantSym, ParameterSym:
+			if t := ti.resolve(sym.Type, scope); t.Kind == CollectionType && t.Elem != nil {
+				return ti.resolve(*t.Elem, scope)
+			}
+		}
+	}
+	// package function declared in this tree
+	if len(names) == 2 {
+		if pkg := scope.Lookup(names[0]); pkg != nil && pkg.Kind == PackageSym {
+			if sym := pkg.Own.Local(names[1]); sym != nil && sym.Kind == FunctionSym {
+				return ti.resolve(sym.Type, pkg.Own)
+			}
+		}
+	}
+	name := names[len(names)-1]
+	if f := builtinFuncs[name]; f != nil && len(names) == 1 {
+		return f(args)
+	}
+	return ti.catalogFuncType(names)
+}
+
+// catalogFuncType returns the return type of the stored function from ALL_ARGUMENTS.
+func (ti *TypeInfo) catalogFuncType(names []string) Type {
+	if ti.Catalog == nil {
+		return Type{}
+	}
+	var owner, pkg string
+	object := names[len(names)-1]
+	switch len(names) {
+	case 2:
+		pkg = names[0]
+	case 3:
+		owner, pkg = names[0], names[1]
+	}
+	for _, a := range ti.Catalog.Arguments {
+		if a.Position == 0 && a.Level == 0 && a.Object == object &&
+			(a.Package == pkg || pkg != "" && a.Package == "" && a.Owner == pkg) &&
+			(owner == "" || a.Owner == owner) {
+			return Type{
+				Kind: kindOf(a.DataType), Name: a.DataType,
+				Length: a.Length, Precision: a.Precision, Scale: a.Scale,
+			}
+		}
+	}
+	return Type{}
+}
+
+// nameType resolves a dotted name to a variable, record field or column.
+func (ti *TypeInfo) nameType(ctx antlr.Tree, names []string) Type {
+	scope := ti.Symbols.ScopeOf(ctx)
+	if sym := scope.Lookup(names[0]); sym != nil {
+		switch sym.Kind {
+		case VariableSym, ConstantSym, ParameterSym:
+			t := ti.resolve(sym.Type, scope)
+			for _, f := range names[1:] {
+				var ok bool
+				if t, ok = ti.resolve(t, scope).Field(f); !ok {
+					return Type{}
+				}
+				t = ti.resolve(t, scope)
+			}
+			return t
+		case PackageSym:
+			if len(names) > 1 {
+				if v := sym.Own.Local(names[1]); v != nil && (v.Kind == VariableSym || v.Kind == ConstantSym) {
+					return ti.resolve(v.Type, sym.Own)
+				}
+			}
+		}
+	}
+	if len(names) == 1 {
+		if f := builtinFuncs[names[0]]; f != nil {
+			return f(nil)
+		}
+	}
+	// columns of the tables of the enclosing query
+	if ti.Catalog == nil {
+		return Type{}
+	}
+	col := names[len(names)-1]
+	for _, t := range queryTables(ctx) {
+		if len(names) > 1 && names[len(names)-2] != t.Alias && (t.Alias != "" || names[len(names)-2] != t.Name) {
+			continue
+		}
+		if c := ti.Catalog.Table(t.Name).Column(col); c != nil {
+			return columnType(c, t.Name+"."+c.Name+"%TYPE")
+		}
+	}
+	if len(names) > 1 {
+		tbl := strings.Join(names[:len(names)-1], ".")
+		if c := ti.Catalog.Table(tbl).Column(col); c != nil {
+			return columnType(c, tbl+"."+c.Name+"%TYPE")
+		}
+	}
+	return Type{}
+}
+
+// queryTables returns the tables (with their aliases) of the nearest enclosing query or DML statement.
+func queryTables(node antlr.Tree) []checkTable {
+	for ; node != nil; node = node.GetParent() {
+		switch x := node.(type) {
+		case *plsql.Query_blockContext:
+			var tables []checkTable
+			if fc := x.From_clause(); fc != nil {
+				collectTables(fc, &tables)
+			}
+			return tables
+		case *plsql.Update_statementContext:
+			return generalTableRef(x.General_table_ref())
+		case *plsql.Delete_statementContext:
+			return generalTableRef(x.General_table_ref())
+		}
+	}
+	return nil
+}
+
+func generalTableRef(gtr plsql.IGeneral_table_refContext) []checkTable {
+	g := gtr.(*plsql.General_table_refContext)
+	t := tableName(g.Dml_table_expression_clause())
+	if a := g.Table_alias(); a != nil {
+		t.Alias = catalog.Normalize(a.GetText())
+	}
+	return []checkTable{t}
+}
+
+func collectTables(node antlr.Tree, tables *[]checkTable) {
+	for _, ch := range node.GetChildren() {
+		switch x := ch.(type) {
+		case *plsql.Query_blockContext:
+			// subqueries have their own tables
+			continue
+		case *plsql.Table_ref_auxContext:
+			if one, ok := x.Table_ref_aux_internal().(*plsql.Table_ref_aux_internal_oneContext); ok {
+				t := tableName(one.Dml_table_expression_clause())
+				if a := x.Table_alias(); a != nil {
+					t.Alias = catalog.Normalize(a.GetText())
+				}
+				*tables = append(*tables, t)
+			}
+		}
+		collectTables(ch, tables)
+	}
+}
+
+func tableName(dml plsql.IDml_table_expression_clauseContext) checkTable {
+	var t checkTable
+	if d, ok := dml.(*plsql.Dml_table_expression_clauseContext); ok && d.Tableview_name() != nil {
+		t.Name = tableviewName(d.Tableview_name())
+	}
+	return t
+}
+
+// tableviewName returns the normalized, possibly owner-qualified name of the table.
+func tableviewName(tvn plsql.ITableview_nameContext) string {
+	tv := tvn.(*plsql.Tableview_nameContext)
+	if tv.Identifier() == nil {
+		return ""
+	}
+	name := catalog.Normalize(tv.Identifier().GetText())
+	if id := tv.Id_expression(); id != nil {
+		name += "." + catalog.Normalize(id.GetText())
+	}
+	return name
+}
+
+// resolve %TYPE, %ROWTYPE and named types.
+func (ti *TypeInfo) resolve(t Type, scope *Scope) Type {
+	for i := 0; i < 16; i++ {
+		next, ok := ti.resolveOnce(t, scope)
+		if !ok {
+			return t
+		}
+		t = next
+	}
+	return t
+}
+
+func (ti *TypeInfo) resolveOnce(t Type, scope *Scope) (Type, bool) {
+	if t.Ref != "" && (t.Kind == UnknownType || t.Kind == RecordType && t.Fields == nil) {
+		ref := t.Ref
+		i := strings.IndexByte(ref, '%')
+		names, anchor := strings.Split(ref[:i], "."), ref[i+1:]
+		if sym := scope.Lookup(names[0]); sym != nil {
+			switch sym.Kind {
+			case VariableSym, ConstantSym, ParameterSym:
+				if anchor != "TYPE" {
+					return Type{}, false
+				}
+				vt := ti.resolve(sym.Type, scope)
+				for _, f := range names[1:] {
+					var ok bool
+					if vt, ok = vt.Field(f); !ok {
+						return Type{}, false
+					}
+				}
+				vt.Ref = ref
+				return vt, true
+			case CursorSym:
+				if sym.Type.Elem != nil {
+					rt := ti.resolve(*sym.Type.Elem, scope)
+					rt.Ref = ref
+					return rt, true
+				}
+				return Type{Kind: RecordType, Ref: ref, Fields: []Field{}}, true
+			}
+		}
+		if ti.Catalog == nil {
+			return t, false
+		}
+		if anchor == "ROWTYPE" {
+			tbl := ti.Catalog.Table(ref[:i])
+			if tbl == nil {
+				return t, false
+			}
+			rt := Type{Kind: RecordType, Ref: ref, Fields: make([]Field, 0, len(tbl.Columns))}
+			for j := range tbl.Columns {
+				rt.Fields = append(rt.Fields, Field{Name: tbl.Columns[j].Name, Type: columnType(&tbl.Columns[j], "")})
+			}
+			return rt, true
+		}
+		if len(names) < 2 {
+			return t, false
+		}
+		if c := ti.Catalog.Table(strings.Join(names[:len(names)-1], ".")).Column(names[len(names)-1]); c != nil {
+			return columnType(c, ref), true
+		}
+		return t, false
+	}
+	if t.Kind == UnknownType && t.Name != "" && t.Name != "NULL" {
+		if sym := scope.Lookup(t.Name); sym != nil && sym.Kind == TypeSym {
+			return sym.Type, sym.Type.Kind != UnknownType || sym.Type.Ref != ""
+		}
+		return Type{Kind: ObjectType, Name: t.Name}, true
+	}
+	return t, false
+}
+
+// functionType returns the type of the built-in function parsed as standard_function.
+func (ti *TypeInfo) functionType(sf antlr.Tree) Type {
+	var name string
+	var args []Type
+	var spec plsql.IType_specContext
+	var visit func(antlr.Tree)
+	visit = func(node antlr.Tree) {
+		for _, ch := range node.GetChildren() {
+			switch x := ch.(type) {
+			case antlr.TerminalNode:
+				if name == "" {
+					name = strings.ToUpper(x.GetText())
+				}
+				switch x.GetSymbol().GetTokenType() {
+				case plsql.PlSqlParserPERCENT_ISOPEN, plsql.PlSqlParserPERCENT_FOUND, plsql.PlSqlParserPERCENT_NOTFOUND:
+					name = "%FOUND"
+				case plsql.PlSqlParserPERCENT_ROWCOUNT:
+					name = "%ROWCOUNT"
+				}
+			case *plsql.ExpressionContext, *plsql.ConcatenationContext, *plsql.Table_elementContext:
+				args = append(args, ti.TypeOf(x))
+			case *plsql.ExpressionsContext:
+				for _, e := range x.AllExpression() {
+					args = append(args, ti.TypeOf(e))
+				}
+			case *plsql.Standard_functionContext:
+				args = append(args, ti.TypeOf(x))
+			case *plsql.Type_specContext:
+				spec = x
+			case *plsql.Regular_idContext, *plsql.Over_clause_keywordContext, *plsql.Within_or_over_clause_keywordContext:
+				if name == "" {
+					name = strings.ToUpper(x.(antlr.ParseTree).GetText())
+				}
+			case *plsql.String_functionContext, *plsql.Numeric_function_wrapperContext, *plsql.Numeric_functionContext, *plsql.Other_functionContext,
+				*plsql.Function_argumentContext, *plsql.Function_argument_analyticContext, *plsql.ArgumentContext:
+				visit(x)
+			}
+		}
+	}
+	visit(sf)
+	switch name {
+	case "%FOUND":
+		return booleanType
+	case "%ROWCOUNT":
+		return Type{Kind: NumberType}
+	case "CAST", "XMLCAST", "TREAT":
+		return ti.resolve(typeOfSpec(spec), ti.Symbols.ScopeOf(sf))
+	}
+	if f := builtinFuncs[name]; f != nil {
+		return f(args)
+	}
+	if strings.HasPrefix(name, "XML") {
+		return Type{Kind: ObjectType, Name: "XMLTYPE"}
+	}
+	return Type{}
+}
+
+func returns(t Type) func([]Type) Type { return func([]Type) Type { return t } }
+
+// argType returns the type of the i-th argument, or the first known of the following ones.
+func argType(i int) func([]Type) Type {
+	return func(args []Type) Type {
+		for ; i < len(args); i++ {
+			if args[i].Kind != UnknownType {
+				return args[i]
+			}
+		}
+		return Type{}
+	}
+}
+
+// dateOrNumber is the type of ROUND and TRUNC: DATE for DATE arguments, NUMBER otherwise.
+func dateOrNumber(args []Type) Type {
+	if len(args) != 0 && (args[0].Kind == DateType || args[0].Kind == TimestampType) {
+		return Type{Kind: DateType}
+	}
+	return Type{Kind: NumberType}
+}
+
+// decodeType is the type of the first result of DECODE(expr, search, result, ...).
+func decodeType(args []Type) Type {
+	for i := 2; i < len(args); i += 2 {
+		if args[i].Kind != UnknownType {
+			return args[i]
+		}
+	}
+	if len(args)%2 == 0 && len(args) > 2 {
+		return args[len(args)-1]
+	}
+	return Type{}
+}
+
+var (
+	numberType    = Type{Kind: NumberType}
+	varchar2Type  = Type{Kind: CharType}
+	dateType      = Type{Kind: DateType}
+	timestampType = Type{Kind: TimestampType}
+)
+
+// builtinFuncs are the result types of the Oracle built-in functions, given the argument types.
+var builtinFuncs = map[string]func([]Type) Type{
+	"NVL": argType(0), "NVL2": argType(1), "COALESCE": argType(0), "NULLIF": argType(0),
+	"DECODE": decodeType, "GREATEST": argType(0), "LEAST": argType(0), "MAX": argType(0), "MIN": argType(0),
+	"TO_CHAR": returns(varchar2Type), "TO_NCHAR": returns(Type{Kind: CharType, Name: "NVARCHAR2"}),
+	"TO_NUMBER": returns(numberType), "TO_DATE": returns(dateType),
+	"TO_TIMESTAMP": returns(timestampType), "TO_TIMESTAMP_TZ": returns(Type{Kind: TimestampType, Name: "TIMESTAMP WITH TIME ZONE"}),
+	"TO_CLOB": returns(Type{Kind: CharType, Name: "CLOB"}), "TO_BLOB": returns(Type{Kind: BinaryType, Name: "BLOB"}),
+	"SYSDATE": returns(dateType), "CURRENT_DATE": returns(dateType),
+	"SYSTIMESTAMP":      returns(Type{Kind: TimestampType, Name: "TIMESTAMP WITH TIME ZONE"}),
+	"CURRENT_TIMESTAMP": returns(Type{Kind: TimestampType, Name: "TIMESTAMP WITH TIME ZONE"}),
+	"LOCALTIMESTAMP":    returns(timestampType),
+	"ADD_MONTHS":        returns(dateType), "LAST_DAY": returns(dateType), "NEXT_DAY": returns(dateType),
+	"MONTHS_BETWEEN": returns(numberType), "EXTRACT": returns(numberType),
+	"ROUND": dateOrNumber, "TRUNC": dateOrNumber,
+	"SUBSTR": returns(varchar2Type), "UPPER": returns(varchar2Type), "LOWER": returns(varchar2Type),
+	"INITCAP": returns(varchar2Type), "TRIM": returns(varchar2Type), "LTRIM": returns(varchar2Type),
+	"RTRIM": returns(varchar2Type), "LPAD": returns(varchar2Type), "RPAD": returns(varchar2Type),
+	"REPLACE": returns(varchar2Type), "TRANSLATE": returns(varchar2Type), "CONCAT": returns(varchar2Type),
+	"CHR": returns(varchar2Type), "REGEXP_SUBSTR": returns(varchar2Type), "REGEXP_REPLACE": returns(varchar2Type),
+	"LISTAGG": returns(varchar2Type), "USER": returns(varchar2Type), "SYS_CONTEXT": returns(varchar2Type),
+	"SQLERRM": returns(varchar2Type), "RAWTOHEX": returns(varchar2Type), "DUMP": returns(varchar2Type),
+	"LENGTH": returns(numberType), "INSTR": returns(numberType), "REGEXP_INSTR": returns(numberType),
+	"REGEXP_COUNT": returns(numberType), "ASCII": returns(numberType),
+	"ABS": returns(numberType), "CEIL": returns(numberType), "FLOOR": returns(numberType), "MOD": returns(numberType),
+	"REMAINDER": returns(numberType), "POWER": returns(numberType), "SQRT": returns(numberType), "EXP": returns(numberType),
+	"LN": returns(numberType), "LOG": returns(numberType), "SIGN": returns(numberType),
+	"COUNT": returns(numberType), "SUM": returns(numberType), "AVG": returns(numberType),
+	"ROW_NUMBER": returns(numberType), "RANK": returns(numberType), "DENSE_RANK": returns(numberType),
+	"SQLCODE": returns(numberType), "UID": returns(numberType),
+	"HEXTORAW": returns(Type{Kind: BinaryType}), "SYS_GUID": returns(Type{Kind: BinaryType}),
+	"REGEXP_LIKE": returns(booleanType),
+	"FIRST_VALUE": argType(0), "LAST_VALUE": argType(0), "LAG": argType(0), "LEAD": argType(0),
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Tamás Gulácsi. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plsqlparser_test
+
+import (
+	"strings"
+	"testing"
+
+	plsqlparser "github.com/UNO-SOFT/plsql-parser"
+)
+
+func TestInferTypes(t *testing.T) {
+	const src = `DECLARE
+  v_num NUMBER(10,2);
+  v_name emp.ename%TYPE;
+  v_d DATE;
+BEGIN
+  v_d := SYSDATE + 1;
+  v_name := NVL(v_name, 'x');
+  v_num := TO_NUMBER('1') * 2;
+  IF v_num > 0 THEN NULL; END IF;
+END;`
+	tree := plsqlparser.NewPlSqlLexerParser(strings.ToUpper(src)).Sql_script()
+	ti := plsqlparser.InferTypes(tree, testCatalog)
+	for text, want := range map[string]string{
+		"SYSDATE+1":        "DATE",
+		"NVL(V_NAME,'X')":  "VARCHAR2(10)",
+		"TO_NUMBER('1')*2": "NUMBER",
+		"V_NUM":            "NUMBER(10,2)",
+		"V_NUM>0":          "BOOLEAN",
+	} {
+		var found bool
+		for ctx, typ := range ti.Types {
+			if ctx.GetText() != text {
+				continue
+			}
+			found = true
+			if got := typ.String(); got != want {
+				t.Errorf("%s: got %q, wanted %q", text, got, want)
+			}
+		}
+		if !found {
+			t.Errorf("%s: not found", text)
+		}
+	}
+
+	pos := strings.Index(src, "v_name, ") + 1
+	if _, typ, ok := ti.At(pos); !ok || typ.Ref != "EMP.ENAME%TYPE" {
+		t.Errorf("At(%d): got %+v", pos, typ)
+	}
+}